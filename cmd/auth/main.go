@@ -0,0 +1,194 @@
+// Сервис авторизации: регистрация, вход, обновление и проверка токенов для всех сервисов CraftPlace.
+// HTTP API - на APP_PORT (те же /api/v1/auth-user/..., что и в cmd/dev, и /.well-known/jwks.json),
+// gRPC API - на AUTH_GRPC_PORT для других сервисов (cmd/dev с AUTH_MODE=remote). gRPC и introspect
+// принимают только вызовы с AUTH_SERVICE_TOKEN.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api"
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	engine := gin.New()
+	// Настройка CORS
+	engine.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"*"},
+		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+	engine.OPTIONS("/*any", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusNoContent)
+	})
+	engine.Use(gin.Logger())
+	engine.Use(gin.Recovery())
+
+	// ----- Config ------
+	appCnfg, err := cnfg.LoadAppConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	if err := appCnfg.RequireTokenKeys(); err != nil {
+		panic(err.Error())
+	}
+	dbCnfg, err := cnfg.LoadDatabaseConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	notifyCnfg, err := cnfg.LoadNotifyConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	throttleCnfg, err := cnfg.LoadThrottleConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	passwordCnfg, err := cnfg.LoadPasswordConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	authCnfg, err := cnfg.LoadAuthConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	if err := engine.SetTrustedProxies(appCnfg.TrustedProxies); err != nil {
+		panic(err.Error())
+	}
+	// -------------------
+
+	// ----- Repositories -----
+	var (
+		userRep    userrep.UserRep
+		shopRep    shoprep.ShopRep
+		followRep  followrep.FollowRep
+		refreshRep refreshtokenrep.RefreshTokenRep
+		revokedRep revokedtokenrep.RevokedTokenRep
+		tokenRep   usertokenrep.UserTokenRep
+		attemptRep loginattemptrep.LoginAttemptRep
+		mfaRep     mfarep.MFARep
+	)
+	switch appCnfg.StorageType {
+	case cnfg.StorageMemory:
+		db := memdb.NewDB()
+		userRep = userrep.NewMemUserRep(db)
+		shopRep = shoprep.NewMemShopRep(db)
+		followRep = followrep.NewMemFollowRep(db)
+		refreshRep = refreshtokenrep.NewMemRefreshTokenRep(db)
+		revokedRep = revokedtokenrep.NewMemRevokedTokenRep(db)
+		tokenRep = usertokenrep.NewMemUserTokenRep(db)
+		attemptRep = loginattemptrep.NewMemLoginAttemptRep(db)
+		mfaRep = mfarep.NewMemMFARep(db)
+	default:
+		pool, err := pgdb.NewPool(context.Background(), dbCnfg)
+		if err != nil {
+			panic(err.Error())
+		}
+		defer pool.Close()
+		userRep = userrep.NewPgUserRep(pool)
+		shopRep = shoprep.NewPgShopRep(pool)
+		followRep = followrep.NewPgFollowRep(pool)
+		refreshRep = refreshtokenrep.NewPgRefreshTokenRep(pool)
+		revokedRep = revokedtokenrep.NewPgRevokedTokenRep(pool)
+		tokenRep = usertokenrep.NewPgUserTokenRep(pool)
+		attemptRep = loginattemptrep.NewPgLoginAttemptRep(pool)
+		mfaRep = mfarep.NewPgMFARep(pool)
+	}
+	// ------------------------
+
+	// ----- Services -----
+	mailSender, err := notifier.NewSender(notifyCnfg)
+	if err != nil {
+		panic(err.Error())
+	}
+	// письма сервиса авторизации - только подтверждение почты и сброс пароля
+	notifierServ, err := notifier.NewNotifier(notifyCnfg, mailSender, userRep, shopRep, followRep)
+	if err != nil {
+		panic(err.Error())
+	}
+	defer notifierServ.Close()
+	tokenMaker, err := tokenmaker.NewTokenMaker(appCnfg)
+	if err != nil {
+		panic(err.Error())
+	}
+	hasher, err := hasher.NewHasher(passwordCnfg)
+	if err != nil {
+		panic(err.Error())
+	}
+	passwordPolicy := passwordpolicy.NewPasswordPolicy(passwordCnfg)
+	mfaServ := mfa.NewMFA(appCnfg, mfaRep, userRep)
+	authUser, err := authuser.NewAuthUser(
+		appCnfg, userRep, refreshRep, revokedRep, tokenRep, tokenMaker, hasher, passwordPolicy, notifierServ, mfaServ,
+	)
+	if err != nil {
+		panic(err.Error())
+	}
+	loginThrottle := loginthrottle.NewLoginThrottle(throttleCnfg, attemptRep)
+	authUser = authuser.NewThrottledAuthUser(authUser, loginThrottle)
+	authZ, err := auth.NewAuthZ()
+	if err != nil {
+		panic(err.Error())
+	}
+	// --------------------
+
+	// ----- gRPC -----
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", authCnfg.GRPCPort))
+	if err != nil {
+		panic(err.Error())
+	}
+	grpcServer, err := api.NewAuthGRPCServer(authUser, authCnfg.ServiceToken)
+	if err != nil {
+		panic(fmt.Sprintf("config AUTH_SERVICE_TOKEN: %s", err.Error()))
+	}
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			panic(err.Error())
+		}
+	}()
+	defer grpcServer.GracefulStop()
+	// ----------------
+
+	// ----- Groups -----
+	apiGroup := engine.Group("/api/v1")
+	authMiddleware := api.NewAuthMiddleware(authUser, authZ)
+	// ------------------
+	authUserRouter := api.NewAuthUserRouter(apiGroup, authUser, authZ, loginThrottle, mfaServ, authMiddleware)
+	_ = authUserRouter
+	introspectRouter, err := api.NewIntrospectRouter(apiGroup, authUser, authCnfg.ServiceToken)
+	if err != nil {
+		panic(err.Error())
+	}
+	_ = introspectRouter
+	jwksRouter := api.NewJWKSRouter(&engine.RouterGroup, tokenMaker)
+	_ = jwksRouter
+
+	engine.Run(fmt.Sprintf(":%d", appCnfg.Port))
+}
@@ -3,19 +3,55 @@
 // @description API для платформы для мастеров ручной работы
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	_ "github.com/CakeForKit/CraftPlace.git/docs"
 	"github.com/CakeForKit/CraftPlace.git/internal/api"
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	authclient "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_client"
 	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
 	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
 	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	categoryservice "github.com/CakeForKit/CraftPlace.git/internal/services/category_service"
+	followservice "github.com/CakeForKit/CraftPlace.git/internal/services/follow_service"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	postservice "github.com/CakeForKit/CraftPlace.git/internal/services/post_service"
+	productservice "github.com/CakeForKit/CraftPlace.git/internal/services/product_service"
+	searchservice "github.com/CakeForKit/CraftPlace.git/internal/services/search_service"
 	"github.com/CakeForKit/CraftPlace.git/internal/services/searcher"
+	shopservice "github.com/CakeForKit/CraftPlace.git/internal/services/shop_service"
+	userselfservice "github.com/CakeForKit/CraftPlace.git/internal/services/user_self_service"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
@@ -30,7 +66,7 @@ func main() {
 		AllowOrigins:     []string{"*"}, // Можно указать конкретные домены вместо "*"
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -41,35 +77,205 @@ func main() {
 	engine.Use(gin.Recovery())
 
 	// ----- Config ------
-	appCnfg_Port := 8080
+	appCnfg, err := cnfg.LoadAppConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	dbCnfg, err := cnfg.LoadDatabaseConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	mediaCnfg, err := cnfg.LoadMediaConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	notifyCnfg, err := cnfg.LoadNotifyConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	throttleCnfg, err := cnfg.LoadThrottleConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	passwordCnfg, err := cnfg.LoadPasswordConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	authCnfg, err := cnfg.LoadAuthConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	// сервис авторизации хранит пользователей у себя, поэтому общие данные возможны только в одной базе postgres
+	if authCnfg.Mode == cnfg.AuthRemote && appCnfg.StorageType == cnfg.StorageMemory {
+		panic("config AUTH_MODE: remote auth requires STORAGE_TYPE=postgres")
+	}
+	// адрес клиента для ограничения попыток входа берется из X-Forwarded-For только от доверенных прокси
+	if err := engine.SetTrustedProxies(appCnfg.TrustedProxies); err != nil {
+		panic(err.Error())
+	}
 	// -------------------
 
 	// для Swagger - НЕ ТРОГАТЬ
-	url := ginSwagger.URL(fmt.Sprintf("http://localhost:%d/swagger/doc.json", appCnfg_Port))
+	url := ginSwagger.URL(fmt.Sprintf("http://localhost:%d/swagger/doc.json", appCnfg.Port))
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
+	// ----- Repositories -----
+	var (
+		userRep     userrep.UserRep
+		shopRep     shoprep.ShopRep
+		productRep  productrep.ProductRep
+		postRep     postrep.PostRep
+		categoryRep categoryrep.CategoryRep
+		refreshRep  refreshtokenrep.RefreshTokenRep
+		revokedRep  revokedtokenrep.RevokedTokenRep
+		tokenRep    usertokenrep.UserTokenRep
+		attemptRep  loginattemptrep.LoginAttemptRep
+		mfaRep      mfarep.MFARep
+		imageRep    imagerep.ImageRep
+		searchRep   searchrep.SearchRep
+		followRep   followrep.FollowRep
+	)
+	switch appCnfg.StorageType {
+	case cnfg.StorageMemory:
+		db := memdb.NewDB()
+		userRep = userrep.NewMemUserRep(db)
+		shopRep = shoprep.NewMemShopRep(db)
+		productRep = productrep.NewMemProductRep(db)
+		postRep = postrep.NewMemPostRep(db)
+		categoryRep = categoryrep.NewMemCategoryRep(db)
+		refreshRep = refreshtokenrep.NewMemRefreshTokenRep(db)
+		revokedRep = revokedtokenrep.NewMemRevokedTokenRep(db)
+		tokenRep = usertokenrep.NewMemUserTokenRep(db)
+		attemptRep = loginattemptrep.NewMemLoginAttemptRep(db)
+		mfaRep = mfarep.NewMemMFARep(db)
+		imageRep = imagerep.NewMemImageRep(db)
+		searchRep = searchrep.NewMemSearchRep(db)
+		followRep = followrep.NewMemFollowRep(db)
+	default:
+		pool, err := pgdb.NewPool(context.Background(), dbCnfg)
+		if err != nil {
+			panic(err.Error())
+		}
+		defer pool.Close()
+		userRep = userrep.NewPgUserRep(pool)
+		shopRep = shoprep.NewPgShopRep(pool)
+		productRep = productrep.NewPgProductRep(pool)
+		postRep = postrep.NewPgPostRep(pool)
+		categoryRep = categoryrep.NewPgCategoryRep(pool)
+		refreshRep = refreshtokenrep.NewPgRefreshTokenRep(pool)
+		revokedRep = revokedtokenrep.NewPgRevokedTokenRep(pool)
+		tokenRep = usertokenrep.NewPgUserTokenRep(pool)
+		attemptRep = loginattemptrep.NewPgLoginAttemptRep(pool)
+		mfaRep = mfarep.NewPgMFARep(pool)
+		imageRep = imagerep.NewPgImageRep(pool)
+		searchRep = searchrep.NewPgSearchRep(pool)
+		followRep = followrep.NewPgFollowRep(pool)
+	}
+	mediaStorage, err := mediastorage.NewMediaStorage(mediaCnfg)
+	if err != nil {
+		panic(err.Error())
+	}
+	if mediaCnfg.StorageType == cnfg.MediaLocal {
+		// локальные файлы раздает сам сервер, S3 отдает их по своему адресу
+		engine.Static(mediaCnfg.BaseURL, mediaCnfg.LocalDir)
+	}
+	// ------------------------
+
 	// ----- Services -----
-	tokenMaker, err := tokenmaker.NewTokenMaker("12345678901234567890123456789012")
+	mailSender, err := notifier.NewSender(notifyCnfg)
 	if err != nil {
 		panic(err.Error())
 	}
-	hasher, err := hasher.NewHasher()
+	notifierServ, err := notifier.NewNotifier(notifyCnfg, mailSender, userRep, shopRep, followRep)
 	if err != nil {
 		panic(err.Error())
 	}
-	authUser := authuser.NewAuthUser(tokenMaker, hasher)
-	searcherServ := searcher.NewSearcher()
+	defer notifierServ.Close()
+	hasher, err := hasher.NewHasher(passwordCnfg)
+	if err != nil {
+		panic(err.Error())
+	}
+	passwordPolicy := passwordpolicy.NewPasswordPolicy(passwordCnfg)
+	mfaServ := mfa.NewMFA(appCnfg, mfaRep, userRep)
+	loginThrottle := loginthrottle.NewLoginThrottle(throttleCnfg, attemptRep)
+	var (
+		authUser   authuser.AuthUser
+		tokenMaker tokenmaker.TokenMaker
+	)
+	switch authCnfg.Mode {
+	case cnfg.AuthRemote:
+		// токены выпускает и проверяет cmd/auth, попытки входа ограничивает тоже он
+		authConn, err := authclient.Dial(authCnfg.GRPCAddr)
+		if err != nil {
+			panic(err.Error())
+		}
+		defer authConn.Close()
+		authUser = authclient.NewAuthClient(authConn, authCnfg.Timeout, authCnfg.ServiceToken)
+	default:
+		if err := appCnfg.RequireTokenKeys(); err != nil {
+			panic(err.Error())
+		}
+		tokenMaker, err = tokenmaker.NewTokenMaker(appCnfg)
+		if err != nil {
+			panic(err.Error())
+		}
+		authUser, err = authuser.NewAuthUser(
+			appCnfg, userRep, refreshRep, revokedRep, tokenRep, tokenMaker, hasher, passwordPolicy, notifierServ, mfaServ,
+		)
+		if err != nil {
+			panic(err.Error())
+		}
+		authUser = authuser.NewThrottledAuthUser(authUser, loginThrottle)
+	}
+	authZ, err := auth.NewAuthZ()
+	if err != nil {
+		panic(err.Error())
+	}
+	searcherServ := searcher.NewSearcher(categoryRep, shopRep, postRep, productRep, searchRep, appCnfg.SuggestTimeout)
+	userSelfServ := userselfservice.NewUserSelfServ(authZ, authUser, userRep, hasher, passwordPolicy, notifierServ)
+	ownerPolicy := ownerpolicy.NewOwnerPolicy(authZ, shopRep, productRep, postRep)
+	imageCleaner := mediaservice.NewImageCleaner(imageRep, mediaStorage)
+	shopServ := shopservice.NewShopServ(authZ, shopRep, ownerPolicy, userRep, imageCleaner)
+	productServ := productservice.NewProductServ(productRep, ownerPolicy, imageCleaner)
+	postServ := postservice.NewPostServ(postRep, ownerPolicy, notifierServ, imageCleaner)
+	categoryServ := categoryservice.NewCategoryServ(authZ, categoryRep)
+	mediaServ := mediaservice.NewMediaServ(imageRep, mediaStorage, ownerPolicy, mediaCnfg.MaxUploadSize)
+	searchServ := searchservice.NewSearchServ(searchRep)
+	followServ := followservice.NewFollowServ(authZ, followRep, searcherServ)
 	// --------------------
 
 	// ----- Groups -----
 	apiGroup := engine.Group("/api/v1")
+	userGroup := apiGroup.Group("/user")
+	authMiddleware := api.NewAuthMiddleware(authUser, authZ)
 	// ------------------
-	searcherRouter := api.NewSearcherRouter(apiGroup, searcherServ)
+	searcherRouter := api.NewSearcherRouter(apiGroup, searcherServ, mediaServ, followServ)
 	_ = searcherRouter
-	authUserRouter := api.NewAuthUserRouter(apiGroup, authUser)
+	searchRouter := api.NewSearchRouter(apiGroup, searchServ)
+	_ = searchRouter
+	authUserRouter := api.NewAuthUserRouter(apiGroup, authUser, authZ, loginThrottle, mfaServ, authMiddleware)
 	_ = authUserRouter
-	// userSelfRouter := api.NewUserSelfRouter(apiGroup)
-	// shopRouter := api.NewShopRouter()
+	userSelfRouter := api.NewUserSelfRouter(
+		apiGroup, userSelfServ, authZ, searcherServ, shopServ, productServ, postServ, authMiddleware,
+	)
+	_ = userSelfRouter
+	shopRouter := api.NewShopRouter(userGroup, shopServ, authMiddleware)
+	_ = shopRouter
+	productRouter := api.NewProductRouter(userGroup, productServ, authMiddleware)
+	_ = productRouter
+	postRouter := api.NewPostRouter(userGroup, postServ, authMiddleware)
+	_ = postRouter
+	categoryRouter := api.NewCategoryRouter(apiGroup, categoryServ, authMiddleware)
+	_ = categoryRouter
+	mediaRouter := api.NewMediaRouter(userGroup, mediaServ, mediaCnfg.MaxUploadSize, authMiddleware)
+	_ = mediaRouter
+	followRouter := api.NewFollowRouter(userGroup, followServ, mediaServ, authMiddleware)
+	_ = followRouter
+	if tokenMaker != nil {
+		// при AUTH_MODE=remote ключи публикует cmd/auth
+		jwksRouter := api.NewJWKSRouter(&engine.RouterGroup, tokenMaker)
+		_ = jwksRouter
+	}
 
-	engine.Run(fmt.Sprintf(":%d", appCnfg_Port))
+	engine.Run(fmt.Sprintf(":%d", appCnfg.Port))
 }
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const usage = `Использование: migrator <команда> [флаги]
+
+Команды:
+  up              применить все новые миграции
+  down [-steps N] откатить N последних миграций (по умолчанию 1)
+  status          показать текущую версию и список миграций
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	dbCnfg, err := cnfg.LoadDatabaseConfig()
+	if err != nil {
+		exitErr(err)
+	}
+	m, err := pgdb.NewMigrator(dbCnfg)
+	if err != nil {
+		exitErr(err)
+	}
+	defer m.Close()
+
+	switch os.Args[1] {
+	case "up":
+		err = up(m)
+	case "down":
+		err = down(m, os.Args[2:])
+	case "status":
+		err = status(m)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		exitErr(err)
+	}
+}
+
+func up(m *migrate.Migrate) error {
+	err := m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no new migrations")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return status(m)
+}
+
+func down(m *migrate.Migrate, args []string) error {
+	fs := flag.NewFlagSet("down", flag.ExitOnError)
+	steps := fs.Int("steps", 1, "количество откатываемых миграций")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", *steps)
+	}
+
+	err := m.Steps(-*steps)
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("nothing to roll back")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return status(m)
+}
+
+func status(m *migrate.Migrate) error {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		version, dirty = 0, false
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Printf("current version: %d (dirty: %t)\n", version, dirty)
+	list, err := pgdb.MigrationsStatus(version)
+	if err != nil {
+		return err
+	}
+	for _, s := range list {
+		mark := "pending"
+		if s.Applied {
+			mark = "applied"
+		}
+		fmt.Printf("  %06d  %s\n", s.Version, mark)
+	}
+	return nil
+}
+
+func exitErr(err error) {
+	fmt.Fprintln(os.Stderr, "migrator:", err)
+	os.Exit(1)
+}
@@ -15,114 +15,156 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth-user/login": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Набор JWK (RFC 7517) для проверки подписи токенов доступа другими сервисами.\nДля jwt_eddsa и jwt_es256 - все не истекшие ключи, для jwt и PASETO набор пуст.\nОтдается по корню сервера, вне /api/v1; ответ можно кэшировать 5 минут.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Открытые ключи подписи токенов",
+                "responses": {
+                    "200": {
+                        "description": "Открытые ключи",
+                        "schema": {
+                            "$ref": "#/definitions/tokenmaker.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth-user/forgot-password": {
             "post": {
-                "description": "Аутентифицирует пользователя и возвращает токен доступа",
+                "description": "Отправляет на почту ссылку для сброса пароля. Ответ не зависит от того,\nзарегистрирована ли почта, чтобы по нему нельзя было проверить наличие аккаунта",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "аутентификация"
                 ],
-                "summary": "Вход пользователя",
+                "summary": "Запрос на сброс пароля",
                 "parameters": [
                     {
-                        "description": "Учетные данные для входа",
+                        "description": "Почта аккаунта",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.LoginUserRequest"
+                            "$ref": "#/definitions/reqresp.ForgotPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь успешно аутентифицирован"
+                        "description": "Запрос принят"
                     },
                     "400": {
                         "description": "Неверные входные параметры"
-                    },
-                    "401": {
-                        "description": "Ошибка аутентификации"
                     }
                 }
             }
         },
-        "/auth-user/register": {
+        "/auth-user/introspect": {
             "post": {
-                "description": "Регистрирует нового пользователя",
+                "description": "Только в сервисе авторизации (cmd/auth). Проверяет подпись, срок и отзыв токена доступа.\nНедействительный токен - не ошибка: ответ 200 с active=false.\nВызывающий сервис передает AUTH_SERVICE_TOKEN в заголовке Authorization: Bearer",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "аутентификация"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Проверка токена доступа",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
+                        "type": "string",
+                        "description": "Bearer токен сервиса",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Токен доступа",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.RegisterUserRequest"
+                            "$ref": "#/definitions/reqresp.IntrospectRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь зарегистрирован"
+                        "description": "Состояние токена",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.IntrospectResponse"
+                        }
                     },
                     "400": {
                         "description": "Неверные входные параметры"
                     },
                     "401": {
-                        "description": "Ошибка аутентификации"
+                        "description": "Нет токена сервиса или он неверный"
                     },
-                    "409": {
-                        "description": "Попытка повторной регистрации"
+                    "500": {
+                        "description": "Внутренняя ошибка сервера"
                     }
                 }
             }
         },
-        "/categories": {
+        "/auth-user/lockouts": {
             "get": {
-                "description": "Возвращает список категорий с возможностью фильтрации",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Последние блокировки входа по логину или IP-адресу после серии неудачных попыток, новые первыми",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "аутентификация"
                 ],
-                "summary": "Получить категории",
+                "summary": "Журнал блокировок входа",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Фильтр по названию категории",
-                        "name": "title",
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Число записей, не больше 500",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Журнал блокировок",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/reqresp.CategoryResponse"
+                                "$ref": "#/definitions/reqresp.LoginLockoutResponse"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Неверные параметры"
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    },
+                    "403": {
+                        "description": "Доступно только администратору"
                     }
                 }
             }
         },
-        "/categories/{id_category}": {
-            "get": {
-                "description": "Возвращает информацию о категории по её идентификатору",
+        "/auth-user/login": {
+            "post": {
+                "description": "Аутентифицирует пользователя и возвращает токен доступа и refresh-токен.\nПосле нескольких неудачных попыток подряд по логину или с одного адреса вход временно запрещается.\nЕсли у пользователя включен второй фактор, вместо токенов возвращается mfa_token для /auth-user/mfa",
                 "consumes": [
                     "application/json"
                 ],
@@ -130,102 +172,108 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "аутентификация"
                 ],
-                "summary": "Получить категорию по ID",
+                "summary": "Вход пользователя",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "ID категории",
-                        "name": "id_category",
-                        "in": "path",
-                        "required": true
+                        "description": "Учетные данные для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.LoginUserRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация о категории",
+                        "description": "Пользователь успешно аутентифицирован",
                         "schema": {
-                            "$ref": "#/definitions/reqresp.CategoryResponse"
+                            "$ref": "#/definitions/reqresp.LoginUserResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID категории",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "Неверные входные параметры"
                     },
-                    "404": {
-                        "description": "Категория не найдена",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    "401": {
+                        "description": "Ошибка аутентификации"
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                    "429": {
+                        "description": "Слишком много неудачных попыток, повторить через Retry-After секунд",
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Через сколько секунд можно повторить попытку"
+                            }
                         }
                     }
                 }
             }
         },
-        "/posts": {
-            "get": {
-                "description": "Возвращает список постов с возможностью фильтрации по магазину",
-                "consumes": [
-                    "application/json"
+        "/auth-user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
-                "produces": [
+                "description": "Отзывает токен доступа из заголовка. Если передан refresh-токен, отзывается и он вместе со всеми его предшественниками",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "аутентификация"
                 ],
-                "summary": "Получить посты",
+                "summary": "Выход из текущей сессии",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Фильтр по ID магазина",
-                        "name": "id_shop",
-                        "in": "query"
+                        "description": "Refresh-токен текущей сессии",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.LogoutRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список постов",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/reqresp.PostResponse"
-                            }
-                        }
+                        "description": "Сессия завершена"
                     },
                     "400": {
-                        "description": "Неверный формат ID магазина",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "Неверные входные параметры"
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    "401": {
+                        "description": "Пользователь не авторизован или refresh-токен принадлежит другому пользователю"
                     }
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "Возвращает список товаров с возможностью фильтрации по различным параметрам",
+        "/auth-user/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отзывает все токены доступа и refresh-токены пользователя, выпущенные до этого момента",
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Выход со всех устройств",
+                "responses": {
+                    "200": {
+                        "description": "Все сессии завершены"
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    }
+                }
+            }
+        },
+        "/auth-user/mfa": {
+            "post": {
+                "description": "Обменивает mfa_token из ответа на вход и код приложения-аутентификатора или код восстановления\nна токен доступа и refresh-токен. mfa_token одноразовый, неверные коды ограничиваются как неверные пароли",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,119 +281,1730 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "аутентификация"
                 ],
-                "summary": "Получить товары",
+                "summary": "Второй шаг входа",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Фильтр по названию товара",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Минимальная цена товара",
-                        "name": "min_cost",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 100000,
-                        "description": "Максимальная цена товара",
-                        "name": "max_cost",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "default": "00000000-0000-0000-0000-000000000000",
-                        "description": "Фильтр по ID магазина",
-                        "name": "id_shop",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "default": "00000000-0000-0000-0000-000000000000",
-                        "description": "Фильтр по ID категории",
-                        "name": "id_category",
-                        "in": "query"
+                        "description": "Токен второго шага и код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.CompleteMFARequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список товаров",
+                        "description": "Пользователь успешно аутентифицирован",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/reqresp.ProductResponse"
-                            }
+                            "$ref": "#/definitions/reqresp.LoginUserResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат параметров",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "Неверные входные параметры"
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                    "401": {
+                        "description": "Токен второго шага недействителен или неверный код"
+                    },
+                    "429": {
+                        "description": "Слишком много неудачных попыток, повторить через Retry-After секунд",
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Через сколько секунд можно повторить попытку"
+                            }
                         }
                     }
                 }
             }
         },
-        "/shops": {
-            "get": {
-                "description": "Возвращает список магазинов с возможностью фильтрации",
-                "consumes": [
-                    "application/json"
+        "/auth-user/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
-                "produces": [
+                "description": "Включает второй фактор, если передан текущий код из приложения-аутентификатора",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "аутентификация"
                 ],
-                "summary": "Получить магазины",
+                "summary": "Включение второго фактора",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Фильтр по названию магазина",
-                        "name": "title",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "default": "00000000-0000-0000-0000-000000000000",
-                        "description": "Фильтр по ID пользователя",
-                        "name": "id_user",
-                        "in": "query"
+                        "description": "Код из приложения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.MFACodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
+                        "description": "Второй фактор включен"
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры или неверный код"
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    },
+                    "409": {
+                        "description": "Настройка не начата или второй фактор уже включен"
+                    }
+                }
+            }
+        },
+        "/auth-user/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Выключает второй фактор по коду из приложения или коду восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Выключение второго фактора",
+                "parameters": [
+                    {
+                        "description": "Код из приложения или код восстановления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Второй фактор выключен"
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры или неверный код"
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    },
+                    "409": {
+                        "description": "Второй фактор не включен"
+                    }
+                }
+            }
+        },
+        "/auth-user/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает секрет TOTP и коды восстановления. provisioning_uri нужно показать пользователю QR-кодом\nдля приложения-аутентификатора, коды восстановления показываются только в этом ответе.\nВторой фактор начнет действовать после подтверждения кодом из приложения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Настройка второго фактора",
+                "responses": {
+                    "200": {
+                        "description": "Данные для приложения-аутентификатора",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.MFAEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    },
+                    "409": {
+                        "description": "Второй фактор уже включен"
+                    }
+                }
+            }
+        },
+        "/auth-user/refresh": {
+            "post": {
+                "description": "Обменивает refresh-токен на новую пару токенов. Каждый refresh-токен одноразовый:\nповторное использование уже обмененного токена отзывает все токены, выданные при этом входе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Обновление токенов",
+                "parameters": [
+                    {
+                        "description": "Refresh-токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Токены обновлены",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.LoginUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры"
+                    },
+                    "401": {
+                        "description": "Refresh-токен недействителен, истек или использован повторно"
+                    }
+                }
+            }
+        },
+        "/auth-user/register": {
+            "post": {
+                "description": "Регистрирует нового пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь зарегистрирован"
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры или пароль не соответствует требованиям"
+                    },
+                    "401": {
+                        "description": "Ошибка аутентификации"
+                    },
+                    "409": {
+                        "description": "Логин или почта уже заняты"
+                    }
+                }
+            }
+        },
+        "/auth-user/reset-password": {
+            "post": {
+                "description": "Задает новый пароль по токену из письма и завершает все сессии пользователя. Токен одноразовый",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Сброс пароля",
+                "parameters": [
+                    {
+                        "description": "Токен из письма и новый пароль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пароль изменен"
+                    },
+                    "400": {
+                        "description": "Неверные входные параметры, токен недействителен или пароль не соответствует требованиям"
+                    }
+                }
+            }
+        },
+        "/auth-user/verify-email": {
+            "post": {
+                "description": "Подтверждает почту пользователя по токену из письма. Токен одноразовый",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Подтверждение почты",
+                "parameters": [
+                    {
+                        "description": "Токен из письма",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Почта подтверждена"
+                    },
+                    "400": {
+                        "description": "Токен недействителен, истек или уже использован"
+                    }
+                }
+            }
+        },
+        "/auth-user/verify-email/resend": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отправляет новое письмо со ссылкой подтверждения, ранее выданные ссылки перестают действовать",
+                "tags": [
+                    "аутентификация"
+                ],
+                "summary": "Повторное письмо для подтверждения почты",
+                "responses": {
+                    "200": {
+                        "description": "Письмо отправлено"
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован"
+                    },
+                    "409": {
+                        "description": "Почта не задана или уже подтверждена"
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Возвращает список категорий с возможностью фильтрации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить категории",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию категории (подстрока без учета регистра)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Только категории, в которых есть товары магазина",
+                        "name": "id_shop",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "title",
+                        "description": "Поле сортировки",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение от начала списка, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.CategoryPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат параметров",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет название и описание категории. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Категории"
+                ],
+                "summary": "Обновить категорию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория успешно обновлена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже есть",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую категорию товаров. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Категории"
+                ],
+                "summary": "Добавить категорию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные новой категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.AddCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Категория успешно создана",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже есть",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет категорию. Если к ней привязаны товары, удаление отклоняется,\nа с detach_products=true категория сначала убирается из товаров. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Категории"
+                ],
+                "summary": "Удалить категорию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для удаления категории",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.DeleteCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория успешно удалена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Категория используется товарами",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/tree": {
+            "get": {
+                "description": "Возвращает все категории в виде дерева, дочерние категории отсортированы по названию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить дерево категорий",
+                "responses": {
+                    "200": {
+                        "description": "Корневые категории с вложенными дочерними",
+                        "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/reqresp.ShopResponse"
+                                "$ref": "#/definitions/reqresp.CategoryTreeResponse"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id_category}": {
+            "get": {
+                "description": "Возвращает информацию о категории по её идентификатору вместе с путем от корня",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить категорию по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID категории",
+                        "name": "id_category",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация о категории",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID категории",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/posts": {
+            "get": {
+                "description": "Возвращает список постов с возможностью фильтрации по магазину",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить посты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по тексту поста (подстрока без учета регистра)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID магазина",
+                        "name": "id_shop",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "time_publication"
+                        ],
+                        "type": "string",
+                        "default": "time_publication",
+                        "description": "Поле сортировки",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение от начала списка, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список постов",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.PostPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат параметров",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Возвращает список товаров с возможностью фильтрации по различным параметрам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить товары",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию товара (подстрока без учета регистра)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Минимальная цена товара",
+                        "name": "min_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Максимальная цена товара, 0 - без ограничения",
+                        "name": "max_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID магазина",
+                        "name": "id_shop",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID категории",
+                        "name": "id_category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Учитывать товары всех дочерних категорий id_category",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "cost"
+                        ],
+                        "type": "string",
+                        "default": "title",
+                        "description": "Поле сортировки",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение от начала списка, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список товаров",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ProductPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат параметров",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Ищет слова запроса в названиях и описаниях товаров, названиях магазинов и текстах постов\nс учетом русской морфологии (\"серьги звезды\" найдет \"Серёжки со звездой\"), ё и е не различаются.\nЗапись подходит, если в ней есть хотя бы одно слово запроса; самые релевантные идут первыми.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Полнотекстовый поиск",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковая строка, до 200 символов",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "product",
+                            "shop",
+                            "post"
+                        ],
+                        "type": "string",
+                        "description": "Искать только среди записей одного вида",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение от начала результатов",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Найденные записи",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.SearchPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Пустой запрос или неверные параметры",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shops": {
+            "get": {
+                "description": "Возвращает список магазинов с возможностью фильтрации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить магазины",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию магазина (подстрока без учета регистра)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Фильтр по ID пользователя",
+                        "name": "id_user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Только магазины, у которых есть товары категории",
+                        "name": "id_category",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "title",
+                        "description": "Поле сортировки",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Направление сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение от начала списка, не используется вместе с cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ShopPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат параметров",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shops/{id_shop}": {
+            "get": {
+                "description": "Возвращает информацию о магазине по его идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Получить магазин по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID магазина",
+                        "name": "id_shop",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация о магазине",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ShopResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID магазина",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/suggest": {
+            "get": {
+                "description": "Названия магазинов, товаров и категорий, похожие на набранный текст: сначала те, что начинаются\nс него, затем содержащие его и похожие с опечатками. Запрос короче 2 символов дает пустые списки.\nЕсли подсказки не нашлись за отведенное время, списки пустые и timed_out = true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Поиск"
+                ],
+                "summary": "Подсказки для строки поиска",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Набранный текст, до 100 символов",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Подсказок каждого вида, не больше 10",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подсказки",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.SuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/feed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Посты магазинов, на которые подписан пользователь, новые первыми.\nСледующая страница запрашивается с курсором из next_cursor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Подписки"
+                ],
+                "summary": "Лента подписок",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы, не больше 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы из next_cursor предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Посты ленты",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.FeedPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат параметров",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-email": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Задает новую почту текущего пользователя и отправляет на нее письмо для подтверждения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Пользователь"
+                ],
+                "summary": "Обновить почту пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая почта",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.UpdateEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное обновление",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат почты",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Почта уже используется",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-login": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет логин текущего авторизованного пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Пользователь"
+                ],
+                "summary": "Обновить логин пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления логина",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.UpdateLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное обновление",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-password": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет пароль текущего авторизованного пользователя и завершает все его сессии",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Пользователь"
+                ],
+                "summary": "Обновить пароль пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления пароля",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.UpdateUserPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное обновление",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Пароль не соответствует требованиям",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/user-follows/{id_shop}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подписывает пользователя на магазин, повторная подписка ничего не меняет",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Подписки"
+                ],
+                "summary": "Подписаться на магазин",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID магазина",
+                        "name": "id_shop",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка оформлена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отменяет подписку пользователя на магазин, без подписки ничего не меняет",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Подписки"
+                ],
+                "summary": "Отписаться от магазина",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID магазина",
+                        "name": "id_shop",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка отменена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/user-posts": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет новый пост в указанный магазин пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Посты"
+                ],
+                "summary": "Добавить пост в магазин",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные нового поста",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.AddPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Пост успешно добавлен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или пост не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет пост пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Посты"
+                ],
+                "summary": "Удалить пост",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для удаления поста",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.DeletePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пост успешно удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или пост не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/shops/{id_shop}": {
-            "get": {
-                "description": "Возвращает информацию о магазине по его идентификатору",
+        "/user/user-posts/{id_post}/images": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Задает новый порядок изображений, в списке должны быть все изображения поста ровно по одному разу",
                 "consumes": [
                     "application/json"
                 ],
@@ -353,42 +2012,165 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Поиск"
+                    "Изображения"
                 ],
-                "summary": "Получить магазин по ID",
+                "summary": "Изменить порядок изображений поста",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "ID магазина",
-                        "name": "id_shop",
+                        "description": "ID поста",
+                        "name": "id_post",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ID изображений в новом порядке",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ReorderImagesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация о магазине",
+                        "description": "Порядок изменен",
                         "schema": {
-                            "$ref": "#/definitions/reqresp.ShopResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID магазина",
+                        "description": "Список не совпадает с изображениями поста",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Пост принадлежит другому пользователю",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Магазин не найден",
+                        "description": "Пост не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загружает изображение (jpeg, png или gif) в конец списка изображений поста и создает миниатюру.\nТип файла определяется по содержимому.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Изображения"
+                ],
+                "summary": "Добавить изображение поста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer токен",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID поста",
+                        "name": "id_post",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Изображение добавлено",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Нет файла или файл поврежден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Пост принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "У поста уже максимальное число изображений",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип файла",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -397,14 +2179,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/update-login": {
-            "patch": {
+        "/user/user-posts/{id_post}/images/{id_image}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Изменяет логин текущего авторизованного пользователя",
+                "description": "Удаляет изображение и его миниатюру, остальные изображения сдвигаются",
                 "consumes": [
                     "application/json"
                 ],
@@ -412,9 +2194,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Пользователь"
+                    "Изображения"
                 ],
-                "summary": "Обновить логин пользователя",
+                "summary": "Удалить изображение поста",
                 "parameters": [
                     {
                         "type": "string",
@@ -424,18 +2206,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления логина",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqresp.UpdateLoginRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID поста",
+                        "name": "id_post",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID изображения",
+                        "name": "id_image",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное обновление",
+                        "description": "Изображение удалено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Пост принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Пост или изображение не найдены",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -444,14 +2254,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/update-password": {
-            "patch": {
+        "/user/user-products": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Изменяет пароль текущего авторизованного пользователя",
+                "description": "Обновляет данные товара пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -459,9 +2269,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Пользователь"
+                    "Изделия"
                 ],
-                "summary": "Обновить пароль пользователя",
+                "summary": "Обновить товар",
                 "parameters": [
                     {
                         "type": "string",
@@ -471,34 +2281,53 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления пароля",
+                        "description": "Данные для обновления товара",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.UpdateUserPasswordRequest"
+                            "$ref": "#/definitions/reqresp.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар успешно обновлен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Успешное обновление",
+                    },
+                    "404": {
+                        "description": "Магазин или товар не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/user/user-posts": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет новый пост в указанный магазин пользователя",
+                "description": "Добавляет новый товар в указанный магазин пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,9 +2335,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Посты"
+                    "Изделия"
                 ],
-                "summary": "Добавить пост в магазин",
+                "summary": "Добавить товар в магазин",
                 "parameters": [
                     {
                         "type": "string",
@@ -518,18 +2347,39 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные нового поста",
+                        "description": "Данные нового товара",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.AddPostRequest"
+                            "$ref": "#/definitions/reqresp.AddProductRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Пост успешно добавлен",
+                        "description": "Товар успешно добавлен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или товар не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -543,7 +2393,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет пост пользователя",
+                "description": "Удаляет товар пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -551,9 +2401,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Посты"
+                    "Изделия"
                 ],
-                "summary": "Удалить пост",
+                "summary": "Удалить товар",
                 "parameters": [
                     {
                         "type": "string",
@@ -563,18 +2413,39 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для удаления поста",
+                        "description": "Данные для удаления товара",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.DeletePostRequest"
+                            "$ref": "#/definitions/reqresp.DeleteProductRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пост успешно удален",
+                        "description": "Товар успешно удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин или товар не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -583,14 +2454,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/user-products": {
+        "/user/user-products/{id_product}/images": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет данные товара пользователя",
+                "description": "Задает новый порядок изображений, в списке должны быть все изображения товара ровно по одному разу",
                 "consumes": [
                     "application/json"
                 ],
@@ -598,9 +2469,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Изделия"
+                    "Изображения"
                 ],
-                "summary": "Обновить товар",
+                "summary": "Изменить порядок изображений товара",
                 "parameters": [
                     {
                         "type": "string",
@@ -610,18 +2481,54 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления товара",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID товара",
+                        "name": "id_product",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID изображений в новом порядке",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.UpdateProductRequest"
+                            "$ref": "#/definitions/reqresp.ReorderImagesRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Товар успешно обновлен",
+                        "description": "Порядок изменен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Список не совпадает с изображениями товара",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Товар принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -635,17 +2542,17 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет новый товар в указанный магазин пользователя",
+                "description": "Загружает изображение (jpeg, png или gif) в конец списка изображений товара и создает миниатюру.\nТип файла определяется по содержимому.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Изделия"
+                    "Изображения"
                 ],
-                "summary": "Добавить товар в магазин",
+                "summary": "Добавить изображение товара",
                 "parameters": [
                     {
                         "type": "string",
@@ -655,32 +2562,88 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные нового товара",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqresp.AddProductRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID товара",
+                        "name": "id_product",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Товар успешно добавлен",
+                        "description": "Изображение добавлено",
+                        "schema": {
+                            "$ref": "#/definitions/reqresp.ImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Нет файла или файл поврежден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Товар принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Товар не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "У товара уже максимальное число изображений",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип файла",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/user/user-products/{id_product}/images/{id_image}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет товар пользователя",
+                "description": "Удаляет изображение и его миниатюру, остальные изображения сдвигаются",
                 "consumes": [
                     "application/json"
                 ],
@@ -688,9 +2651,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Изделия"
+                    "Изображения"
                 ],
-                "summary": "Удалить товар",
+                "summary": "Удалить изображение товара",
                 "parameters": [
                     {
                         "type": "string",
@@ -700,18 +2663,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для удаления товара",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/reqresp.DeleteProductRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID товара",
+                        "name": "id_product",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ID изображения",
+                        "name": "id_image",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Товар успешно удален",
+                        "description": "Изображение удалено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Товар принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Товар или изображение не найдены",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -727,7 +2718,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет данные указанного магазина пользователя",
+                "description": "Обновляет данные указанного магазина пользователя. Переданный список contacts заменяет\nконтакты целиком, без поля contacts они не меняются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -763,6 +2754,34 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Неверные данные магазина или контакт",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             },
@@ -772,7 +2791,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый магазин для текущего авторизованного пользователя",
+                "description": "Создает новый магазин для текущего авторизованного пользователя.\nКонтакты: telegram, vk, instagram - имя (@name) или ссылка на профиль, website - адрес сайта, email - почта.",
                 "consumes": [
                     "application/json"
                 ],
@@ -808,6 +2827,20 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Неверные данные магазина или контакт",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             },
@@ -842,13 +2875,34 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reqresp.DeleteShopRequest"
+                            "$ref": "#/definitions/reqresp.DeleteShopRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Магазин успешно удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Пользователь не авторизован",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Магазин принадлежит другому пользователю",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Магазин успешно удален",
+                    },
+                    "404": {
+                        "description": "Магазин не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -913,6 +2967,30 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "reqresp.AddCategoryRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Магазин сережек"
+                },
+                "parent_id": {
+                    "description": "ParentID - пустая строка для корневой категории",
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Звезды"
+                }
+            }
+        },
         "reqresp.AddPostRequest": {
             "type": "object",
             "required": [
@@ -975,6 +3053,13 @@ const docTemplate = `{
                 "title"
             ],
             "properties": {
+                "contacts": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ContactLinkRequest"
+                    }
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 255,
@@ -987,71 +3072,414 @@ const docTemplate = `{
                 }
             }
         },
+        "reqresp.CategoryCrumb": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Украшения"
+                }
+            }
+        },
+        "reqresp.CategoryPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.CategoryResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "reqresp.CategoryResponse": {
             "type": "object",
-            "required": [
-                "description"
-            ],
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Лучший магазин сережек"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "path": {
+                    "description": "Path - хлебные крошки от корня до самой категории включительно",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.CategoryCrumb"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Eco"
+                }
+            }
+        },
+        "reqresp.CategoryTreeResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.CategoryTreeResponse"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Серьги, кольца, броши"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Украшения"
+                }
+            }
+        },
+        "reqresp.CompleteMFARequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "код приложения или код восстановления",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "reqresp.ContactLinkRequest": {
+            "type": "object",
+            "required": [
+                "type",
+                "value"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "telegram",
+                        "vk",
+                        "instagram",
+                        "website",
+                        "email"
+                    ],
+                    "example": "telegram"
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "@craft_star"
+                }
+            }
+        },
+        "reqresp.ContactLinkResponse": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "example": "telegram"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://t.me/craft_star"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "craft_star"
+                }
+            }
+        },
+        "reqresp.DeleteCategoryRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "detach_products": {
+                    "description": "DetachProducts - убрать категорию из товаров вместо отказа в удалении",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                }
+            }
+        },
+        "reqresp.DeletePostRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                }
+            }
+        },
+        "reqresp.DeleteProductRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                }
+            }
+        },
+        "reqresp.DeleteShopRequest": {
+            "type": "object",
+            "properties": {
+                "id_shop": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                }
+            }
+        },
+        "reqresp.FeedPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.PostResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoidGltZV9wdWJsaWNhdGlvbiJ9"
+                }
+            }
+        },
+        "reqresp.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "master@example.com"
+                }
+            }
+        },
+        "reqresp.ImageResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "id": {
+                    "type": "string",
+                    "example": "cc2e8400-e29b-41d4-a716-446655443333"
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "/media/products/bb2e8400-e29b-41d4-a716-446655442222/cc2e8400-e29b-41d4-a716-446655443333_thumb.jpg"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/media/products/bb2e8400-e29b-41d4-a716-446655442222/cc2e8400-e29b-41d4-a716-446655443333.jpg"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "reqresp.IntrospectRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "reqresp.IntrospectResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "exp": {
+                    "type": "integer"
+                },
+                "iat": {
+                    "description": "unix-время",
+                    "type": "integer"
+                },
+                "jti": {
+                    "type": "string"
+                },
+                "nbf": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user_role"
+                },
+                "sub": {
+                    "description": "id пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "reqresp.LoginLockoutResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "login или ip",
+                    "type": "string",
+                    "example": "login"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "ulogin"
+                }
+            }
+        },
+        "reqresp.LoginUserRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 4,
+                    "example": "ulogin"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Password123"
+                }
+            }
+        },
+        "reqresp.LoginUserResponse": {
+            "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "example": "Лучший магазин сережек"
+                "access_token": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                "mfa_required": {
+                    "type": "boolean"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Eco"
+                "mfa_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
                 }
             }
         },
-        "reqresp.DeletePostRequest": {
+        "reqresp.LogoutRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                "refresh_token": {
+                    "description": "необязателен, если передан - отзывается вместе с токеном доступа",
+                    "type": "string"
                 }
             }
         },
-        "reqresp.DeleteProductRequest": {
+        "reqresp.MFACodeRequest": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
-                "id": {
+                "code": {
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                    "maxLength": 32,
+                    "example": "123456"
                 }
             }
         },
-        "reqresp.DeleteShopRequest": {
+        "reqresp.MFAEnrollResponse": {
             "type": "object",
             "properties": {
-                "id_shop": {
+                "provisioning_uri": {
                     "type": "string",
-                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                    "example": "otpauth://totp/CraftPlace:ulogin?secret=JBSWY3DPEHPK3PXP\u0026issuer=CraftPlace"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ABCDE-FGHIJ"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "reqresp.LoginUserRequest": {
+        "reqresp.PostPageResponse": {
             "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
             "properties": {
-                "login": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 4,
-                    "example": "ulogin"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.PostResponse"
+                    }
                 },
-                "password": {
+                "next_cursor": {
                     "type": "string",
-                    "minLength": 4,
-                    "example": "12345678"
+                    "example": "eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
@@ -1071,6 +3499,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "bb2e8400-e29b-41d4-a716-446655442222"
                 },
+                "images": {
+                    "description": "Images - изображения поста в заданном владельцем порядке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ImageResponse"
+                    }
+                },
                 "shopID": {
                     "type": "string",
                     "example": "bb2e8400-e29b-41d4-a716-446655442222"
@@ -1081,6 +3516,25 @@ const docTemplate = `{
                 }
             }
         },
+        "reqresp.ProductPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ProductResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "reqresp.ProductResponse": {
             "type": "object",
             "required": [
@@ -1110,6 +3564,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "bb2e8400-e29b-41d4-a716-446655442222"
                 },
+                "images": {
+                    "description": "Images - изображения товара в заданном владельцем порядке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ImageResponse"
+                    }
+                },
                 "shopID": {
                     "type": "string",
                     "example": "bb2e8400-e29b-41d4-a716-446655442222"
@@ -1120,14 +3581,31 @@ const docTemplate = `{
                 }
             }
         },
+        "reqresp.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
         "reqresp.RegisterUserRequest": {
             "type": "object",
             "required": [
+                "email",
                 "login",
                 "password",
                 "username"
             ],
             "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "master@example.com"
+                },
                 "login": {
                     "type": "string",
                     "maxLength": 50,
@@ -1135,9 +3613,9 @@ const docTemplate = `{
                     "example": "ulogin"
                 },
                 "password": {
+                    "description": "проверяется политикой паролей",
                     "type": "string",
-                    "minLength": 4,
-                    "example": "12345678"
+                    "example": "Password123"
                 },
                 "username": {
                     "type": "string",
@@ -1146,6 +3624,108 @@ const docTemplate = `{
                 }
             }
         },
+        "reqresp.ReorderImagesRequest": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "reqresp.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "description": "проверяется политикой паролей",
+                    "type": "string",
+                    "example": "Password123"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "reqresp.SearchHitResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "product",
+                        "shop",
+                        "post"
+                    ],
+                    "example": "product"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.0759
+                },
+                "shop_id": {
+                    "description": "ShopID - магазин записи, для магазина совпадает с ID",
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "snippet": {
+                    "description": "Snippet - фрагмент текста, найденные слова выделены \u003cmark\u003e, остальной текст экранирован для HTML",
+                    "type": "string",
+                    "example": "Сережки со \u003cmark\u003eзвездой\u003c/mark\u003e. Серебро"
+                },
+                "title": {
+                    "description": "Title - название товара или магазина, у постов пустое",
+                    "type": "string",
+                    "example": "Серёжки со звездой"
+                }
+            }
+        },
+        "reqresp.SearchPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.SearchHitResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "reqresp.ShopPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ShopResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "reqresp.ShopResponse": {
             "type": "object",
             "required": [
@@ -1153,11 +3733,23 @@ const docTemplate = `{
                 "userID"
             ],
             "properties": {
+                "contacts": {
+                    "description": "Contacts - каналы связи с мастером в заданном им порядке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ContactLinkResponse"
+                    }
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 255,
                     "example": "Лучший магазин сережек"
                 },
+                "followers": {
+                    "description": "Followers - число подписчиков магазина",
+                    "type": "integer",
+                    "example": 42
+                },
                 "id_shop": {
                     "type": "string",
                     "example": "bb2e8400-e29b-41d4-a716-446655442222"
@@ -1172,6 +3764,89 @@ const docTemplate = `{
                 }
             }
         },
+        "reqresp.SuggestResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.SuggestionResponse"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.SuggestionResponse"
+                    }
+                },
+                "shops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reqresp.SuggestionResponse"
+                    }
+                },
+                "timed_out": {
+                    "description": "TimedOut - подсказки не успели найтись за отведенное время, списки могут быть пустыми",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "reqresp.SuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Серёжки со звездой"
+                }
+            }
+        },
+        "reqresp.UpdateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Лучший магазин сережек"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bb2e8400-e29b-41d4-a716-446655442222"
+                },
+                "parent_id": {
+                    "description": "ParentID - пустая строка делает категорию корневой",
+                    "type": "string",
+                    "example": "aa2e8400-e29b-41d4-a716-446655441111"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Лучшие звезды"
+                }
+            }
+        },
+        "reqresp.UpdateEmailRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "master@example.com"
+                }
+            }
+        },
         "reqresp.UpdateLoginRequest": {
             "type": "object",
             "required": [
@@ -1233,6 +3908,14 @@ const docTemplate = `{
                 "title"
             ],
             "properties": {
+                "contacts": {
+                    "description": "Contacts заменяет контакты магазина целиком; без поля контакты не меняются, [] - удаляет все",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/reqresp.ContactLinkRequest"
+                    }
+                },
                 "description": {
                     "type": "string",
                     "maxLength": 255,
@@ -1256,9 +3939,9 @@ const docTemplate = `{
             ],
             "properties": {
                 "password": {
+                    "description": "проверяется политикой паролей",
                     "type": "string",
-                    "minLength": 4,
-                    "example": "12345678"
+                    "example": "Password123"
                 }
             }
         },
@@ -1268,13 +3951,92 @@ const docTemplate = `{
                 "login"
             ],
             "properties": {
+                "email": {
+                    "description": "Email - пусто у пользователей, зарегистрированных без почты",
+                    "type": "string",
+                    "example": "master@example.com"
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "login": {
                     "type": "string",
                     "maxLength": 50,
                     "example": "uname"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "uname"
+                }
+            }
+        },
+        "reqresp.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "tokenmaker.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "EdDSA"
+                },
+                "crv": {
+                    "type": "string",
+                    "example": "Ed25519"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "2026-10"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "OKP"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string",
+                    "example": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+                },
+                "y": {
+                    "description": "только для EC",
+                    "type": "string"
+                }
+            }
+        },
+        "tokenmaker.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tokenmaker.JWK"
+                    }
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"net"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api/authpb"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	authclient "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_client"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// AuthGRPCServer - gRPC API сервиса авторизации для других сервисов CraftPlace. До методов
+// доходят только вызовы с токеном сервиса, поэтому адресу клиента из x-client-ip можно доверять
+type AuthGRPCServer struct {
+	authpb.UnimplementedAuthServiceServer
+	authu authuser.AuthUser
+}
+
+// NewAuthGRPCServer - gRPC сервер с AuthService, который проверяет токен сервиса в каждом вызове
+func NewAuthGRPCServer(authu authuser.AuthUser, serviceToken string, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if serviceToken == "" {
+		return nil, ErrNoServiceToken
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(serviceTokenInterceptor(serviceToken)))
+	server := grpc.NewServer(opts...)
+	authpb.RegisterAuthServiceServer(server, &AuthGRPCServer{
+		authu: authu,
+	})
+	return server, nil
+}
+
+// withClientIP - адрес клиента API из метаданных, а без них - адрес вызывающего сервиса
+func withClientIP(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ips := md.Get(authclient.ClientIPMetadata); len(ips) > 0 {
+			return loginthrottle.WithClientIP(ctx, ips[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return loginthrottle.WithClientIP(ctx, host)
+	}
+	return ctx
+}
+
+func (s *AuthGRPCServer) Register(ctx context.Context, req *authpb.RegisterRequest) (*emptypb.Empty, error) {
+	err := s.authu.RegisterUser(ctx, reqresp.RegisterUserRequest{
+		Username: req.GetUsername(),
+		Login:    req.GetLogin(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.Tokens, error) {
+	tokens, err := s.authu.LoginUser(withClientIP(ctx), reqresp.LoginUserRequest{
+		Login:    req.GetLogin(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return authclient.TokensToProto(tokens), nil
+}
+
+func (s *AuthGRPCServer) CompleteMFA(ctx context.Context, req *authpb.CompleteMFARequest) (*authpb.Tokens, error) {
+	tokens, err := s.authu.CompleteMFA(withClientIP(ctx), req.GetMfaToken(), req.GetCode())
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return authclient.TokensToProto(tokens), nil
+}
+
+func (s *AuthGRPCServer) Refresh(ctx context.Context, req *authpb.RefreshRequest) (*authpb.Tokens, error) {
+	tokens, err := s.authu.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return authclient.TokensToProto(tokens), nil
+}
+
+func (s *AuthGRPCServer) Introspect(ctx context.Context, req *authpb.IntrospectRequest) (*authpb.Payload, error) {
+	payload, err := s.authu.VerifyByToken(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return authclient.PayloadToProto(*payload), nil
+}
+
+// sessionUser проверяет токен доступа и то, что он принадлежит пользователю userID
+func (s *AuthGRPCServer) sessionUser(ctx context.Context, accessToken string, userID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	payload, err := s.authu.VerifyByToken(ctx, accessToken)
+	if err != nil {
+		return uuid.Nil, authclient.ToStatus(err)
+	}
+	if payload.GetPersonID() != id {
+		return uuid.Nil, authclient.ToStatus(authclient.ErrSessionMismatch)
+	}
+	return id, nil
+}
+
+func (s *AuthGRPCServer) Logout(ctx context.Context, req *authpb.LogoutRequest) (*emptypb.Empty, error) {
+	payload, err := s.authu.VerifyByToken(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	if err := s.authu.Logout(ctx, *payload, req.GetRefreshToken()); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) LogoutAll(ctx context.Context, req *authpb.UserRequest) (*emptypb.Empty, error) {
+	userID, err := s.sessionUser(ctx, req.GetAccessToken(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authu.LogoutAll(ctx, userID); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) VerifyEmail(ctx context.Context, req *authpb.TokenRequest) (*emptypb.Empty, error) {
+	if err := s.authu.VerifyEmail(ctx, req.GetToken()); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) RequestEmailVerification(ctx context.Context, req *authpb.UserRequest) (*emptypb.Empty, error) {
+	userID, err := s.sessionUser(ctx, req.GetAccessToken(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authu.RequestEmailVerification(ctx, userID); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) ForgotPassword(ctx context.Context, req *authpb.ForgotPasswordRequest) (*emptypb.Empty, error) {
+	if err := s.authu.ForgotPassword(ctx, req.GetEmail()); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthGRPCServer) ResetPassword(ctx context.Context, req *authpb.ResetPasswordRequest) (*emptypb.Empty, error) {
+	if err := s.authu.ResetPassword(ctx, req.GetToken(), req.GetPassword()); err != nil {
+		return nil, authclient.ToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
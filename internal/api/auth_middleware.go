@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	authRealm           = "craftplace"
+)
+
+var (
+	ErrNoAuthHeader      = errors.New("authorization header is not provided")
+	ErrInvalidAuthHeader = errors.New("invalid authorization header format")
+)
+
+// AuthMiddleware проверяет токен из заголовка Authorization: Bearer
+// и кладет его полезную нагрузку в контекст запроса через AuthZ
+type AuthMiddleware struct {
+	authu authuser.AuthUser
+	authz auth.AuthZ
+}
+
+func NewAuthMiddleware(authu authuser.AuthUser, authz auth.AuthZ) AuthMiddleware {
+	return AuthMiddleware{
+		authu: authu,
+		authz: authz,
+	}
+}
+
+// Required пропускает только запросы с действительным токеном
+func (m AuthMiddleware) Required() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(authorizationHeader)
+		if header == "" {
+			abortUnauthorized(c, ErrNoAuthHeader)
+			return
+		}
+		m.authorize(c, header)
+	}
+}
+
+// Optional пропускает анонимные запросы, но отклоняет запросы с недействительным токеном
+func (m AuthMiddleware) Optional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(authorizationHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+		m.authorize(c, header)
+	}
+}
+
+// RequireRole ставится после Required и пропускает только пользователей с ролью не ниже role
+func (m AuthMiddleware) RequireRole(role tokenmaker.RoleAuth) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := m.authz.RequireRole(c.Request.Context(), role); err != nil {
+			if errors.Is(err, auth.ErrNotAuthZ) {
+				abortUnauthorized(c, ErrNoAuthHeader)
+			} else {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		c.Next()
+	}
+}
+
+func (m AuthMiddleware) authorize(c *gin.Context, header string) {
+	token, ok := strings.CutPrefix(header, bearerPrefix)
+	if !ok || strings.TrimSpace(token) == "" {
+		abortUnauthorized(c, ErrInvalidAuthHeader)
+		return
+	}
+	payload, err := m.authu.VerifyByToken(c.Request.Context(), strings.TrimSpace(token))
+	if err != nil {
+		if isTokenError(err) {
+			abortUnauthorized(c, err)
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	ctx := authuser.WithAccessToken(m.authz.Authorize(c.Request.Context(), *payload), strings.TrimSpace(token))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// abortUnauthorized отвечает 401 с заголовком WWW-Authenticate по RFC 6750
+func abortUnauthorized(c *gin.Context, err error) {
+	challenge := fmt.Sprintf(`Bearer realm="%s"`, authRealm)
+	if !errors.Is(err, ErrNoAuthHeader) {
+		challenge += fmt.Sprintf(`, error="invalid_token", error_description="%s"`, tokenErrorDescription(err))
+	}
+	c.Header("WWW-Authenticate", challenge)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+}
+
+// isTokenError отличает недействительный токен от сбоя проверки (например, хранилища отзывов)
+func isTokenError(err error) bool {
+	return errors.Is(err, tokenmaker.ErrInvalidToken) ||
+		errors.Is(err, tokenmaker.ErrExpiredToken) ||
+		errors.Is(err, tokenmaker.ErrIncorrectRole) ||
+		errors.Is(err, authuser.ErrRevokedToken)
+}
+
+func tokenErrorDescription(err error) string {
+	switch {
+	case errors.Is(err, tokenmaker.ErrExpiredToken):
+		return tokenmaker.ErrExpiredToken.Error()
+	case errors.Is(err, authuser.ErrRevokedToken):
+		return authuser.ErrRevokedToken.Error()
+	case errors.Is(err, ErrInvalidAuthHeader):
+		return ErrInvalidAuthHeader.Error()
+	default:
+		return tokenmaker.ErrInvalidToken.Error()
+	}
+}
@@ -0,0 +1,191 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type AuthMiddlewareSuite struct {
+	suite.Suite
+	tokenMaker tokenmaker.TokenMaker
+	revokedRep revokedtokenrep.RevokedTokenRep
+	engine     *gin.Engine
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	suite.RunSuite(t, new(AuthMiddlewareSuite))
+}
+
+func (s *AuthMiddlewareSuite) BeforeAll(t provider.T) {
+	gin.SetMode(gin.TestMode)
+	appCnfg := testobj.NewAppConfigMother().Default()
+
+	tokenMaker, err := tokenmaker.NewTokenMaker(appCnfg)
+	t.Require().NoError(err)
+	s.tokenMaker = tokenMaker
+	s.revokedRep = revokedtokenrep.NewMemRevokedTokenRep(memdb.NewDB())
+	authu, err := authuser.NewAuthUser(
+		appCnfg,
+		new(userrep.MockUserRep),
+		new(refreshtokenrep.MockRefreshTokenRep),
+		s.revokedRep,
+		new(usertokenrep.MockUserTokenRep),
+		tokenMaker,
+		new(hasher.MockHasher),
+		passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default()),
+		new(notifier.MockNotifier),
+		new(mfa.MockMFA),
+	)
+	t.Require().NoError(err)
+	authz, err := auth.NewAuthZ()
+	t.Require().NoError(err)
+
+	authMiddleware := api.NewAuthMiddleware(authu, authz)
+	whoami := func(c *gin.Context) {
+		userID, err := authz.UserIDFromContext(c.Request.Context())
+		if err != nil {
+			c.String(http.StatusOK, "anonymous")
+			return
+		}
+		c.String(http.StatusOK, userID.String())
+	}
+	s.engine = gin.New()
+	s.engine.GET("/required", authMiddleware.Required(), whoami)
+	s.engine.GET("/optional", authMiddleware.Optional(), whoami)
+	s.engine.GET("/admin", authMiddleware.Required(), authMiddleware.RequireRole(tokenmaker.AdminRole), whoami)
+}
+
+func (s *AuthMiddlewareSuite) do(path string, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func (s *AuthMiddlewareSuite) TestAuthMiddleware_Required(t provider.T) {
+	t.WithNewStep("valid token", func(sCtx provider.StepCtx) {
+		userID := uuid.New()
+		token, err := s.tokenMaker.CreateToken(userID, tokenmaker.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		w := s.do("/required", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusOK, w.Code)
+		sCtx.Assert().Equal(userID.String(), w.Body.String())
+	})
+	t.WithNewStep("missing header", func(sCtx provider.StepCtx) {
+		w := s.do("/required", "")
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().Equal(`Bearer realm="craftplace"`, w.Header().Get("WWW-Authenticate"))
+	})
+	t.WithNewStep("not a bearer scheme", func(sCtx provider.StepCtx) {
+		w := s.do("/required", "Basic dXNlcjpwYXNz")
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().Contains(w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+	})
+	t.WithNewStep("expired token", func(sCtx provider.StepCtx) {
+		token, err := s.tokenMaker.CreateToken(uuid.New(), tokenmaker.UserRole, -time.Minute)
+		sCtx.Require().NoError(err)
+
+		w := s.do("/required", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().Contains(w.Header().Get("WWW-Authenticate"), tokenmaker.ErrExpiredToken.Error())
+	})
+	t.WithNewStep("invalid token", func(sCtx provider.StepCtx) {
+		w := s.do("/required", "Bearer not.a.token")
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().Contains(w.Header().Get("WWW-Authenticate"), tokenmaker.ErrInvalidToken.Error())
+	})
+	t.WithNewStep("revoked token", func(sCtx provider.StepCtx) {
+		token, err := s.tokenMaker.CreateToken(uuid.New(), tokenmaker.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+		payload, err := s.tokenMaker.VerifyToken(token, tokenmaker.UserRole)
+		sCtx.Require().NoError(err)
+		sCtx.Require().NoError(s.revokedRep.Revoke(context.Background(), payload.GetID(), payload.GetExpiredAt()))
+
+		w := s.do("/required", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().Contains(w.Header().Get("WWW-Authenticate"), authuser.ErrRevokedToken.Error())
+	})
+}
+
+func (s *AuthMiddlewareSuite) TestAuthMiddleware_Optional(t provider.T) {
+	t.WithNewStep("anonymous request passes", func(sCtx provider.StepCtx) {
+		w := s.do("/optional", "")
+
+		sCtx.Assert().Equal(http.StatusOK, w.Code)
+		sCtx.Assert().Equal("anonymous", w.Body.String())
+	})
+	t.WithNewStep("valid token is stored in context", func(sCtx provider.StepCtx) {
+		userID := uuid.New()
+		token, err := s.tokenMaker.CreateToken(userID, tokenmaker.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		w := s.do("/optional", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusOK, w.Code)
+		sCtx.Assert().Equal(userID.String(), w.Body.String())
+	})
+	t.WithNewStep("invalid token is rejected", func(sCtx provider.StepCtx) {
+		w := s.do("/optional", "Bearer not.a.token")
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+		sCtx.Assert().NotEmpty(w.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func (s *AuthMiddlewareSuite) TestAuthMiddleware_RequireRole(t provider.T) {
+	t.WithNewStep("admin passes", func(sCtx provider.StepCtx) {
+		userID := uuid.New()
+		token, err := s.tokenMaker.CreateToken(userID, tokenmaker.AdminRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		w := s.do("/admin", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusOK, w.Code)
+		sCtx.Assert().Equal(userID.String(), w.Body.String())
+	})
+	t.WithNewStep("user is forbidden", func(sCtx provider.StepCtx) {
+		token, err := s.tokenMaker.CreateToken(uuid.New(), tokenmaker.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		w := s.do("/admin", "Bearer "+token)
+
+		sCtx.Assert().Equal(http.StatusForbidden, w.Code)
+	})
+	t.WithNewStep("anonymous request", func(sCtx provider.StepCtx) {
+		w := s.do("/admin", "")
+
+		sCtx.Assert().Equal(http.StatusUnauthorized, w.Code)
+	})
+}
@@ -2,24 +2,59 @@ package api
 
 import (
 	"errors"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
 
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
 	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
 	"github.com/gin-gonic/gin"
 )
 
 type AuthUserRouter struct {
-	authu authuser.AuthUser
+	authu    authuser.AuthUser
+	authz    auth.AuthZ
+	throttle loginthrottle.LoginThrottle
+	mfa      mfa.MFA
 }
 
-func NewAuthUserRouter(router *gin.RouterGroup, authu authuser.AuthUser) AuthUserRouter {
+func NewAuthUserRouter(
+	router *gin.RouterGroup,
+	authu authuser.AuthUser,
+	authz auth.AuthZ,
+	throttle loginthrottle.LoginThrottle,
+	mfa mfa.MFA,
+	authMiddleware AuthMiddleware,
+) AuthUserRouter {
 	r := AuthUserRouter{
-		authu: authu,
+		authu:    authu,
+		authz:    authz,
+		throttle: throttle,
+		mfa:      mfa,
 	}
 	gr := router.Group("auth-user")
 	gr.POST("/register", r.Register)
 	gr.POST("/login", r.Login)
+	gr.POST("/refresh", r.Refresh)
+	gr.POST("/verify-email", r.VerifyEmail)
+	gr.POST("/forgot-password", r.ForgotPassword)
+	gr.POST("/reset-password", r.ResetPassword)
+	gr.POST("/mfa", r.CompleteMFA)
+
+	authGr := gr.Group("", authMiddleware.Required())
+	authGr.POST("/logout", r.Logout)
+	authGr.POST("/logout-all", r.LogoutAll)
+	authGr.POST("/verify-email/resend", r.ResendVerification)
+	authGr.GET("/lockouts", authMiddleware.RequireRole(tokenmaker.AdminRole), r.Lockouts)
+	authGr.POST("/mfa/enroll", r.EnrollMFA)
+	authGr.POST("/mfa/confirm", r.ConfirmMFA)
+	authGr.POST("/mfa/disable", r.DisableMFA)
 	return r
 }
 
@@ -30,9 +65,9 @@ func NewAuthUserRouter(router *gin.RouterGroup, authu authuser.AuthUser) AuthUse
 // @Accept json
 // @Param request body reqresp.RegisterUserRequest true "Данные для регистрации"
 // @Success 200 "Пользователь зарегистрирован"
-// @Failure 400 "Неверные входные параметры"
+// @Failure 400 "Неверные входные параметры или пароль не соответствует требованиям"
 // @Failure 401 "Ошибка аутентификации"
-// @Failure 409 "Попытка повторной регистрации"
+// @Failure 409 "Логин или почта уже заняты"
 // @Router /auth-user/register [post]
 func (r *AuthUserRouter) Register(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -44,8 +79,10 @@ func (r *AuthUserRouter) Register(c *gin.Context) {
 	}
 
 	if err := r.authu.RegisterUser(ctx, req); err != nil {
-		if errors.Is(err, authuser.ErrDuplicateLoginUser) {
+		if errors.Is(err, authuser.ErrDuplicateLoginUser) || errors.Is(err, authuser.ErrDuplicateEmailUser) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authuser.ErrWeakPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		}
@@ -56,16 +93,21 @@ func (r *AuthUserRouter) Register(c *gin.Context) {
 
 // Login Handler
 // @Summary Вход пользователя
-// @Description Аутентифицирует пользователя и возвращает токен доступа
+// @Description Аутентифицирует пользователя и возвращает токен доступа и refresh-токен.
+// @Description После нескольких неудачных попыток подряд по логину или с одного адреса вход временно запрещается.
+// @Description Если у пользователя включен второй фактор, вместо токенов возвращается mfa_token для /auth-user/mfa
 // @Tags аутентификация
 // @Accept json
+// @Produce json
 // @Param request body reqresp.LoginUserRequest true "Учетные данные для входа"
-// @Success 200 "Пользователь успешно аутентифицирован"
+// @Success 200 {object} reqresp.LoginUserResponse "Пользователь успешно аутентифицирован"
 // @Failure 400 "Неверные входные параметры"
 // @Failure 401 "Ошибка аутентификации"
+// @Failure 429 "Слишком много неудачных попыток, повторить через Retry-After секунд"
+// @Header 429 {integer} Retry-After "Через сколько секунд можно повторить попытку"
 // @Router /auth-user/login [post]
 func (r *AuthUserRouter) Login(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx := loginthrottle.WithClientIP(c.Request.Context(), c.ClientIP())
 
 	var req reqresp.LoginUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -73,9 +115,61 @@ func (r *AuthUserRouter) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := r.authu.LoginUser(ctx, req)
+	tokens, err := r.authu.LoginUser(ctx, req)
+	if err != nil {
+		var throttled *loginthrottle.ThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authuser.ErrUserNotFound) || errors.Is(err, hasher.ErrPassword) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	rsp := reqresp.LoginUserResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		MFARequired:  tokens.MFAToken != "",
+		MFAToken:     tokens.MFAToken,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// CompleteMFA Handler
+// @Summary Второй шаг входа
+// @Description Обменивает mfa_token из ответа на вход и код приложения-аутентификатора или код восстановления
+// @Description на токен доступа и refresh-токен. mfa_token одноразовый, неверные коды ограничиваются как неверные пароли
+// @Tags аутентификация
+// @Accept json
+// @Produce json
+// @Param request body reqresp.CompleteMFARequest true "Токен второго шага и код"
+// @Success 200 {object} reqresp.LoginUserResponse "Пользователь успешно аутентифицирован"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 401 "Токен второго шага недействителен или неверный код"
+// @Failure 429 "Слишком много неудачных попыток, повторить через Retry-After секунд"
+// @Header 429 {integer} Retry-After "Через сколько секунд можно повторить попытку"
+// @Router /auth-user/mfa [post]
+func (r *AuthUserRouter) CompleteMFA(c *gin.Context) {
+	ctx := loginthrottle.WithClientIP(c.Request.Context(), c.ClientIP())
+
+	var req reqresp.CompleteMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := r.authu.CompleteMFA(ctx, req.MFAToken, req.Code)
 	if err != nil {
-		if errors.Is(err, authuser.ErrUserNotFound) {
+		var throttled *loginthrottle.ThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authuser.ErrInvalidMFAToken) ||
+			errors.Is(err, authuser.ErrInvalidMFACode) ||
+			errors.Is(err, authuser.ErrUserNotFound) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -84,7 +178,367 @@ func (r *AuthUserRouter) Login(c *gin.Context) {
 	}
 
 	rsp := reqresp.LoginUserResponse{
-		AccessToken: accessToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// Refresh Handler
+// @Summary Обновление токенов
+// @Description Обменивает refresh-токен на новую пару токенов. Каждый refresh-токен одноразовый:
+// @Description повторное использование уже обмененного токена отзывает все токены, выданные при этом входе
+// @Tags аутентификация
+// @Accept json
+// @Produce json
+// @Param request body reqresp.RefreshTokenRequest true "Refresh-токен"
+// @Success 200 {object} reqresp.LoginUserResponse "Токены обновлены"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 401 "Refresh-токен недействителен, истек или использован повторно"
+// @Router /auth-user/refresh [post]
+func (r *AuthUserRouter) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := r.authu.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, authuser.ErrInvalidRefreshToken) ||
+			errors.Is(err, authuser.ErrRefreshTokenReused) ||
+			errors.Is(err, authuser.ErrUserNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	rsp := reqresp.LoginUserResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// Logout Handler
+// @Summary Выход из текущей сессии
+// @Description Отзывает токен доступа из заголовка. Если передан refresh-токен, отзывается и он вместе со всеми его предшественниками
+// @Tags аутентификация
+// @Accept json
+// @Security ApiKeyAuth
+// @Param request body reqresp.LogoutRequest false "Refresh-токен текущей сессии"
+// @Success 200 "Сессия завершена"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 401 "Пользователь не авторизован или refresh-токен принадлежит другому пользователю"
+// @Router /auth-user/logout [post]
+func (r *AuthUserRouter) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	payload, err := r.authz.PayloadFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.authu.Logout(ctx, payload, req.RefreshToken); err != nil {
+		if errors.Is(err, authuser.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// LogoutAll Handler
+// @Summary Выход со всех устройств
+// @Description Отзывает все токены доступа и refresh-токены пользователя, выпущенные до этого момента
+// @Tags аутентификация
+// @Security ApiKeyAuth
+// @Success 200 "Все сессии завершены"
+// @Failure 401 "Пользователь не авторизован"
+// @Router /auth-user/logout-all [post]
+func (r *AuthUserRouter) LogoutAll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, err := r.authz.UserIDFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.authu.LogoutAll(ctx, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// VerifyEmail Handler
+// @Summary Подтверждение почты
+// @Description Подтверждает почту пользователя по токену из письма. Токен одноразовый
+// @Tags аутентификация
+// @Accept json
+// @Param request body reqresp.VerifyEmailRequest true "Токен из письма"
+// @Success 200 "Почта подтверждена"
+// @Failure 400 "Токен недействителен, истек или уже использован"
+// @Router /auth-user/verify-email [post]
+func (r *AuthUserRouter) VerifyEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.authu.VerifyEmail(ctx, req.Token); err != nil {
+		if errors.Is(err, authuser.ErrInvalidUserToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ResendVerification Handler
+// @Summary Повторное письмо для подтверждения почты
+// @Description Отправляет новое письмо со ссылкой подтверждения, ранее выданные ссылки перестают действовать
+// @Tags аутентификация
+// @Security ApiKeyAuth
+// @Success 200 "Письмо отправлено"
+// @Failure 401 "Пользователь не авторизован"
+// @Failure 409 "Почта не задана или уже подтверждена"
+// @Router /auth-user/verify-email/resend [post]
+func (r *AuthUserRouter) ResendVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, err := r.authz.UserIDFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.authu.RequestEmailVerification(ctx, userID); err != nil {
+		if errors.Is(err, authuser.ErrNoEmail) || errors.Is(err, authuser.ErrEmailAlreadyVerified) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ForgotPassword Handler
+// @Summary Запрос на сброс пароля
+// @Description Отправляет на почту ссылку для сброса пароля. Ответ не зависит от того,
+// @Description зарегистрирована ли почта, чтобы по нему нельзя было проверить наличие аккаунта
+// @Tags аутентификация
+// @Accept json
+// @Param request body reqresp.ForgotPasswordRequest true "Почта аккаунта"
+// @Success 200 "Запрос принят"
+// @Failure 400 "Неверные входные параметры"
+// @Router /auth-user/forgot-password [post]
+func (r *AuthUserRouter) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.authu.ForgotPassword(ctx, req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ResetPassword Handler
+// @Summary Сброс пароля
+// @Description Задает новый пароль по токену из письма и завершает все сессии пользователя. Токен одноразовый
+// @Tags аутентификация
+// @Accept json
+// @Param request body reqresp.ResetPasswordRequest true "Токен из письма и новый пароль"
+// @Success 200 "Пароль изменен"
+// @Failure 400 "Неверные входные параметры, токен недействителен или пароль не соответствует требованиям"
+// @Router /auth-user/reset-password [post]
+func (r *AuthUserRouter) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.authu.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		if errors.Is(err, authuser.ErrInvalidUserToken) || errors.Is(err, authuser.ErrWeakPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// Lockouts Handler
+// @Summary Журнал блокировок входа
+// @Description Последние блокировки входа по логину или IP-адресу после серии неудачных попыток, новые первыми
+// @Tags аутентификация
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query integer false "Число записей, не больше 500" default(50)
+// @Success 200 {array} reqresp.LoginLockoutResponse "Журнал блокировок"
+// @Failure 400 "Неверные параметры"
+// @Failure 401 "Пользователь не авторизован"
+// @Failure 403 "Доступно только администратору"
+// @Router /auth-user/lockouts [get]
+func (r *AuthUserRouter) Lockouts(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit, err := queryUint(c, "limit", 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lockouts, err := r.throttle.Lockouts(ctx, int(min(limit, loginthrottle.MaxLockoutsLimit)))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	rsp := make([]reqresp.LoginLockoutResponse, 0, len(lockouts))
+	for _, lockout := range lockouts {
+		rsp = append(rsp, lockout.ToResponse())
 	}
 	c.JSON(http.StatusOK, rsp)
 }
+
+// EnrollMFA Handler
+// @Summary Настройка второго фактора
+// @Description Создает секрет TOTP и коды восстановления. provisioning_uri нужно показать пользователю QR-кодом
+// @Description для приложения-аутентификатора, коды восстановления показываются только в этом ответе.
+// @Description Второй фактор начнет действовать после подтверждения кодом из приложения
+// @Tags аутентификация
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} reqresp.MFAEnrollResponse "Данные для приложения-аутентификатора"
+// @Failure 401 "Пользователь не авторизован"
+// @Failure 409 "Второй фактор уже включен"
+// @Router /auth-user/mfa/enroll [post]
+func (r *AuthUserRouter) EnrollMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, err := r.authz.UserIDFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	enrollment, err := r.mfa.Enroll(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mfa.ErrMFAAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	rsp := reqresp.MFAEnrollResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+		RecoveryCodes:   enrollment.RecoveryCodes,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ConfirmMFA Handler
+// @Summary Включение второго фактора
+// @Description Включает второй фактор, если передан текущий код из приложения-аутентификатора
+// @Tags аутентификация
+// @Accept json
+// @Security ApiKeyAuth
+// @Param request body reqresp.MFACodeRequest true "Код из приложения"
+// @Success 200 "Второй фактор включен"
+// @Failure 400 "Неверные входные параметры или неверный код"
+// @Failure 401 "Пользователь не авторизован"
+// @Failure 409 "Настройка не начата или второй фактор уже включен"
+// @Router /auth-user/mfa/confirm [post]
+func (r *AuthUserRouter) ConfirmMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, err := r.authz.UserIDFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.mfa.Confirm(ctx, userID, req.Code); err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, mfa.ErrMFANotEnrolled) || errors.Is(err, mfa.ErrMFAAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DisableMFA Handler
+// @Summary Выключение второго фактора
+// @Description Выключает второй фактор по коду из приложения или коду восстановления
+// @Tags аутентификация
+// @Accept json
+// @Security ApiKeyAuth
+// @Param request body reqresp.MFACodeRequest true "Код из приложения или код восстановления"
+// @Success 200 "Второй фактор выключен"
+// @Failure 400 "Неверные входные параметры или неверный код"
+// @Failure 401 "Пользователь не авторизован"
+// @Failure 409 "Второй фактор не включен"
+// @Router /auth-user/mfa/disable [post]
+func (r *AuthUserRouter) DisableMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, err := r.authz.UserIDFromContext(ctx)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.mfa.Disable(ctx, userID, req.Code); err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, mfa.ErrMFANotEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	categoryservice "github.com/CakeForKit/CraftPlace.git/internal/services/category_service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategoryRouter struct {
+	categoryServ categoryservice.CategoryServ
+}
+
+// NewCategoryRouter - изменение категорий, доступно только администраторам.
+// Чтение категорий остается публичным (см. SearcherRouter).
+func NewCategoryRouter(
+	router *gin.RouterGroup,
+	categoryServ categoryservice.CategoryServ,
+	authMiddleware AuthMiddleware,
+) CategoryRouter {
+	r := CategoryRouter{
+		categoryServ: categoryServ,
+	}
+	gr := router.Group("categories", authMiddleware.Required(), authMiddleware.RequireRole(tokenmaker.AdminRole))
+	gr.POST("", r.AddCategory)
+	gr.PUT("", r.UpdateCategory)
+	gr.DELETE("", r.DeleteCategory)
+	return r
+}
+
+// AddCategory godoc
+// @Summary Добавить категорию
+// @Description Создает новую категорию товаров. Доступно только администраторам
+// @Tags Категории
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param request body reqresp.AddCategoryRequest true "Данные новой категории"
+// @Success 201 {object} map[string]interface{} "Категория успешно создана"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Недостаточно прав"
+// @Failure 409 {object} map[string]interface{} "Категория с таким названием уже есть"
+// @Router /categories [post]
+func (r *CategoryRouter) AddCategory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.AddCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.categoryServ.Add(ctx, req); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{})
+}
+
+// UpdateCategory godoc
+// @Summary Обновить категорию
+// @Description Обновляет название и описание категории. Доступно только администраторам
+// @Tags Категории
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param request body reqresp.UpdateCategoryRequest true "Данные для обновления категории"
+// @Success 200 {object} map[string]interface{} "Категория успешно обновлена"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Недостаточно прав"
+// @Failure 404 {object} map[string]interface{} "Категория не найдена"
+// @Failure 409 {object} map[string]interface{} "Категория с таким названием уже есть"
+// @Router /categories [put]
+func (r *CategoryRouter) UpdateCategory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.categoryServ.Update(ctx, req); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteCategory godoc
+// @Summary Удалить категорию
+// @Description Удаляет категорию. Если к ней привязаны товары, удаление отклоняется,
+// @Description а с detach_products=true категория сначала убирается из товаров. Доступно только администраторам
+// @Tags Категории
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param request body reqresp.DeleteCategoryRequest true "Данные для удаления категории"
+// @Success 200 {object} map[string]interface{} "Категория успешно удалена"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Недостаточно прав"
+// @Failure 404 {object} map[string]interface{} "Категория не найдена"
+// @Failure 409 {object} map[string]interface{} "Категория используется товарами"
+// @Router /categories [delete]
+func (r *CategoryRouter) DeleteCategory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.DeleteCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	categoryID, err := uuid.Parse(req.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := r.categoryServ.Delete(ctx, categoryID, req.DetachProducts); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
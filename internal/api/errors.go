@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	categoryservice "github.com/CakeForKit/CraftPlace.git/internal/services/category_service"
+	followservice "github.com/CakeForKit/CraftPlace.git/internal/services/follow_service"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	postservice "github.com/CakeForKit/CraftPlace.git/internal/services/post_service"
+	productservice "github.com/CakeForKit/CraftPlace.git/internal/services/product_service"
+	shopservice "github.com/CakeForKit/CraftPlace.git/internal/services/shop_service"
+)
+
+// catalogErrorStatus - HTTP-статус ошибки изменения магазина, товара, поста, категории, изображений или подписки
+func catalogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrNotAuthZ):
+		return http.StatusUnauthorized
+	case errors.Is(err, auth.ErrHasNoRights),
+		errors.Is(err, shopservice.ErrEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, shopservice.ErrShopNotFound),
+		errors.Is(err, productservice.ErrProductNotFound),
+		errors.Is(err, postservice.ErrPostNotFound),
+		errors.Is(err, categoryservice.ErrCategoryNotFound),
+		errors.Is(err, mediaservice.ErrImageNotFound),
+		errors.Is(err, mediaservice.ErrOwnerNotFound),
+		errors.Is(err, followservice.ErrShopNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, categoryservice.ErrParentNotFound),
+		errors.Is(err, shopservice.ErrInvalidShop),
+		errors.Is(err, shopservice.ErrInvalidContact),
+		errors.Is(err, mediaservice.ErrInvalidImage),
+		errors.Is(err, mediaservice.ErrInvalidOrder),
+		errors.Is(err, followservice.ErrInvalidFilter):
+		return http.StatusBadRequest
+	case errors.Is(err, mediaservice.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, mediaservice.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, categoryservice.ErrCategoryInUse),
+		errors.Is(err, categoryservice.ErrDuplicateTitle),
+		errors.Is(err, categoryservice.ErrCategoryCycle),
+		errors.Is(err, categoryservice.ErrCategoryHasChildren),
+		errors.Is(err, mediaservice.ErrTooManyImages):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
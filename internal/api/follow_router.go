@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	followservice "github.com/CakeForKit/CraftPlace.git/internal/services/follow_service"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FollowRouter struct {
+	followServ followservice.FollowServ
+	mediaServ  mediaservice.MediaServ
+}
+
+func NewFollowRouter(
+	router *gin.RouterGroup,
+	followServ followservice.FollowServ,
+	mediaServ mediaservice.MediaServ,
+	authMiddleware AuthMiddleware,
+) FollowRouter {
+	r := FollowRouter{
+		followServ: followServ,
+		mediaServ:  mediaServ,
+	}
+	gr := router.Group("user-follows", authMiddleware.Required())
+	gr.POST("/:id_shop", r.FollowShop)
+	gr.DELETE("/:id_shop", r.UnfollowShop)
+
+	router.GET("feed", authMiddleware.Required(), r.Feed)
+	return r
+}
+
+// FollowShop godoc
+// @Summary Подписаться на магазин
+// @Description Подписывает пользователя на магазин, повторная подписка ничего не меняет
+// @Tags Подписки
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_shop path string true "ID магазина" format(uuid)
+// @Success 200 {object} map[string]interface{} "Подписка оформлена"
+// @Failure 400 {object} map[string]interface{} "Неверный формат ID"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 404 {object} map[string]interface{} "Магазин не найден"
+// @Router /user/user-follows/{id_shop} [post]
+func (r *FollowRouter) FollowShop(c *gin.Context) {
+	ctx := c.Request.Context()
+	shopID, err := uuid.Parse(c.Param("id_shop"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id_shop format"})
+		return
+	}
+
+	if err := r.followServ.Follow(ctx, shopID); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnfollowShop godoc
+// @Summary Отписаться от магазина
+// @Description Отменяет подписку пользователя на магазин, без подписки ничего не меняет
+// @Tags Подписки
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_shop path string true "ID магазина" format(uuid)
+// @Success 200 {object} map[string]interface{} "Подписка отменена"
+// @Failure 400 {object} map[string]interface{} "Неверный формат ID"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Router /user/user-follows/{id_shop} [delete]
+func (r *FollowRouter) UnfollowShop(c *gin.Context) {
+	ctx := c.Request.Context()
+	shopID, err := uuid.Parse(c.Param("id_shop"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id_shop format"})
+		return
+	}
+
+	if err := r.followServ.Unfollow(ctx, shopID); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// Feed godoc
+// @Summary Лента подписок
+// @Description Посты магазинов, на которые подписан пользователь, новые первыми.
+// @Description Следующая страница запрашивается с курсором из next_cursor.
+// @Tags Подписки
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor предыдущего ответа"
+// @Success 200 {object} reqresp.FeedPageResponse "Посты ленты"
+// @Failure 400 {object} map[string]interface{} "Неверный формат параметров"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /user/feed [get]
+func (r *FollowRouter) Feed(c *gin.Context) {
+	ctx := c.Request.Context()
+	page, err := queryPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	posts, err := r.followServ.Feed(ctx, page)
+	if err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	items, err := postResponses(ctx, r.mediaServ, posts.Items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reqresp.FeedPageResponse{
+		Items:      items,
+		NextCursor: posts.NextCursor,
+	})
+}
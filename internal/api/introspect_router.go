@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/gin-gonic/gin"
+)
+
+// IntrospectRouter - проверка токенов доступа для других сервисов, подключается только в cmd/auth.
+// По RFC 7662 вызывающий должен быть аутентифицирован: без токена сервиса ответ 401
+type IntrospectRouter struct {
+	authu authuser.AuthUser
+}
+
+func NewIntrospectRouter(router *gin.RouterGroup, authu authuser.AuthUser, serviceToken string) (IntrospectRouter, error) {
+	if serviceToken == "" {
+		return IntrospectRouter{}, ErrNoServiceToken
+	}
+	r := IntrospectRouter{
+		authu: authu,
+	}
+	router.POST("/auth-user/introspect", serviceTokenRequired(serviceToken), r.Introspect)
+	return r, nil
+}
+
+// Introspect Handler
+// @Summary Проверка токена доступа
+// @Description Только в сервисе авторизации (cmd/auth). Проверяет подпись, срок и отзыв токена доступа.
+// @Description Недействительный токен - не ошибка: ответ 200 с active=false.
+// @Description Вызывающий сервис передает AUTH_SERVICE_TOKEN в заголовке Authorization: Bearer
+// @Tags аутентификация
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен сервиса"
+// @Param request body reqresp.IntrospectRequest true "Токен доступа"
+// @Success 200 {object} reqresp.IntrospectResponse "Состояние токена"
+// @Failure 400 "Неверные входные параметры"
+// @Failure 401 "Нет токена сервиса или он неверный"
+// @Failure 500 "Внутренняя ошибка сервера"
+// @Router /auth-user/introspect [post]
+func (r *IntrospectRouter) Introspect(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.IntrospectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, err := r.authu.VerifyByToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, tokenmaker.ErrInvalidToken) ||
+			errors.Is(err, tokenmaker.ErrExpiredToken) ||
+			errors.Is(err, tokenmaker.ErrIncorrectRole) ||
+			errors.Is(err, authuser.ErrRevokedToken) {
+			c.JSON(http.StatusOK, reqresp.IntrospectResponse{Active: false})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	rsp := reqresp.IntrospectResponse{
+		Active:    true,
+		TokenID:   payload.GetID().String(),
+		Subject:   payload.GetPersonID().String(),
+		Role:      string(payload.GetRole()),
+		IssuedAt:  payload.GetIssuedAt().Unix(),
+		NotBefore: payload.GetNotBefore().Unix(),
+		ExpiresAt: payload.GetExpiredAt().Unix(),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge - сколько другие сервисы могут кэшировать ключи. Новый ключ публикуется заранее,
+// поэтому его Not-Before должен отстоять от момента публикации хотя бы на это время
+const jwksMaxAge = 300
+
+// JWKSRouter - открытые ключи подписи токенов по стандартному пути вне /api/v1.
+// Если токены подписываются симметричным ключом, набор пуст
+type JWKSRouter struct {
+	tokenMaker tokenmaker.TokenMaker
+}
+
+func NewJWKSRouter(router *gin.RouterGroup, tokenMaker tokenmaker.TokenMaker) JWKSRouter {
+	r := JWKSRouter{
+		tokenMaker: tokenMaker,
+	}
+	router.GET("/.well-known/jwks.json", r.JWKS)
+	return r
+}
+
+// JWKS godoc
+// @Summary Открытые ключи подписи токенов
+// @Description Набор JWK (RFC 7517) для проверки подписи токенов доступа другими сервисами.
+// @Description Для jwt_eddsa и jwt_es256 - все не истекшие ключи, для jwt и PASETO набор пуст.
+// @Description Отдается по корню сервера, вне /api/v1; ответ можно кэшировать 5 минут.
+// @Tags аутентификация
+// @Produce json
+// @Success 200 {object} tokenmaker.JWKSet "Открытые ключи"
+// @Router /.well-known/jwks.json [get]
+func (r *JWKSRouter) JWKS(c *gin.Context) {
+	set := tokenmaker.JWKSet{Keys: []tokenmaker.JWK{}}
+	if keySet, ok := r.tokenMaker.(tokenmaker.KeySet); ok {
+		set = keySet.JWKS()
+	}
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+	c.JSON(http.StatusOK, set)
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead - запас на заголовки и границы multipart сверх размера самого файла
+const multipartOverhead = 64 << 10
+
+type MediaRouter struct {
+	mediaServ     mediaservice.MediaServ
+	maxUploadSize int64
+}
+
+func NewMediaRouter(
+	router *gin.RouterGroup,
+	mediaServ mediaservice.MediaServ,
+	maxUploadSize int64,
+	authMiddleware AuthMiddleware,
+) MediaRouter {
+	r := MediaRouter{
+		mediaServ:     mediaServ,
+		maxUploadSize: maxUploadSize,
+	}
+	productGr := router.Group("user-products/:id_product/images", authMiddleware.Required())
+	productGr.POST("", r.AddProductImage)
+	productGr.PUT("", r.ReorderProductImages)
+	productGr.DELETE("/:id_image", r.DeleteProductImage)
+
+	postGr := router.Group("user-posts/:id_post/images", authMiddleware.Required())
+	postGr.POST("", r.AddPostImage)
+	postGr.PUT("", r.ReorderPostImages)
+	postGr.DELETE("/:id_image", r.DeletePostImage)
+	return r
+}
+
+// AddProductImage godoc
+// @Summary Добавить изображение товара
+// @Description Загружает изображение (jpeg, png или gif) в конец списка изображений товара и создает миниатюру.
+// @Description Тип файла определяется по содержимому.
+// @Tags Изображения
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_product path string true "ID товара" format(uuid)
+// @Param image formData file true "Файл изображения"
+// @Success 201 {object} reqresp.ImageResponse "Изображение добавлено"
+// @Failure 400 {object} map[string]interface{} "Нет файла или файл поврежден"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Товар принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Товар не найден"
+// @Failure 409 {object} map[string]interface{} "У товара уже максимальное число изображений"
+// @Failure 413 {object} map[string]interface{} "Файл слишком большой"
+// @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип файла"
+// @Router /user/user-products/{id_product}/images [post]
+func (r *MediaRouter) AddProductImage(c *gin.Context) {
+	r.addImage(c, models.ImageOwnerProduct, "id_product")
+}
+
+// ReorderProductImages godoc
+// @Summary Изменить порядок изображений товара
+// @Description Задает новый порядок изображений, в списке должны быть все изображения товара ровно по одному разу
+// @Tags Изображения
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_product path string true "ID товара" format(uuid)
+// @Param request body reqresp.ReorderImagesRequest true "ID изображений в новом порядке"
+// @Success 200 {object} map[string]interface{} "Порядок изменен"
+// @Failure 400 {object} map[string]interface{} "Список не совпадает с изображениями товара"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Товар принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Товар не найден"
+// @Router /user/user-products/{id_product}/images [put]
+func (r *MediaRouter) ReorderProductImages(c *gin.Context) {
+	r.reorderImages(c, models.ImageOwnerProduct, "id_product")
+}
+
+// DeleteProductImage godoc
+// @Summary Удалить изображение товара
+// @Description Удаляет изображение и его миниатюру, остальные изображения сдвигаются
+// @Tags Изображения
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_product path string true "ID товара" format(uuid)
+// @Param id_image path string true "ID изображения" format(uuid)
+// @Success 200 {object} map[string]interface{} "Изображение удалено"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Товар принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Товар или изображение не найдены"
+// @Router /user/user-products/{id_product}/images/{id_image} [delete]
+func (r *MediaRouter) DeleteProductImage(c *gin.Context) {
+	r.deleteImage(c, models.ImageOwnerProduct, "id_product")
+}
+
+// AddPostImage godoc
+// @Summary Добавить изображение поста
+// @Description Загружает изображение (jpeg, png или gif) в конец списка изображений поста и создает миниатюру.
+// @Description Тип файла определяется по содержимому.
+// @Tags Изображения
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_post path string true "ID поста" format(uuid)
+// @Param image formData file true "Файл изображения"
+// @Success 201 {object} reqresp.ImageResponse "Изображение добавлено"
+// @Failure 400 {object} map[string]interface{} "Нет файла или файл поврежден"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Пост принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Пост не найден"
+// @Failure 409 {object} map[string]interface{} "У поста уже максимальное число изображений"
+// @Failure 413 {object} map[string]interface{} "Файл слишком большой"
+// @Failure 415 {object} map[string]interface{} "Неподдерживаемый тип файла"
+// @Router /user/user-posts/{id_post}/images [post]
+func (r *MediaRouter) AddPostImage(c *gin.Context) {
+	r.addImage(c, models.ImageOwnerPost, "id_post")
+}
+
+// ReorderPostImages godoc
+// @Summary Изменить порядок изображений поста
+// @Description Задает новый порядок изображений, в списке должны быть все изображения поста ровно по одному разу
+// @Tags Изображения
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_post path string true "ID поста" format(uuid)
+// @Param request body reqresp.ReorderImagesRequest true "ID изображений в новом порядке"
+// @Success 200 {object} map[string]interface{} "Порядок изменен"
+// @Failure 400 {object} map[string]interface{} "Список не совпадает с изображениями поста"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Пост принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Пост не найден"
+// @Router /user/user-posts/{id_post}/images [put]
+func (r *MediaRouter) ReorderPostImages(c *gin.Context) {
+	r.reorderImages(c, models.ImageOwnerPost, "id_post")
+}
+
+// DeletePostImage godoc
+// @Summary Удалить изображение поста
+// @Description Удаляет изображение и его миниатюру, остальные изображения сдвигаются
+// @Tags Изображения
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param id_post path string true "ID поста" format(uuid)
+// @Param id_image path string true "ID изображения" format(uuid)
+// @Success 200 {object} map[string]interface{} "Изображение удалено"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Пост принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Пост или изображение не найдены"
+// @Router /user/user-posts/{id_post}/images/{id_image} [delete]
+func (r *MediaRouter) DeletePostImage(c *gin.Context) {
+	r.deleteImage(c, models.ImageOwnerPost, "id_post")
+}
+
+func (r *MediaRouter) addImage(c *gin.Context, owner models.ImageOwner, ownerParam string) {
+	ctx := c.Request.Context()
+	ownerID, err := uuid.Parse(c.Param(ownerParam))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s format", ownerParam)})
+		return
+	}
+
+	// тело больше лимита обрывается при чтении, не дожидаясь конца загрузки
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, r.maxUploadSize+multipartOverhead)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": mediaservice.ErrImageTooLarge.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if fileHeader.Size > r.maxUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": mediaservice.ErrImageTooLarge.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	image, err := r.mediaServ.AddImage(ctx, owner, ownerID, data)
+	if err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, image)
+}
+
+func (r *MediaRouter) reorderImages(c *gin.Context, owner models.ImageOwner, ownerParam string) {
+	ctx := c.Request.Context()
+	ownerID, err := uuid.Parse(c.Param(ownerParam))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s format", ownerParam)})
+		return
+	}
+	var req reqresp.ReorderImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.mediaServ.ReorderImages(ctx, owner, ownerID, req); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (r *MediaRouter) deleteImage(c *gin.Context, owner models.ImageOwner, ownerParam string) {
+	ctx := c.Request.Context()
+	ownerID, err := uuid.Parse(c.Param(ownerParam))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s format", ownerParam)})
+		return
+	}
+	imageID, err := uuid.Parse(c.Param("id_image"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id_image format"})
+		return
+	}
+
+	if err := r.mediaServ.DeleteImage(ctx, owner, ownerID, imageID); err != nil {
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -16,13 +16,14 @@ type PostRouter struct {
 func NewPostRouter(
 	router *gin.RouterGroup,
 	postServ postservice.PostServ,
+	authMiddleware AuthMiddleware,
 ) PostRouter {
 	r := PostRouter{
 		postServ: postServ,
 	}
-	gr := router.Group("posts")
-	gr.POST("/", r.AddPostToShop)
-	gr.DELETE("/", r.DeletePost)
+	gr := router.Group("user-posts", authMiddleware.Required())
+	gr.POST("", r.AddPostToShop)
+	gr.DELETE("", r.DeletePost)
 	return r
 }
 
@@ -36,6 +37,9 @@ func NewPostRouter(
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.AddPostRequest true "Данные нового поста"
 // @Success 201 {object} map[string]interface{} "Пост успешно добавлен"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин или пост не найден"
 // @Router /user/user-posts [post]
 func (r *PostRouter) AddPostToShop(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -47,7 +51,7 @@ func (r *PostRouter) AddPostToShop(c *gin.Context) {
 	}
 
 	if err := r.postServ.Add(ctx, req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -63,6 +67,9 @@ func (r *PostRouter) AddPostToShop(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.DeletePostRequest true "Данные для удаления поста"
 // @Success 200 {object} map[string]interface{} "Пост успешно удален"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин или пост не найден"
 // @Router /user/user-posts [delete]
 func (r *PostRouter) DeletePost(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -79,7 +86,7 @@ func (r *PostRouter) DeletePost(c *gin.Context) {
 		return
 	}
 	if err := r.postServ.Delete(ctx, postID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
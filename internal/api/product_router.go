@@ -16,14 +16,15 @@ type ProductRouter struct {
 func NewProductRouter(
 	router *gin.RouterGroup,
 	productServ productservice.ProductServ,
+	authMiddleware AuthMiddleware,
 ) ProductRouter {
 	r := ProductRouter{
 		productServ: productServ,
 	}
-	gr := router.Group("products")
-	gr.POST("/", r.AddProductToShop)
-	gr.PUT("/", r.UpdateProduct)
-	gr.DELETE("/", r.DeleteProduct)
+	gr := router.Group("user-products", authMiddleware.Required())
+	gr.POST("", r.AddProductToShop)
+	gr.PUT("", r.UpdateProduct)
+	gr.DELETE("", r.DeleteProduct)
 
 	return r
 }
@@ -38,6 +39,9 @@ func NewProductRouter(
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.AddProductRequest true "Данные нового товара"
 // @Success 201 {object} map[string]interface{} "Товар успешно добавлен"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин или товар не найден"
 // @Router /user/user-products [post]
 func (r *ProductRouter) AddProductToShop(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -49,7 +53,7 @@ func (r *ProductRouter) AddProductToShop(c *gin.Context) {
 	}
 
 	if err := r.productServ.Add(ctx, req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -65,6 +69,9 @@ func (r *ProductRouter) AddProductToShop(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.UpdateProductRequest true "Данные для обновления товара"
 // @Success 200 {object} map[string]interface{} "Товар успешно обновлен"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин или товар не найден"
 // @Router /user/user-products [put]
 func (r *ProductRouter) UpdateProduct(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -76,7 +83,7 @@ func (r *ProductRouter) UpdateProduct(c *gin.Context) {
 	}
 
 	if err := r.productServ.Update(ctx, req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
@@ -92,6 +99,9 @@ func (r *ProductRouter) UpdateProduct(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.DeleteProductRequest true "Данные для удаления товара"
 // @Success 200 {object} map[string]interface{} "Товар успешно удален"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин или товар не найден"
 // @Router /user/user-products [delete]
 func (r *ProductRouter) DeleteProduct(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -108,7 +118,7 @@ func (r *ProductRouter) DeleteProduct(c *gin.Context) {
 		return
 	}
 	if err := r.productServ.Delete(ctx, productID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
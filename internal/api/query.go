@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// queryUint возвращает необязательный числовой query-параметр, def - если он не задан
+func queryUint(c *gin.Context, key string, def uint64) (uint64, error) {
+	v := c.Query(key)
+	if v == "" {
+		return def, nil
+	}
+	res, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return res, nil
+}
+
+// queryBool возвращает необязательный логический query-параметр, false - если он не задан
+func queryBool(c *gin.Context, key string) (bool, error) {
+	v := c.Query(key)
+	if v == "" {
+		return false, nil
+	}
+	res, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return res, nil
+}
+
+// queryUUID возвращает необязательный query-параметр uuid, uuid.Nil - если он не задан
+func queryUUID(c *gin.Context, key string) (uuid.UUID, error) {
+	v := c.Query(key)
+	if v == "" {
+		return uuid.Nil, nil
+	}
+	res, err := uuid.Parse(v)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return res, nil
+}
+
+// queryPage возвращает параметры страницы limit, offset и cursor
+func queryPage(c *gin.Context) (reqresp.PageOps, error) {
+	limit, err := queryUint(c, "limit", 0)
+	if err != nil {
+		return reqresp.PageOps{}, err
+	}
+	offset, err := queryUint(c, "offset", 0)
+	if err != nil {
+		return reqresp.PageOps{}, err
+	}
+	return reqresp.PageOps{
+		Limit:  int(min(limit, pagination.MaxLimit)),
+		Offset: int(min(offset, math.MaxInt32)),
+		Cursor: c.Query("cursor"),
+	}, nil
+}
+
+// toResponses переводит список моделей в список ответов
+func toResponses[M any, R any](items []M, toResponse func(M) R) []R {
+	res := make([]R, len(items))
+	for i, v := range items {
+		res[i] = toResponse(v)
+	}
+	return res
+}
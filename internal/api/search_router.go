@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	searchservice "github.com/CakeForKit/CraftPlace.git/internal/services/search_service"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchRouter struct {
+	searchServ searchservice.SearchServ
+}
+
+func NewSearchRouter(router *gin.RouterGroup, searchServ searchservice.SearchServ) SearchRouter {
+	r := SearchRouter{
+		searchServ: searchServ,
+	}
+	router.GET("/search", r.Search)
+	return r
+}
+
+// Search godoc
+// @Summary Полнотекстовый поиск
+// @Description Ищет слова запроса в названиях и описаниях товаров, названиях магазинов и текстах постов
+// @Description с учетом русской морфологии ("серьги звезды" найдет "Серёжки со звездой"), ё и е не различаются.
+// @Description Запись подходит, если в ней есть хотя бы одно слово запроса; самые релевантные идут первыми.
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param q query string true "Поисковая строка, до 200 символов"
+// @Param kind query string false "Искать только среди записей одного вида" Enums(product, shop, post)
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param offset query integer false "Смещение от начала результатов" default(0)
+// @Success 200 {object} reqresp.SearchPageResponse "Найденные записи"
+// @Failure 400 {object} map[string]interface{} "Пустой запрос или неверные параметры"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /search [get]
+func (r *SearchRouter) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, err := queryPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filterOps := reqresp.SearchFilter{
+		Query: c.Query("q"),
+		Kind:  c.Query("kind"),
+		Page:  page,
+	}
+
+	hits, err := r.searchServ.Search(ctx, &filterOps)
+	if err != nil {
+		if errors.Is(err, searchservice.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, reqresp.SearchPageResponse{
+		Items: toResponses(hits.Items, (*models.SearchHit).ToResponse),
+		Total: hits.Total,
+	})
+}
@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"strconv"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	followservice "github.com/CakeForKit/CraftPlace.git/internal/services/follow_service"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
 	"github.com/CakeForKit/CraftPlace.git/internal/services/searcher"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,19 +16,30 @@ import (
 
 type SearcherRouter struct {
 	searcherServ searcher.Searcher
+	mediaServ    mediaservice.MediaServ
+	followServ   followservice.FollowServ
 }
 
-func NewSearcherRouter(router *gin.RouterGroup, searcherServ searcher.Searcher) SearcherRouter {
+func NewSearcherRouter(
+	router *gin.RouterGroup,
+	searcherServ searcher.Searcher,
+	mediaServ mediaservice.MediaServ,
+	followServ followservice.FollowServ,
+) SearcherRouter {
 	r := SearcherRouter{
 		searcherServ: searcherServ,
+		mediaServ:    mediaServ,
+		followServ:   followServ,
 	}
 	gr := router.Group("/")
 	gr.GET("/categories", r.GetCategories)
+	gr.GET("/categories/tree", r.GetCategoryTree)
 	gr.GET("/categories/:id_category", r.GetCategoryByID)
 	gr.GET("/shops", r.GetShops)
 	gr.GET("/shops/:id_shop", r.GetShopByID)
 	gr.GET("/products", r.GetProducts)
 	gr.GET("/posts", r.GetPosts)
+	gr.GET("/suggest", r.Suggest)
 
 	// gr.GET("/shops/:id_shop/posts", r.GetShopPosts)
 	// gr.GET("/shops/:id_shop/products", r.GetShopProducts)
@@ -38,31 +52,80 @@ func NewSearcherRouter(router *gin.RouterGroup, searcherServ searcher.Searcher)
 // @Tags Поиск
 // @Accept json
 // @Produce json
-// @Param title query string false "Фильтр по названию категории"
-// @Success 200 {array} reqresp.CategoryResponse
+// @Param title query string false "Фильтр по названию категории (подстрока без учета регистра)"
+// @Param id_shop query string false "Только категории, в которых есть товары магазина" format(uuid)
+// @Param sort_by query string false "Поле сортировки" Enums(title) default(title)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(asc)
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param offset query integer false "Смещение от начала списка, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor предыдущего ответа"
+// @Success 200 {object} reqresp.CategoryPageResponse
+// @Failure 400 {object} map[string]interface{} "Неверный формат параметров"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /categories [get]
 func (r *SearcherRouter) GetCategories(c *gin.Context) {
 	ctx := c.Request.Context()
 
+	shopID, err := queryUUID(c, "id_shop")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := queryPage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	filterOps := reqresp.CategoryFilter{
-		Title: c.Query("title"),
+		Title:  c.Query("title"),
+		ShopID: shopID,
+		SortBy: c.Query("sort_by"),
+		Order:  c.Query("order"),
+		Page:   page,
 	}
 
-	caterories, err := r.searcherServ.GetCategories(ctx, &filterOps)
+	categories, err := r.searcherServ.GetCategories(ctx, &filterOps)
+	if err != nil {
+		if errors.Is(err, searcher.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	tree, err := r.searcherServ.GetCategoryTree(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	resp := make([]reqresp.CategoryResponse, len(caterories))
-	for i, v := range caterories {
-		resp[i] = v.ToResponse()
+	c.JSON(http.StatusOK, reqresp.CategoryPageResponse{
+		Items:      toResponses(categories.Items, tree.ToResponse),
+		Total:      categories.Total,
+		NextCursor: categories.NextCursor,
+	})
+}
+
+// GetCategoryTree godoc
+// @Summary Получить дерево категорий
+// @Description Возвращает все категории в виде дерева, дочерние категории отсортированы по названию
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Success 200 {array} reqresp.CategoryTreeResponse "Корневые категории с вложенными дочерними"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /categories/tree [get]
+func (r *SearcherRouter) GetCategoryTree(c *gin.Context) {
+	tree, err := r.searcherServ.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, tree.ToTreeResponse())
 }
 
 // GetCategoryByID godoc
 // @Summary Получить категорию по ID
-// @Description Возвращает информацию о категории по её идентификатору
+// @Description Возвращает информацию о категории по её идентификатору вместе с путем от корня
 // @Tags Поиск
 // @Accept json
 // @Produce json
@@ -89,7 +152,12 @@ func (r *SearcherRouter) GetCategoryByID(c *gin.Context) {
 		}
 		return
 	}
-	c.JSON(http.StatusOK, category.ToResponse())
+	tree, err := r.searcherServ.GetCategoryTree(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tree.ToResponse(category))
 }
 
 // GetShops godoc
@@ -98,33 +166,64 @@ func (r *SearcherRouter) GetCategoryByID(c *gin.Context) {
 // @Tags Поиск
 // @Accept json
 // @Produce json
-// @Param title query string false "Фильтр по названию магазина"
-// @Param id_user query string false "Фильтр по ID пользователя" format(uuid) default(00000000-0000-0000-0000-000000000000)
-// @Success 200 {array} reqresp.ShopResponse
+// @Param title query string false "Фильтр по названию магазина (подстрока без учета регистра)"
+// @Param id_user query string false "Фильтр по ID пользователя" format(uuid)
+// @Param id_category query string false "Только магазины, у которых есть товары категории" format(uuid)
+// @Param sort_by query string false "Поле сортировки" Enums(title) default(title)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(asc)
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param offset query integer false "Смещение от начала списка, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor предыдущего ответа"
+// @Success 200 {object} reqresp.ShopPageResponse
+// @Failure 400 {object} map[string]interface{} "Неверный формат параметров"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /shops [get]
 func (r *SearcherRouter) GetShops(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	userID, err := uuid.Parse(c.Query("id_user")) // default = uuid.Nil
+	userID, err := queryUUID(c, "id_user")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	categoryID, err := queryUUID(c, "id_category")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := queryPage(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	filterOps := reqresp.ShopFilter{
-		Title:  c.Query("title"),
-		UserID: userID,
+		Title:      c.Query("title"),
+		UserID:     userID,
+		CategoryID: categoryID,
+		SortBy:     c.Query("sort_by"),
+		Order:      c.Query("order"),
+		Page:       page,
 	}
 
 	shops, err := r.searcherServ.GetShops(ctx, &filterOps)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, searcher.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	resp := make([]reqresp.ShopResponse, len(shops))
-	for i, v := range shops {
-		resp[i] = v.ToResponse()
+	items, err := shopResponses(ctx, r.followServ, shops.Items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, reqresp.ShopPageResponse{
+		Items:      items,
+		Total:      shops.Total,
+		NextCursor: shops.NextCursor,
+	})
 }
 
 // GetShopByID godoc
@@ -156,7 +255,12 @@ func (r *SearcherRouter) GetShopByID(c *gin.Context) {
 		}
 		return
 	}
-	c.JSON(http.StatusOK, shop.ToResponse())
+	items, err := shopResponses(ctx, r.followServ, []*models.Shop{shop})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items[0])
 }
 
 // GetProducts godoc
@@ -165,56 +269,85 @@ func (r *SearcherRouter) GetShopByID(c *gin.Context) {
 // @Tags Поиск
 // @Accept json
 // @Produce json
-// @Param title query string false "Фильтр по названию товара"
+// @Param title query string false "Фильтр по названию товара (подстрока без учета регистра)"
 // @Param min_cost query integer false "Минимальная цена товара" default(0)
-// @Param max_cost query integer false "Максимальная цена товара" default(100000)
-// @Param id_shop query string false "Фильтр по ID магазина" format(uuid) default(00000000-0000-0000-0000-000000000000)
-// @Param id_category query string false "Фильтр по ID категории" format(uuid) default(00000000-0000-0000-0000-000000000000)
-// @Success 200 {array} reqresp.ProductResponse "Список товаров"
+// @Param max_cost query integer false "Максимальная цена товара, 0 - без ограничения" default(0)
+// @Param id_shop query string false "Фильтр по ID магазина" format(uuid)
+// @Param id_category query string false "Фильтр по ID категории" format(uuid)
+// @Param include_descendants query boolean false "Учитывать товары всех дочерних категорий id_category" default(false)
+// @Param sort_by query string false "Поле сортировки" Enums(title, cost) default(title)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(asc)
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param offset query integer false "Смещение от начала списка, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor предыдущего ответа"
+// @Success 200 {object} reqresp.ProductPageResponse "Список товаров"
 // @Failure 400 {object} map[string]interface{} "Неверный формат параметров"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /products [get]
 func (r *SearcherRouter) GetProducts(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	minCost, err := strconv.ParseUint(c.Query("min_cost"), 10, 64)
+	minCost, err := queryUint(c, "min_cost", 0)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	maxCost, err := strconv.ParseUint(c.Query("max_cost"), 10, 64)
+	maxCost, err := queryUint(c, "max_cost", 0)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	shopID, err := uuid.Parse(c.Query("id_shop")) // default = uuid.Nil
+	shopID, err := queryUUID(c, "id_shop")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	categoryID, err := uuid.Parse(c.Query("id_category")) // default = uuid.Nil
+	categoryID, err := queryUUID(c, "id_category")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	includeDescendants, err := queryBool(c, "include_descendants")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := queryPage(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	filterOps := reqresp.ProductFilter{
-		Title:      c.Query("title"), // default = ""
-		MaxCost:    maxCost,
-		MinCost:    minCost,
-		ShopID:     shopID,
-		CategoryID: categoryID,
+		Title:              c.Query("title"),
+		MaxCost:            maxCost,
+		MinCost:            minCost,
+		ShopID:             shopID,
+		CategoryID:         categoryID,
+		IncludeDescendants: includeDescendants,
+		SortBy:             c.Query("sort_by"),
+		Order:              c.Query("order"),
+		Page:               page,
 	}
 
 	products, err := r.searcherServ.GetProducts(ctx, &filterOps)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, searcher.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	resp := make([]reqresp.ProductResponse, len(products))
-	for i, v := range products {
-		resp[i] = v.ToResponse()
+	items, err := productResponses(ctx, r.mediaServ, products.Items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, reqresp.ProductPageResponse{
+		Items:      items,
+		Total:      products.Total,
+		NextCursor: products.NextCursor,
+	})
 }
 
 // GetPosts godoc
@@ -223,33 +356,147 @@ func (r *SearcherRouter) GetProducts(c *gin.Context) {
 // @Tags Поиск
 // @Accept json
 // @Produce json
+// @Param title query string false "Фильтр по тексту поста (подстрока без учета регистра)"
 // @Param id_shop query string false "Фильтр по ID магазина" format(uuid)
-// @Success 200 {array} reqresp.PostResponse "Список постов"
-// @Failure 400 {object} map[string]interface{} "Неверный формат ID магазина"
+// @Param sort_by query string false "Поле сортировки" Enums(time_publication) default(time_publication)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(desc)
+// @Param limit query integer false "Размер страницы, не больше 100" default(20)
+// @Param offset query integer false "Смещение от начала списка, не используется вместе с cursor" default(0)
+// @Param cursor query string false "Курсор следующей страницы из next_cursor предыдущего ответа"
+// @Success 200 {object} reqresp.PostPageResponse "Список постов"
+// @Failure 400 {object} map[string]interface{} "Неверный формат параметров"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /posts [get]
 func (r *SearcherRouter) GetPosts(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	shopID, err := uuid.Parse(c.Query("id_shop")) // default = uuid.Nil
+	shopID, err := queryUUID(c, "id_shop")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := queryPage(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	filterOps := reqresp.PostFilter{
+		Title:  c.Query("title"),
 		ShopID: shopID,
+		SortBy: c.Query("sort_by"),
+		Order:  c.Query("order"),
+		Page:   page,
 	}
 
 	posts, err := r.searcherServ.GetPosts(ctx, &filterOps)
+	if err != nil {
+		if errors.Is(err, searcher.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	items, err := postResponses(ctx, r.mediaServ, posts.Items)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	resp := make([]reqresp.PostResponse, len(posts))
-	for i, v := range posts {
-		resp[i] = v.ToResponse()
+	c.JSON(http.StatusOK, reqresp.PostPageResponse{
+		Items:      items,
+		Total:      posts.Total,
+		NextCursor: posts.NextCursor,
+	})
+}
+
+// Suggest godoc
+// @Summary Подсказки для строки поиска
+// @Description Названия магазинов, товаров и категорий, похожие на набранный текст: сначала те, что начинаются
+// @Description с него, затем содержащие его и похожие с опечатками. Запрос короче 2 символов дает пустые списки.
+// @Description Если подсказки не нашлись за отведенное время, списки пустые и timed_out = true.
+// @Tags Поиск
+// @Accept json
+// @Produce json
+// @Param q query string true "Набранный текст, до 100 символов"
+// @Param limit query integer false "Подсказок каждого вида, не больше 10" default(5)
+// @Success 200 {object} reqresp.SuggestResponse "Подсказки"
+// @Failure 400 {object} map[string]interface{} "Неверные параметры"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /suggest [get]
+func (r *SearcherRouter) Suggest(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit, err := queryUint(c, "limit", 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filterOps := reqresp.SuggestFilter{
+		Query: c.Query("q"),
+		Limit: int(min(limit, searcher.MaxSuggestLimit)),
+	}
+
+	suggestions, err := r.searcherServ.Suggest(ctx, &filterOps)
+	if err != nil {
+		if errors.Is(err, searcher.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// shopResponses - ответы по магазинам вместе с числом подписчиков
+func shopResponses(ctx context.Context, followServ followservice.FollowServ, shops []*models.Shop) ([]reqresp.ShopResponse, error) {
+	ids := make(uuid.UUIDs, len(shops))
+	for i, shop := range shops {
+		ids[i] = shop.GetID()
+	}
+	counts, err := followServ.FollowerCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := toResponses(shops, (*models.Shop).ToResponse)
+	for i := range res {
+		res[i].Followers = counts[ids[i]]
+	}
+	return res, nil
+}
+
+// productResponses - ответы по товарам страницы вместе с их изображениями
+func productResponses(ctx context.Context, mediaServ mediaservice.MediaServ, products []*models.Product) ([]reqresp.ProductResponse, error) {
+	ids := make(uuid.UUIDs, len(products))
+	for i, product := range products {
+		ids[i] = product.GetID()
+	}
+	images, err := mediaServ.Images(ctx, models.ImageOwnerProduct, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := toResponses(products, (*models.Product).ToResponse)
+	for i := range res {
+		res[i].Images = images[ids[i]]
+	}
+	return res, nil
+}
+
+// postResponses - ответы по постам страницы вместе с их изображениями
+func postResponses(ctx context.Context, mediaServ mediaservice.MediaServ, posts []*models.Post) ([]reqresp.PostResponse, error) {
+	ids := make(uuid.UUIDs, len(posts))
+	for i, post := range posts {
+		ids[i] = post.GetID()
+	}
+	images, err := mediaServ.Images(ctx, models.ImageOwnerPost, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := toResponses(posts, (*models.Post).ToResponse)
+	for i := range res {
+		res[i].Images = images[ids[i]]
 	}
-	c.JSON(http.StatusOK, resp)
+	return res, nil
 }
 
 // // GetShopPosts godoc
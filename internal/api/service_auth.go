@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	authclient "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_client"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Вызовы сервиса авторизации от других сервисов (gRPC и introspect) подписываются общим
+// секретом AUTH_SERVICE_TOKEN: Authorization: Bearer <токен> в заголовке или метаданных
+
+var (
+	ErrNoServiceToken      = errors.New("service token is not configured")
+	ErrInvalidServiceToken = errors.New("invalid service token")
+)
+
+// validServiceToken сравнивает за постоянное время, чтобы токен нельзя было подобрать по времени ответа
+func validServiceToken(header string, serviceToken string) bool {
+	token, ok := strings.CutPrefix(header, bearerPrefix)
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(serviceToken)) == 1
+}
+
+// serviceTokenInterceptor пропускает к методам только вызовы с токеном сервиса
+func serviceTokenInterceptor(serviceToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(authclient.ServiceTokenMetadata)
+		if len(values) != 1 || !validServiceToken(values[0], serviceToken) {
+			return nil, status.Error(codes.Unauthenticated, ErrInvalidServiceToken.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+// serviceTokenRequired - то же для HTTP
+func serviceTokenRequired(serviceToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !validServiceToken(c.GetHeader(authorizationHeader), serviceToken) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": ErrInvalidServiceToken.Error()})
+			return
+		}
+		c.Next()
+	}
+}
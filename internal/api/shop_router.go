@@ -16,20 +16,22 @@ type ShopRouter struct {
 func NewShopRouter(
 	router *gin.RouterGroup,
 	shopServ shopservice.ShopServ,
+	authMiddleware AuthMiddleware,
 ) ShopRouter {
 	r := ShopRouter{
 		shopServ: shopServ,
 	}
-	gr := router.Group("/user-shops")
-	gr.POST("/", r.AddUserShop)
-	gr.PUT("/", r.UpdateShop)
-	gr.DELETE("/", r.DeleteShop)
+	gr := router.Group("user-shops", authMiddleware.Required())
+	gr.POST("", r.AddUserShop)
+	gr.PUT("", r.UpdateShop)
+	gr.DELETE("", r.DeleteShop)
 	return r
 }
 
 // AddUserShop godoc
 // @Summary Добавить магазин
-// @Description Создает новый магазин для текущего авторизованного пользователя
+// @Description Создает новый магазин для текущего авторизованного пользователя.
+// @Description Контакты: telegram, vk, instagram - имя (@name) или ссылка на профиль, website - адрес сайта, email - почта.
 // @Tags Магазины
 // @Accept json
 // @Produce json
@@ -37,6 +39,8 @@ func NewShopRouter(
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.AddShopRequest true "Данные нового магазина"
 // @Success 201 {object} map[string]interface{} "Магазин успешно создан"
+// @Failure 400 {object} map[string]interface{} "Неверные данные магазина или контакт"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
 // @Router /user/user-shops [post]
 func (r *ShopRouter) AddUserShop(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -48,7 +52,7 @@ func (r *ShopRouter) AddUserShop(c *gin.Context) {
 	}
 	_, err := r.shopServ.Add(ctx, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -56,7 +60,8 @@ func (r *ShopRouter) AddUserShop(c *gin.Context) {
 
 // UpdateShop godoc
 // @Summary Обновить магазин
-// @Description Обновляет данные указанного магазина пользователя
+// @Description Обновляет данные указанного магазина пользователя. Переданный список contacts заменяет
+// @Description контакты целиком, без поля contacts они не меняются.
 // @Tags Магазины
 // @Accept json
 // @Produce json
@@ -64,6 +69,10 @@ func (r *ShopRouter) AddUserShop(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.UpdateShopRequest true "Данные для обновления магазина"
 // @Success 200 {object} map[string]interface{} "Магазин успешно обновлен"
+// @Failure 400 {object} map[string]interface{} "Неверные данные магазина или контакт"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин не найден"
 // @Router /user/user-shops [put]
 func (r *ShopRouter) UpdateShop(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -75,7 +84,7 @@ func (r *ShopRouter) UpdateShop(c *gin.Context) {
 	}
 
 	if _, err := r.shopServ.Update(ctx, req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
@@ -91,6 +100,9 @@ func (r *ShopRouter) UpdateShop(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.DeleteShopRequest true "Данные для удаления магазина"
 // @Success 200 {object} map[string]interface{} "Магазин успешно удален"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 403 {object} map[string]interface{} "Магазин принадлежит другому пользователю"
+// @Failure 404 {object} map[string]interface{} "Магазин не найден"
 // @Router /user/user-shops [delete]
 func (r *ShopRouter) DeleteShop(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -107,7 +119,7 @@ func (r *ShopRouter) DeleteShop(c *gin.Context) {
 		return
 	}
 	if err := r.shopServ.Delete(ctx, shopID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(catalogErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
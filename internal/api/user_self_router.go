@@ -33,6 +33,7 @@ func NewUserSelfRouter(
 	shopServ shopservice.ShopServ,
 	productServ productservice.ProductServ,
 	postServ postservice.PostServ,
+	authMiddleware AuthMiddleware,
 ) UserSelfRouter {
 	r := UserSelfRouter{
 		userSelfServ: userSelfServ,
@@ -43,9 +44,12 @@ func NewUserSelfRouter(
 		postServ:     postServ,
 	}
 	gr := router.Group("user")
-	gr.GET("/:id_user", r.GetUserByID)
-	gr.PATCH("/update-login", r.UpdateLogin)
-	gr.PATCH("/update-password", r.UpdatePassword)
+	gr.GET("/:id_user", authMiddleware.Optional(), r.GetUserByID)
+
+	authGr := gr.Group("", authMiddleware.Required())
+	authGr.PATCH("/update-login", r.UpdateLogin)
+	authGr.PATCH("/update-password", r.UpdatePassword)
+	authGr.PATCH("/update-email", r.UpdateEmail)
 	return r
 }
 
@@ -90,6 +94,7 @@ func (r *UserSelfRouter) GetUserByID(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.UpdateLoginRequest true "Данные для обновления логина"
 // @Success 200 {object} map[string]interface{} "Успешное обновление"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
 // @Router /user/update-login [patch]
 func (r *UserSelfRouter) UpdateLogin(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -114,7 +119,7 @@ func (r *UserSelfRouter) UpdateLogin(c *gin.Context) {
 
 // UpdatePassword godoc
 // @Summary Обновить пароль пользователя
-// @Description Изменяет пароль текущего авторизованного пользователя
+// @Description Изменяет пароль текущего авторизованного пользователя и завершает все его сессии
 // @Tags Пользователь
 // @Accept json
 // @Produce json
@@ -122,6 +127,8 @@ func (r *UserSelfRouter) UpdateLogin(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body reqresp.UpdateUserPasswordRequest true "Данные для обновления пароля"
 // @Success 200 {object} map[string]interface{} "Успешное обновление"
+// @Failure 400 {object} map[string]interface{} "Пароль не соответствует требованиям"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
 // @Router /user/update-password [patch]
 func (r *UserSelfRouter) UpdatePassword(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -136,6 +143,44 @@ func (r *UserSelfRouter) UpdatePassword(c *gin.Context) {
 	if err := r.userSelfServ.ChangePassword(ctx, newPassword); err != nil {
 		if errors.Is(err, auth.ErrNotAuthZ) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authuser.ErrWeakPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UpdateEmail godoc
+// @Summary Обновить почту пользователя
+// @Description Задает новую почту текущего пользователя и отправляет на нее письмо для подтверждения
+// @Tags Пользователь
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Authorization header string true "Bearer токен"
+// @Param request body reqresp.UpdateEmailRequest true "Новая почта"
+// @Success 200 {object} map[string]interface{} "Успешное обновление"
+// @Failure 400 {object} map[string]interface{} "Неверный формат почты"
+// @Failure 401 {object} map[string]interface{} "Пользователь не авторизован"
+// @Failure 409 {object} map[string]interface{} "Почта уже используется"
+// @Router /user/update-email [patch]
+func (r *UserSelfRouter) UpdateEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req reqresp.UpdateEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := r.userSelfServ.ChangeEmail(ctx, req.Email); err != nil {
+		if errors.Is(err, auth.ErrNotAuthZ) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else if errors.Is(err, authuser.ErrDuplicateEmailUser) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
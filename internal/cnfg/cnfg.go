@@ -0,0 +1,516 @@
+package cnfg
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+
+	TokenJWT          = "jwt"
+	TokenPasetoLocal  = "paseto_local"
+	TokenPasetoPublic = "paseto_public"
+	TokenJWTEdDSA     = "jwt_eddsa"
+	TokenJWTES256     = "jwt_es256"
+
+	MediaLocal = "local"
+	MediaS3    = "s3"
+
+	NotifyFile = "file"
+	NotifySMTP = "smtp"
+
+	AuthLocal  = "local"
+	AuthRemote = "remote"
+
+	HashArgon2id = "argon2id"
+	HashBcrypt   = "bcrypt"
+
+	// bcryptMaxPassword - bcrypt учитывает только первые 72 байта пароля
+	bcryptMaxPassword = 72
+)
+
+type AppConfig struct {
+	Port                 int
+	TokenType            string // TokenJWT, TokenPasetoLocal, TokenPasetoPublic, TokenJWTEdDSA или TokenJWTES256
+	TokenSymmetricKey    string
+	TokenPrivateKey      string // hex закрытого ключа для TokenPasetoPublic, TokenJWTEdDSA и TokenJWTES256
+	TokenKeysDir         string // каталог PEM-ключей с расписанием ротации для TokenJWTEdDSA и TokenJWTES256
+	AccessTokenDuration  time.Duration
+	RefreshTokenDuration time.Duration
+	StorageType          string        // StoragePostgres или StorageMemory
+	SuggestTimeout       time.Duration // время на подсказки в строке поиска
+	TrustedProxies       []string      // адреса и подсети прокси, которым доверяется X-Forwarded-For; пусто - никому
+	// сроки действия одноразовых токенов из писем
+	EmailVerifyTokenDuration   time.Duration
+	PasswordResetTokenDuration time.Duration
+	// второй фактор: название сервиса в приложении-аутентификаторе и срок токена mfa_pending
+	MFAIssuer        string
+	MFATokenDuration time.Duration
+}
+
+// MediaConfig - хранилище изображений товаров и постов
+type MediaConfig struct {
+	StorageType   string // MediaLocal или MediaS3
+	MaxUploadSize int64  // байт на один файл
+	LocalDir      string
+	BaseURL       string // публичный адрес файлов; для MediaLocal - путь, по которому их раздает сервер
+	S3Endpoint    string // http(s)://host[:port], бакет адресуется путем: {endpoint}/{bucket}/{key}
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+}
+
+// NotifyConfig - отправка писем пользователям
+type NotifyConfig struct {
+	Sender       string // NotifyFile или NotifySMTP
+	From         string // адрес отправителя, можно с именем: CraftPlace <noreply@craftplace.ru>
+	Locale       string // язык писем: ru или en
+	BaseURL      string // адрес сайта для ссылок в письмах
+	FilePath     string // для NotifyFile: файл, куда дописываются письма; пусто - стандартный вывод
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string // пусто - без авторизации
+	SMTPPassword string
+	SMTPTimeout  time.Duration // на отправку одного письма
+}
+
+// ThrottleConfig - ограничение неудачных попыток входа по логину и по IP-адресу
+type ThrottleConfig struct {
+	Login ThrottlePolicy
+	IP    ThrottlePolicy
+}
+
+// ThrottlePolicy - после FreeAttempts неудач подряд каждая следующая попытка откладывается на BaseDelay,
+// задержка удваивается до MaxDelay, после LockoutAttempts неудач вход блокируется на LockoutDuration.
+// Неудачи старше Window забываются.
+type ThrottlePolicy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAttempts int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+// PasswordConfig - хеширование паролей и требования к новым паролям
+type PasswordConfig struct {
+	HashAlgorithm string // HashArgon2id или HashBcrypt; хеши другого алгоритма или с другими параметрами пересчитываются при входе
+	Argon2Memory  uint32 // КиБ
+	Argon2Time    uint32 // число проходов
+	Argon2Threads uint8
+	BcryptCost    int
+	MinLength     int // символов
+	MaxLength     int // байт
+	MinClasses    int // сколько видов символов нужно из строчных букв, заглавных, цифр и остальных
+}
+
+// AuthConfig - где выполняется авторизация: в процессе API или в сервисе cmd/auth
+type AuthConfig struct {
+	Mode         string        // AuthLocal или AuthRemote
+	GRPCAddr     string        // для AuthRemote: host:port gRPC сервиса авторизации
+	GRPCPort     int           // порт, на котором cmd/auth принимает gRPC
+	Timeout      time.Duration // на один вызов сервиса авторизации
+	ServiceToken string        // общий секрет сервисов: им подписывается каждый вызов cmd/auth
+}
+
+// MinServiceTokenLength - AUTH_SERVICE_TOKEN короче подбирается перебором
+const MinServiceTokenLength = 32
+
+type DatabaseConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	SSLMode  string
+}
+
+func LoadAppConfig() (AppConfig, error) {
+	port, err := getEnvInt("APP_PORT", 8080)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	accessTokenDuration, err := getEnvDuration("ACCESS_TOKEN_DURATION", 15*time.Minute)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	refreshTokenDuration, err := getEnvDuration("REFRESH_TOKEN_DURATION", 30*24*time.Hour)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	suggestTimeout, err := getEnvDuration("SUGGEST_TIMEOUT", 150*time.Millisecond)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	if suggestTimeout <= 0 {
+		return AppConfig{}, fmt.Errorf("config SUGGEST_TIMEOUT: must be positive")
+	}
+	emailVerifyTokenDuration, err := getEnvDuration("EMAIL_VERIFY_TOKEN_DURATION", 48*time.Hour)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	passwordResetTokenDuration, err := getEnvDuration("PASSWORD_RESET_TOKEN_DURATION", time.Hour)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	mfaTokenDuration, err := getEnvDuration("MFA_TOKEN_DURATION", 5*time.Minute)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	storageType := getEnv("STORAGE_TYPE", StoragePostgres)
+	if storageType != StoragePostgres && storageType != StorageMemory {
+		return AppConfig{}, fmt.Errorf("config STORAGE_TYPE: unknown storage %q", storageType)
+	}
+	tokenType := getEnv("TOKEN_TYPE", TokenJWT)
+	switch tokenType {
+	case TokenJWT, TokenPasetoLocal, TokenPasetoPublic, TokenJWTEdDSA, TokenJWTES256:
+	default:
+		return AppConfig{}, fmt.Errorf("config TOKEN_TYPE: unknown token type %q", tokenType)
+	}
+	// ключи подписи проверяет RequireTokenKeys: API с AUTH_MODE=remote токены не подписывает и ключей не получает
+	return AppConfig{
+		Port:                 port,
+		TokenType:            tokenType,
+		TokenSymmetricKey:    getEnv("TOKEN_SYMMETRIC_KEY", ""),
+		TokenPrivateKey:      getEnv("TOKEN_PRIVATE_KEY", ""),
+		TokenKeysDir:         getEnv("TOKEN_KEYS_DIR", ""),
+		AccessTokenDuration:  accessTokenDuration,
+		RefreshTokenDuration: refreshTokenDuration,
+		StorageType:          storageType,
+		SuggestTimeout:       suggestTimeout,
+		TrustedProxies:       getEnvList("TRUSTED_PROXIES"),
+
+		EmailVerifyTokenDuration:   emailVerifyTokenDuration,
+		PasswordResetTokenDuration: passwordResetTokenDuration,
+		MFAIssuer:                  getEnv("MFA_ISSUER", "CraftPlace"),
+		MFATokenDuration:           mfaTokenDuration,
+	}, nil
+}
+
+// RequireTokenKeys - ключи для TokenType, без них процесс не может выпускать токены.
+// Ключа по умолчанию нет: известным всем ключом любой может подписать токен администратора
+func (c AppConfig) RequireTokenKeys() error {
+	switch c.TokenType {
+	case TokenJWT, TokenPasetoLocal:
+		if c.TokenSymmetricKey == "" {
+			return fmt.Errorf("config TOKEN_SYMMETRIC_KEY: required for token type %q", c.TokenType)
+		}
+	case TokenPasetoPublic:
+		if c.TokenPrivateKey == "" {
+			return fmt.Errorf("config TOKEN_PRIVATE_KEY: required for token type %q", c.TokenType)
+		}
+	case TokenJWTEdDSA, TokenJWTES256:
+		if c.TokenPrivateKey == "" && c.TokenKeysDir == "" {
+			return fmt.Errorf("config TOKEN_KEYS_DIR or TOKEN_PRIVATE_KEY: required for token type %q", c.TokenType)
+		}
+	}
+	return nil
+}
+
+func LoadMediaConfig() (MediaConfig, error) {
+	storageType := getEnv("MEDIA_STORAGE", MediaLocal)
+	if storageType != MediaLocal && storageType != MediaS3 {
+		return MediaConfig{}, fmt.Errorf("config MEDIA_STORAGE: unknown storage %q", storageType)
+	}
+	maxUploadSize, err := getEnvInt("MEDIA_MAX_UPLOAD_SIZE", 10<<20)
+	if err != nil {
+		return MediaConfig{}, err
+	}
+	if maxUploadSize <= 0 {
+		return MediaConfig{}, fmt.Errorf("config MEDIA_MAX_UPLOAD_SIZE: must be positive")
+	}
+	config := MediaConfig{
+		StorageType:   storageType,
+		MaxUploadSize: int64(maxUploadSize),
+		LocalDir:      getEnv("MEDIA_LOCAL_DIR", "./media"),
+		BaseURL:       getEnv("MEDIA_BASE_URL", ""),
+		S3Endpoint:    strings.TrimSuffix(getEnv("S3_ENDPOINT", ""), "/"),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:      getEnv("S3_BUCKET", ""),
+		S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
+	}
+	switch storageType {
+	case MediaLocal:
+		if config.BaseURL == "" {
+			config.BaseURL = "/media"
+		}
+	case MediaS3:
+		if config.S3Endpoint == "" || config.S3Bucket == "" || config.S3AccessKey == "" || config.S3SecretKey == "" {
+			return MediaConfig{}, fmt.Errorf("config S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY: required for media storage %q", storageType)
+		}
+		if config.BaseURL == "" {
+			config.BaseURL = config.S3Endpoint + "/" + config.S3Bucket
+		}
+	}
+	return config, nil
+}
+
+func LoadNotifyConfig() (NotifyConfig, error) {
+	sender := getEnv("NOTIFY_SENDER", NotifyFile)
+	if sender != NotifyFile && sender != NotifySMTP {
+		return NotifyConfig{}, fmt.Errorf("config NOTIFY_SENDER: unknown sender %q", sender)
+	}
+	locale := getEnv("NOTIFY_LOCALE", "ru")
+	if locale != "ru" && locale != "en" {
+		return NotifyConfig{}, fmt.Errorf("config NOTIFY_LOCALE: unknown locale %q", locale)
+	}
+	smtpPort, err := getEnvInt("SMTP_PORT", 587)
+	if err != nil {
+		return NotifyConfig{}, err
+	}
+	smtpTimeout, err := getEnvDuration("SMTP_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return NotifyConfig{}, err
+	}
+	if smtpTimeout <= 0 {
+		return NotifyConfig{}, fmt.Errorf("config SMTP_TIMEOUT: must be positive")
+	}
+	config := NotifyConfig{
+		Sender:       sender,
+		From:         getEnv("NOTIFY_FROM", "CraftPlace <noreply@craftplace.local>"),
+		Locale:       locale,
+		BaseURL:      strings.TrimSuffix(getEnv("NOTIFY_BASE_URL", "http://localhost:8080"), "/"),
+		FilePath:     getEnv("NOTIFY_FILE", ""),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     smtpPort,
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPTimeout:  smtpTimeout,
+	}
+	if sender == NotifySMTP && config.SMTPHost == "" {
+		return NotifyConfig{}, fmt.Errorf("config SMTP_HOST: required for sender %q", sender)
+	}
+	return config, nil
+}
+
+func LoadThrottleConfig() (ThrottleConfig, error) {
+	baseDelay, err := getEnvDuration("LOGIN_BACKOFF_BASE", time.Second)
+	if err != nil {
+		return ThrottleConfig{}, err
+	}
+	maxDelay, err := getEnvDuration("LOGIN_BACKOFF_MAX", 5*time.Minute)
+	if err != nil {
+		return ThrottleConfig{}, err
+	}
+	window, err := getEnvDuration("LOGIN_ATTEMPTS_WINDOW", time.Hour)
+	if err != nil {
+		return ThrottleConfig{}, err
+	}
+	if baseDelay <= 0 || maxDelay < baseDelay || window <= 0 {
+		return ThrottleConfig{}, fmt.Errorf("config LOGIN_BACKOFF_BASE, LOGIN_BACKOFF_MAX, LOGIN_ATTEMPTS_WINDOW: " +
+			"must be positive and LOGIN_BACKOFF_MAX not less than LOGIN_BACKOFF_BASE")
+	}
+	login, err := loadThrottlePolicy("LOGIN", 5, 10, 15*time.Minute)
+	if err != nil {
+		return ThrottleConfig{}, err
+	}
+	ip, err := loadThrottlePolicy("IP", 20, 100, time.Hour)
+	if err != nil {
+		return ThrottleConfig{}, err
+	}
+	for _, policy := range []*ThrottlePolicy{&login, &ip} {
+		policy.BaseDelay = baseDelay
+		policy.MaxDelay = maxDelay
+		policy.Window = window
+	}
+	return ThrottleConfig{Login: login, IP: ip}, nil
+}
+
+// loadThrottlePolicy читает пороги {prefix}_FREE_ATTEMPTS, {prefix}_LOCKOUT_ATTEMPTS и {prefix}_LOCKOUT_DURATION
+func loadThrottlePolicy(prefix string, freeAttempts int, lockoutAttempts int, lockoutDuration time.Duration) (ThrottlePolicy, error) {
+	free, err := getEnvInt(prefix+"_FREE_ATTEMPTS", freeAttempts)
+	if err != nil {
+		return ThrottlePolicy{}, err
+	}
+	lockout, err := getEnvInt(prefix+"_LOCKOUT_ATTEMPTS", lockoutAttempts)
+	if err != nil {
+		return ThrottlePolicy{}, err
+	}
+	duration, err := getEnvDuration(prefix+"_LOCKOUT_DURATION", lockoutDuration)
+	if err != nil {
+		return ThrottlePolicy{}, err
+	}
+	if free < 0 || lockout <= free || duration <= 0 {
+		return ThrottlePolicy{}, fmt.Errorf("config %[1]s_FREE_ATTEMPTS, %[1]s_LOCKOUT_ATTEMPTS, %[1]s_LOCKOUT_DURATION: "+
+			"lockout attempts must be greater than free attempts and duration positive", prefix)
+	}
+	return ThrottlePolicy{
+		FreeAttempts:    free,
+		LockoutAttempts: lockout,
+		LockoutDuration: duration,
+	}, nil
+}
+
+func LoadPasswordConfig() (PasswordConfig, error) {
+	algorithm := getEnv("PASSWORD_HASH", HashArgon2id)
+	if algorithm != HashArgon2id && algorithm != HashBcrypt {
+		return PasswordConfig{}, fmt.Errorf("config PASSWORD_HASH: unknown algorithm %q", algorithm)
+	}
+	// по умолчанию - второй рекомендуемый набор параметров RFC 9106 с меньшим числом потоков
+	memory, err := getEnvInt("ARGON2_MEMORY", 64*1024)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	iterations, err := getEnvInt("ARGON2_TIME", 3)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	threads, err := getEnvInt("ARGON2_THREADS", 2)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	if threads < 1 || threads > 255 || iterations < 1 || memory < 8*threads {
+		return PasswordConfig{}, fmt.Errorf("config ARGON2_MEMORY, ARGON2_TIME, ARGON2_THREADS: " +
+			"threads must be from 1 to 255, time positive and memory at least 8 KiB per thread")
+	}
+	bcryptCost, err := getEnvInt("BCRYPT_COST", 10)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	if bcryptCost < 4 || bcryptCost > 31 {
+		return PasswordConfig{}, fmt.Errorf("config BCRYPT_COST: must be from 4 to 31")
+	}
+	minLength, err := getEnvInt("PASSWORD_MIN_LENGTH", 8)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	maxLength, err := getEnvInt("PASSWORD_MAX_LENGTH", 128)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	if algorithm == HashBcrypt {
+		// длиннее bcrypt молча обрезает, и разные пароли совпали бы
+		maxLength = min(maxLength, bcryptMaxPassword)
+	}
+	if minLength < 1 || maxLength < minLength {
+		return PasswordConfig{}, fmt.Errorf("config PASSWORD_MIN_LENGTH, PASSWORD_MAX_LENGTH: " +
+			"min length must be positive and not greater than max length")
+	}
+	minClasses, err := getEnvInt("PASSWORD_MIN_CLASSES", 2)
+	if err != nil {
+		return PasswordConfig{}, err
+	}
+	if minClasses < 0 || minClasses > 4 {
+		return PasswordConfig{}, fmt.Errorf("config PASSWORD_MIN_CLASSES: must be from 0 to 4")
+	}
+	return PasswordConfig{
+		HashAlgorithm: algorithm,
+		Argon2Memory:  uint32(memory),
+		Argon2Time:    uint32(iterations),
+		Argon2Threads: uint8(threads),
+		BcryptCost:    bcryptCost,
+		MinLength:     minLength,
+		MaxLength:     maxLength,
+		MinClasses:    minClasses,
+	}, nil
+}
+
+func LoadAuthConfig() (AuthConfig, error) {
+	mode := getEnv("AUTH_MODE", AuthLocal)
+	if mode != AuthLocal && mode != AuthRemote {
+		return AuthConfig{}, fmt.Errorf("config AUTH_MODE: unknown mode %q", mode)
+	}
+	grpcPort, err := getEnvInt("AUTH_GRPC_PORT", 9090)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	timeout, err := getEnvDuration("AUTH_TIMEOUT", 5*time.Second)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	if timeout <= 0 {
+		return AuthConfig{}, fmt.Errorf("config AUTH_TIMEOUT: must be positive")
+	}
+	// нужен cmd/auth и cmd/dev с AUTH_MODE=remote, в процессе API он не используется
+	serviceToken := getEnv("AUTH_SERVICE_TOKEN", "")
+	if mode == AuthRemote && serviceToken == "" {
+		return AuthConfig{}, fmt.Errorf("config AUTH_SERVICE_TOKEN: required for auth mode %q", mode)
+	}
+	if serviceToken != "" && len(serviceToken) < MinServiceTokenLength {
+		return AuthConfig{}, fmt.Errorf("config AUTH_SERVICE_TOKEN: must be at least %d characters", MinServiceTokenLength)
+	}
+	return AuthConfig{
+		Mode:         mode,
+		GRPCAddr:     getEnv("AUTH_GRPC_ADDR", "localhost:9090"),
+		GRPCPort:     grpcPort,
+		Timeout:      timeout,
+		ServiceToken: serviceToken,
+	}, nil
+}
+
+func LoadDatabaseConfig() (DatabaseConfig, error) {
+	port, err := getEnvInt("POSTGRES_PORT", 5432)
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	return DatabaseConfig{
+		Host:     getEnv("POSTGRES_HOST", "localhost"),
+		Port:     port,
+		User:     getEnv("POSTGRES_USER", "postgres"),
+		Password: getEnv("POSTGRES_PASSWORD", "postgres"),
+		DBName:   getEnv("POSTGRES_DB", "craftplace"),
+		SSLMode:  getEnv("POSTGRES_SSLMODE", "disable"),
+	}, nil
+}
+
+// DSN возвращает строку подключения в формате URL (postgres://...)
+func (c DatabaseConfig) DSN() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     c.DBName,
+		RawQuery: "sslmode=" + url.QueryEscape(c.SSLMode),
+	}
+	return u.String()
+}
+
+func getEnv(key string, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// getEnvList - значения через запятую, пустые пропускаются
+func getEnvList(key string) []string {
+	var res []string
+	for _, v := range strings.Split(getEnv(key, ""), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func getEnvInt(key string, def int) (int, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	res, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("config %s: %w", key, err)
+	}
+	return res, nil
+}
+
+func getEnvDuration(key string, def time.Duration) (time.Duration, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	res, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("config %s: %w", key, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package cnfg_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type CnfgSuite struct {
+	suite.Suite
+}
+
+func TestCnfg(t *testing.T) {
+	suite.RunSuite(t, new(CnfgSuite))
+}
+
+var tokenEnv = []string{
+	"TOKEN_TYPE", "TOKEN_SYMMETRIC_KEY", "TOKEN_PRIVATE_KEY", "TOKEN_KEYS_DIR", "AUTH_MODE", "AUTH_SERVICE_TOKEN",
+}
+
+// withEnv выполняет f с переменными env, остальные переменные токенов и авторизации сброшены
+func withEnv(env map[string]string, f func()) {
+	saved := make(map[string]string)
+	for _, key := range tokenEnv {
+		if value, ok := os.LookupEnv(key); ok {
+			saved[key] = value
+		}
+		os.Unsetenv(key)
+	}
+	defer func() {
+		for _, key := range tokenEnv {
+			os.Unsetenv(key)
+			if value, ok := saved[key]; ok {
+				os.Setenv(key, value)
+			}
+		}
+	}()
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	f()
+}
+
+func (s *CnfgSuite) TestLoadAppConfig_TokenKeys(t provider.T) {
+	t.WithNewStep("remote auth mode loads without token keys", func(sCtx provider.StepCtx) {
+		withEnv(map[string]string{
+			"AUTH_MODE":          cnfg.AuthRemote,
+			"AUTH_SERVICE_TOKEN": "service-token-0123456789abcdef0123",
+		}, func() {
+			appCnfg, err := cnfg.LoadAppConfig()
+			sCtx.Require().NoError(err)
+			authCnfg, err := cnfg.LoadAuthConfig()
+			sCtx.Require().NoError(err)
+			sCtx.Assert().Equal(cnfg.AuthRemote, authCnfg.Mode)
+			sCtx.Assert().Empty(appCnfg.TokenSymmetricKey)
+			sCtx.Assert().Empty(appCnfg.TokenPrivateKey)
+			sCtx.Assert().Empty(appCnfg.TokenKeysDir)
+		})
+	})
+	t.WithNewStep("signing without a key is rejected", func(sCtx provider.StepCtx) {
+		for _, tokenType := range []string{
+			cnfg.TokenJWT, cnfg.TokenPasetoLocal, cnfg.TokenPasetoPublic, cnfg.TokenJWTEdDSA, cnfg.TokenJWTES256,
+		} {
+			withEnv(map[string]string{"TOKEN_TYPE": tokenType}, func() {
+				appCnfg, err := cnfg.LoadAppConfig()
+				sCtx.Require().NoError(err)
+				sCtx.Assert().Error(appCnfg.RequireTokenKeys(), tokenType)
+			})
+		}
+	})
+	t.WithNewStep("signing with a key", func(sCtx provider.StepCtx) {
+		envs := []map[string]string{
+			{"TOKEN_TYPE": cnfg.TokenJWT, "TOKEN_SYMMETRIC_KEY": "0123456789abcdef0123456789abcdef"},
+			{"TOKEN_TYPE": cnfg.TokenPasetoPublic, "TOKEN_PRIVATE_KEY": "00ff"},
+			{"TOKEN_TYPE": cnfg.TokenJWTEdDSA, "TOKEN_KEYS_DIR": "./keys"},
+		}
+		for _, env := range envs {
+			withEnv(env, func() {
+				appCnfg, err := cnfg.LoadAppConfig()
+				sCtx.Require().NoError(err)
+				sCtx.Assert().NoError(appCnfg.RequireTokenKeys(), env["TOKEN_TYPE"])
+			})
+		}
+	})
+}
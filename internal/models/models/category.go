@@ -18,17 +18,19 @@ type Category struct {
 	id          uuid.UUID
 	title       string
 	description string
+	parentID    uuid.UUID // uuid.Nil - корневая категория
 }
 
 var (
 	ErrCategoryValidate = errors.New("model category validate error")
 )
 
-func NewCategory(id uuid.UUID, title string, description string) (*Category, error) {
+func NewCategory(id uuid.UUID, title string, description string, parentID uuid.UUID) (*Category, error) {
 	p := Category{
 		id:          id,
 		title:       strings.TrimSpace(title),
 		description: strings.TrimSpace(description),
+		parentID:    parentID,
 	}
 	if err := p.validate(); err != nil {
 		return nil, err
@@ -37,26 +39,53 @@ func NewCategory(id uuid.UUID, title string, description string) (*Category, err
 }
 
 func (p *Category) validate() error {
-	if p.title == "" || len(p.title) > MaxLenProductTitle {
+	if p.title == "" || len(p.title) > MaxLenCategoryTitle {
 		return fmt.Errorf("%w: title", ErrCategoryValidate)
-	} else if len(p.description) > MaxLenProductDecription {
+	} else if len(p.description) > MaxLenPCategoryDecription {
 		return fmt.Errorf("%w: description", ErrCategoryValidate)
+	} else if p.parentID != uuid.Nil && p.parentID == p.id {
+		return fmt.Errorf("%w: parent", ErrCategoryValidate)
 	}
 	return nil
 }
 
+// ToResponse - ответ без пути от корня, путь заполняет CategoryTree.ToResponse
 func (p *Category) ToResponse() reqresp.CategoryResponse {
-	return reqresp.CategoryResponse{
+	res := reqresp.CategoryResponse{
 		ID:          p.id.String(),
 		Title:       p.title,
 		Description: p.description,
 	}
+	if p.parentID != uuid.Nil {
+		res.ParentID = p.parentID.String()
+	}
+	return res
+}
+
+func (p *Category) ToCrumb() reqresp.CategoryCrumb {
+	return reqresp.CategoryCrumb{
+		ID:    p.id.String(),
+		Title: p.title,
+	}
 }
 
 func (p *Category) GetID() uuid.UUID {
 	return p.id
 }
 
+func (p *Category) GetTitle() string {
+	return p.title
+}
+
 func (p *Category) GetDescription() string {
 	return p.description
 }
+
+func (p *Category) GetParentID() uuid.UUID {
+	return p.parentID
+}
+
+// SortValue возвращает значение ключа сортировки reqresp.SortByX
+func (p *Category) SortValue(sortBy string) any {
+	return p.title
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"slices"
+	"strings"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// CategoryTree - иерархия категорий, построенная из плоского списка всех категорий
+type CategoryTree struct {
+	byID     map[uuid.UUID]*Category
+	children map[uuid.UUID][]*Category // uuid.Nil - корневые категории
+}
+
+func NewCategoryTree(categories []*Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:     make(map[uuid.UUID]*Category, len(categories)),
+		children: make(map[uuid.UUID][]*Category),
+	}
+	for _, category := range categories {
+		t.byID[category.id] = category
+	}
+	for _, category := range categories {
+		parentID := category.parentID
+		if _, ok := t.byID[parentID]; !ok {
+			parentID = uuid.Nil
+		}
+		t.children[parentID] = append(t.children[parentID], category)
+	}
+	for _, children := range t.children {
+		slices.SortFunc(children, func(a, b *Category) int {
+			return strings.Compare(a.title, b.title)
+		})
+	}
+	return t
+}
+
+// Path - категории от корня до category включительно.
+// Родители ищутся в дереве, отсутствующий родитель обрывает путь.
+func (t *CategoryTree) Path(category *Category) []*Category {
+	path := []*Category{category}
+	seen := map[uuid.UUID]struct{}{category.id: {}}
+	for parentID := category.parentID; parentID != uuid.Nil; {
+		parent, ok := t.byID[parentID]
+		if !ok {
+			break
+		}
+		if _, ok := seen[parentID]; ok {
+			break
+		}
+		seen[parentID] = struct{}{}
+		path = append(path, parent)
+		parentID = parent.parentID
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Subtree - id категории и всех ее потомков
+func (t *CategoryTree) Subtree(categoryID uuid.UUID) uuid.UUIDs {
+	res := uuid.UUIDs{categoryID}
+	seen := map[uuid.UUID]struct{}{categoryID: {}}
+	for i := 0; i < len(res); i++ {
+		for _, child := range t.children[res[i]] {
+			if _, ok := seen[child.id]; ok {
+				continue
+			}
+			seen[child.id] = struct{}{}
+			res = append(res, child.id)
+		}
+	}
+	return res
+}
+
+// HasChildren - есть ли у категории дочерние категории
+func (t *CategoryTree) HasChildren(categoryID uuid.UUID) bool {
+	return len(t.children[categoryID]) > 0
+}
+
+// ToResponse - ответ по категории с путем от корня
+func (t *CategoryTree) ToResponse(category *Category) reqresp.CategoryResponse {
+	res := category.ToResponse()
+	path := t.Path(category)
+	res.Path = make([]reqresp.CategoryCrumb, len(path))
+	for i, c := range path {
+		res.Path[i] = c.ToCrumb()
+	}
+	return res
+}
+
+// ToTreeResponse - корневые категории с вложенными дочерними, отсортированные по названию
+func (t *CategoryTree) ToTreeResponse() []reqresp.CategoryTreeResponse {
+	return t.nodes(uuid.Nil)
+}
+
+func (t *CategoryTree) nodes(parentID uuid.UUID) []reqresp.CategoryTreeResponse {
+	children := t.children[parentID]
+	res := make([]reqresp.CategoryTreeResponse, len(children))
+	for i, c := range children {
+		res[i] = reqresp.CategoryTreeResponse{
+			ID:          c.id.String(),
+			Title:       c.title,
+			Description: c.description,
+			Children:    t.nodes(c.id),
+		}
+	}
+	return res
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+)
+
+// ContactType - канал связи с мастером
+type ContactType string
+
+const (
+	ContactTelegram  ContactType = "telegram"
+	ContactVK        ContactType = "vk"
+	ContactInstagram ContactType = "instagram"
+	ContactWebsite   ContactType = "website"
+	ContactEmail     ContactType = "email"
+)
+
+func (t ContactType) Valid() bool {
+	_, ok := contactRules[t]
+	return ok
+}
+
+const (
+	MaxShopContacts    = 10
+	MaxLenContactValue = 255
+)
+
+// ContactLink - контакт магазина. Значение хранится в каноническом виде: для соцсетей - имя
+// пользователя без @ и адреса профиля, для сайта - URL, для почты - адрес.
+type ContactLink struct {
+	contactType ContactType
+	value       string
+}
+
+var (
+	ErrContactLinkValidate = errors.New("model ContactLink validate error")
+)
+
+// contactRule - как из введенного значения получить каноническое и как построить ссылку
+type contactRule struct {
+	parse func(value string) (string, error)
+	url   func(value string) string
+}
+
+var (
+	telegramHandle  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+	vkHandle        = regexp.MustCompile(`^[a-z0-9][a-z0-9_.]{1,31}$`)
+	instagramHandle = regexp.MustCompile(`^[a-z0-9._]{1,30}$`)
+)
+
+var contactRules = map[ContactType]contactRule{
+	ContactTelegram: {
+		parse: func(value string) (string, error) {
+			handle, err := profileHandle(value, "t.me", "telegram.me")
+			if err != nil || !telegramHandle.MatchString(handle) {
+				return "", fmt.Errorf("%w: telegram username must be 5-32 latin letters, digits or _", ErrContactLinkValidate)
+			}
+			return handle, nil
+		},
+		url: func(value string) string { return "https://t.me/" + value },
+	},
+	ContactVK: {
+		parse: func(value string) (string, error) {
+			handle, err := profileHandle(value, "vk.com", "m.vk.com", "vk.ru")
+			handle = strings.ToLower(handle)
+			if err != nil || !vkHandle.MatchString(handle) {
+				return "", fmt.Errorf("%w: vk page name must be 2-32 latin letters, digits, _ or .", ErrContactLinkValidate)
+			}
+			return handle, nil
+		},
+		url: func(value string) string { return "https://vk.com/" + value },
+	},
+	ContactInstagram: {
+		parse: func(value string) (string, error) {
+			handle, err := profileHandle(value, "instagram.com")
+			handle = strings.ToLower(handle)
+			if err != nil || !instagramHandle.MatchString(handle) ||
+				strings.HasPrefix(handle, ".") || strings.HasSuffix(handle, ".") || strings.Contains(handle, "..") {
+				return "", fmt.Errorf("%w: instagram username must be up to 30 latin letters, digits, _ or .", ErrContactLinkValidate)
+			}
+			return handle, nil
+		},
+		url: func(value string) string { return "https://instagram.com/" + value },
+	},
+	ContactWebsite: {
+		parse: func(value string) (string, error) {
+			u, err := parseWebURL(value)
+			if err != nil {
+				return "", fmt.Errorf("%w: website: %w", ErrContactLinkValidate, err)
+			}
+			return u.String(), nil
+		},
+		url: func(value string) string { return value },
+	},
+	ContactEmail: {
+		parse: func(value string) (string, error) {
+			addr, err := mail.ParseAddress(value)
+			if err != nil || addr.Name != "" || addr.Address != value || !strings.Contains(value[strings.LastIndex(value, "@"):], ".") {
+				return "", fmt.Errorf("%w: email must be a plain address", ErrContactLinkValidate)
+			}
+			return addr.Address, nil
+		},
+		url: func(value string) string { return "mailto:" + value },
+	},
+}
+
+// NewContactLink проверяет значение по правилам типа и приводит его к каноническому виду:
+// "@craft_star", "t.me/craft_star" и "https://t.me/craft_star" дают один и тот же контакт
+func NewContactLink(contactType ContactType, value string) (*ContactLink, error) {
+	rule, ok := contactRules[contactType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrContactLinkValidate, contactType)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > MaxLenContactValue {
+		return nil, fmt.Errorf("%w: %s value", ErrContactLinkValidate, contactType)
+	}
+	canonical, err := rule.parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &ContactLink{
+		contactType: contactType,
+		value:       canonical,
+	}, nil
+}
+
+// profileHandle - имя пользователя из "@name", "name" или ссылки на профиль на одном из hosts
+func profileHandle(value string, hosts ...string) (string, error) {
+	if !strings.Contains(value, "/") {
+		return strings.TrimPrefix(value, "@"), nil
+	}
+	u, err := parseWebURL(value)
+	if err != nil {
+		return "", err
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if !slices.Contains(hosts, host) {
+		return "", fmt.Errorf("unexpected host %q", u.Hostname())
+	}
+	path := strings.Trim(u.Path, "/")
+	if path == "" || strings.Contains(path, "/") || u.RawQuery != "" || u.Fragment != "" {
+		return "", errors.New("link must point to a profile")
+	}
+	return strings.TrimPrefix(path, "@"), nil
+}
+
+// parseWebURL разбирает адрес http(s); без схемы подразумевается https
+func parseWebURL(value string) (*url.URL, error) {
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		return nil, errors.New("credentials in link")
+	}
+	if host := u.Hostname(); !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") {
+		return nil, fmt.Errorf("invalid host %q", host)
+	}
+	return u, nil
+}
+
+func (c *ContactLink) ToResponse() reqresp.ContactLinkResponse {
+	return reqresp.ContactLinkResponse{
+		Type:  string(c.contactType),
+		Value: c.value,
+		URL:   c.URL(),
+	}
+}
+
+func (c *ContactLink) GetType() ContactType {
+	return c.contactType
+}
+
+func (c *ContactLink) GetValue() string {
+	return c.value
+}
+
+// URL - ссылка, по которой покупатель может связаться с мастером
+func (c *ContactLink) URL() string {
+	return contactRules[c.contactType].url(c.value)
+}
+
+// sameAs - контакты ведут в одно место: имена и адреса сравниваются без учета регистра
+func (c *ContactLink) sameAs(other *ContactLink) bool {
+	return c.contactType == other.contactType && strings.EqualFold(c.value, other.value)
+}
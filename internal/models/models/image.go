@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// MaxImagesPerOwner - сколько изображений можно прикрепить к одному товару или посту
+const MaxImagesPerOwner = 10
+
+// ImageOwner - вид записи, к которой прикреплено изображение
+type ImageOwner string
+
+const (
+	ImageOwnerProduct ImageOwner = "product"
+	ImageOwnerPost    ImageOwner = "post"
+)
+
+func (o ImageOwner) Valid() bool {
+	return o == ImageOwnerProduct || o == ImageOwnerPost
+}
+
+// Image - изображение товара или поста. Сами файлы лежат в MediaStorage по ключам
+// key (оригинал) и thumbnailKey (миниатюра), position задает порядок в списке записи.
+type Image struct {
+	id           uuid.UUID
+	owner        ImageOwner
+	ownerID      uuid.UUID
+	position     int
+	key          string
+	thumbnailKey string
+	contentType  string
+	width        int
+	height       int
+}
+
+var (
+	ErrImageValidate = errors.New("model image validate error")
+)
+
+func NewImage(
+	id uuid.UUID,
+	owner ImageOwner,
+	ownerID uuid.UUID,
+	position int,
+	key string,
+	thumbnailKey string,
+	contentType string,
+	width int,
+	height int,
+) (*Image, error) {
+	img := Image{
+		id:           id,
+		owner:        owner,
+		ownerID:      ownerID,
+		position:     position,
+		key:          strings.TrimSpace(key),
+		thumbnailKey: strings.TrimSpace(thumbnailKey),
+		contentType:  contentType,
+		width:        width,
+		height:       height,
+	}
+	if err := img.validate(); err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
+
+func (img *Image) validate() error {
+	if !img.owner.Valid() {
+		return fmt.Errorf("%w: owner", ErrImageValidate)
+	} else if img.ownerID == uuid.Nil {
+		return fmt.Errorf("%w: ownerID", ErrImageValidate)
+	} else if img.position < 0 {
+		return fmt.Errorf("%w: position", ErrImageValidate)
+	} else if img.key == "" || img.thumbnailKey == "" {
+		return fmt.Errorf("%w: key", ErrImageValidate)
+	} else if img.width <= 0 || img.height <= 0 {
+		return fmt.Errorf("%w: size", ErrImageValidate)
+	}
+	return nil
+}
+
+// ToResponse - ответ со ссылками, url переводит ключ хранилища в адрес файла
+func (img *Image) ToResponse(url func(key string) string) reqresp.ImageResponse {
+	return reqresp.ImageResponse{
+		ID:           img.id.String(),
+		URL:          url(img.key),
+		ThumbnailURL: url(img.thumbnailKey),
+		ContentType:  img.contentType,
+		Width:        img.width,
+		Height:       img.height,
+	}
+}
+
+func (img *Image) GetID() uuid.UUID {
+	return img.id
+}
+
+func (img *Image) GetOwner() ImageOwner {
+	return img.owner
+}
+
+func (img *Image) GetOwnerID() uuid.UUID {
+	return img.ownerID
+}
+
+func (img *Image) GetPosition() int {
+	return img.position
+}
+
+func (img *Image) GetKey() string {
+	return img.key
+}
+
+func (img *Image) GetThumbnailKey() string {
+	return img.thumbnailKey
+}
+
+func (img *Image) GetContentType() string {
+	return img.contentType
+}
+
+func (img *Image) GetWidth() int {
+	return img.width
+}
+
+func (img *Image) GetHeight() int {
+	return img.height
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// LoginScope - по чему считаются неудачные попытки входа
+type LoginScope string
+
+const (
+	LoginScopeLogin LoginScope = "login"
+	LoginScopeIP    LoginScope = "ip"
+)
+
+// LoginLockout - запись журнала о блокировке входа по логину или IP-адресу
+type LoginLockout struct {
+	id          uuid.UUID
+	scope       LoginScope
+	subject     string // логин или адрес
+	failures    int    // неудач подряд к моменту блокировки
+	lockedUntil time.Time
+	createdAt   time.Time
+}
+
+var (
+	ErrLoginLockoutValidate = errors.New("model login lockout validate error")
+)
+
+func NewLoginLockout(
+	id uuid.UUID,
+	scope LoginScope,
+	subject string,
+	failures int,
+	lockedUntil time.Time,
+	createdAt time.Time,
+) (*LoginLockout, error) {
+	lockout := &LoginLockout{
+		id:          id,
+		scope:       scope,
+		subject:     subject,
+		failures:    failures,
+		lockedUntil: lockedUntil,
+		createdAt:   createdAt,
+	}
+	if err := lockout.validate(); err != nil {
+		return nil, err
+	}
+	return lockout, nil
+}
+
+func (l *LoginLockout) validate() error {
+	if l.scope != LoginScopeLogin && l.scope != LoginScopeIP {
+		return fmt.Errorf("%w: scope", ErrLoginLockoutValidate)
+	} else if l.subject == "" {
+		return fmt.Errorf("%w: subject", ErrLoginLockoutValidate)
+	} else if l.failures <= 0 {
+		return fmt.Errorf("%w: failures", ErrLoginLockoutValidate)
+	} else if !l.lockedUntil.After(l.createdAt) {
+		return fmt.Errorf("%w: lockedUntil", ErrLoginLockoutValidate)
+	}
+	return nil
+}
+
+func (l *LoginLockout) ToResponse() reqresp.LoginLockoutResponse {
+	return reqresp.LoginLockoutResponse{
+		Scope:       string(l.scope),
+		Subject:     l.subject,
+		Failures:    l.failures,
+		LockedUntil: l.lockedUntil,
+		CreatedAt:   l.createdAt,
+	}
+}
+
+func (l *LoginLockout) GetID() uuid.UUID {
+	return l.id
+}
+
+func (l *LoginLockout) GetScope() LoginScope {
+	return l.scope
+}
+
+func (l *LoginLockout) GetSubject() string {
+	return l.subject
+}
+
+func (l *LoginLockout) GetFailures() int {
+	return l.failures
+}
+
+func (l *LoginLockout) GetLockedUntil() time.Time {
+	return l.lockedUntil
+}
+
+func (l *LoginLockout) GetCreatedAt() time.Time {
+	return l.createdAt
+}
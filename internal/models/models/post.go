@@ -55,6 +55,7 @@ func (p *Post) ToResponse() reqresp.PostResponse {
 		Description:     p.description,
 		TimePublication: p.timePublication,
 		ShopID:          p.shopID,
+		Images:          []reqresp.ImageResponse{},
 	}
 }
 
@@ -73,3 +74,8 @@ func (p *Post) GetTimePublication() time.Time {
 func (p *Post) GetShopID() uuid.UUID {
 	return p.shopID
 }
+
+// SortValue возвращает значение ключа сортировки reqresp.SortByX
+func (p *Post) SortValue(sortBy string) any {
+	return p.timePublication
+}
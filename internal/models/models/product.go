@@ -61,6 +61,7 @@ func (p *Product) ToResponse() reqresp.ProductResponse {
 		Cost:        p.cost,
 		ShopID:      p.shopID,
 		CategoryIDs: p.categoryIDs,
+		Images:      []reqresp.ImageResponse{},
 	}
 }
 
@@ -83,3 +84,15 @@ func (p *Product) GetCost() uint64 {
 func (p *Product) GetShopID() uuid.UUID {
 	return p.shopID
 }
+
+func (p *Product) GetCategoryIDs() uuid.UUIDs {
+	return p.categoryIDs
+}
+
+// SortValue возвращает значение ключа сортировки reqresp.SortByX
+func (p *Product) SortValue(sortBy string) any {
+	if sortBy == reqresp.SortByCost {
+		return p.cost
+	}
+	return p.title
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken - выданный refresh-токен. Хранится только хеш токена.
+// Токены, полученные ротацией из одного входа, образуют семейство familyID.
+type RefreshToken struct {
+	id        uuid.UUID
+	familyID  uuid.UUID
+	userID    uuid.UUID
+	tokenHash string
+	expiresAt time.Time
+	rotated   bool // токен уже обменян на новый
+	revoked   bool // семейство токена отозвано
+}
+
+var (
+	ErrRefreshTokenValidate = errors.New("model refresh token validate error")
+)
+
+func NewRefreshToken(
+	id uuid.UUID,
+	familyID uuid.UUID,
+	userID uuid.UUID,
+	tokenHash string,
+	expiresAt time.Time,
+	rotated bool,
+	revoked bool,
+) (*RefreshToken, error) {
+	token := &RefreshToken{
+		id:        id,
+		familyID:  familyID,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		rotated:   rotated,
+		revoked:   revoked,
+	}
+	if err := token.validate(); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (t *RefreshToken) validate() error {
+	if t.familyID == uuid.Nil {
+		return fmt.Errorf("%w: familyID", ErrRefreshTokenValidate)
+	} else if t.userID == uuid.Nil {
+		return fmt.Errorf("%w: userID", ErrRefreshTokenValidate)
+	} else if t.tokenHash == "" {
+		return fmt.Errorf("%w: tokenHash", ErrRefreshTokenValidate)
+	}
+	return nil
+}
+
+func (t *RefreshToken) GetID() uuid.UUID {
+	return t.id
+}
+
+func (t *RefreshToken) GetFamilyID() uuid.UUID {
+	return t.familyID
+}
+
+func (t *RefreshToken) GetUserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *RefreshToken) GetTokenHash() string {
+	return t.tokenHash
+}
+
+func (t *RefreshToken) GetExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *RefreshToken) IsRotated() bool {
+	return t.rotated
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.revoked
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// SearchKind - вид записи в результатах полнотекстового поиска
+type SearchKind string
+
+const (
+	SearchKindProduct SearchKind = "product"
+	SearchKindShop    SearchKind = "shop"
+	SearchKindPost    SearchKind = "post"
+)
+
+func (k SearchKind) Valid() bool {
+	return k == SearchKindProduct || k == SearchKindShop || k == SearchKindPost
+}
+
+// SearchHit - найденная запись. Snippet - фрагмент текста с найденными словами в <mark>,
+// остальной текст экранирован для HTML. Чем больше rank, тем лучше запись подходит под запрос.
+type SearchHit struct {
+	kind    SearchKind
+	id      uuid.UUID
+	shopID  uuid.UUID
+	title   string
+	snippet string
+	rank    float64
+}
+
+var (
+	ErrSearchHitValidate = errors.New("model search hit validate error")
+)
+
+func NewSearchHit(kind SearchKind, id uuid.UUID, shopID uuid.UUID, title string, snippet string, rank float64) (*SearchHit, error) {
+	h := SearchHit{
+		kind:    kind,
+		id:      id,
+		shopID:  shopID,
+		title:   title,
+		snippet: snippet,
+		rank:    rank,
+	}
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (h *SearchHit) validate() error {
+	if !h.kind.Valid() {
+		return fmt.Errorf("%w: kind", ErrSearchHitValidate)
+	} else if h.id == uuid.Nil {
+		return fmt.Errorf("%w: id", ErrSearchHitValidate)
+	} else if h.shopID == uuid.Nil {
+		return fmt.Errorf("%w: shopID", ErrSearchHitValidate)
+	} else if h.rank < 0 {
+		return fmt.Errorf("%w: rank", ErrSearchHitValidate)
+	}
+	return nil
+}
+
+func (h *SearchHit) ToResponse() reqresp.SearchHitResponse {
+	return reqresp.SearchHitResponse{
+		Kind:    string(h.kind),
+		ID:      h.id.String(),
+		ShopID:  h.shopID.String(),
+		Title:   h.title,
+		Snippet: h.snippet,
+		Rank:    h.rank,
+	}
+}
+
+func (h *SearchHit) GetKind() SearchKind {
+	return h.kind
+}
+
+func (h *SearchHit) GetID() uuid.UUID {
+	return h.id
+}
+
+func (h *SearchHit) GetShopID() uuid.UUID {
+	return h.shopID
+}
+
+func (h *SearchHit) GetTitle() string {
+	return h.title
+}
+
+func (h *SearchHit) GetSnippet() string {
+	return h.snippet
+}
+
+func (h *SearchHit) GetRank() float64 {
+	return h.rank
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
@@ -19,18 +20,20 @@ type Shop struct {
 	title       string
 	description string
 	userID      uuid.UUID
+	contacts    []*ContactLink
 }
 
 var (
 	ErrShopValidate = errors.New("model Shop validate error")
 )
 
-func NewShop(id uuid.UUID, title string, description string, userID uuid.UUID) (*Shop, error) {
+func NewShop(id uuid.UUID, title string, description string, userID uuid.UUID, contacts []*ContactLink) (*Shop, error) {
 	s := Shop{
 		id:          id,
 		title:       strings.TrimSpace(title),
 		description: strings.TrimSpace(description),
 		userID:      userID,
+		contacts:    slices.Clone(contacts),
 	}
 	if err := s.validate(); err != nil {
 		return nil, err
@@ -45,6 +48,16 @@ func (s *Shop) validate() error {
 		return fmt.Errorf("%w: description", ErrShopValidate)
 	} else if s.userID == uuid.Nil {
 		return fmt.Errorf("%w: userID", ErrShopValidate)
+	} else if len(s.contacts) > MaxShopContacts {
+		return fmt.Errorf("%w: more than %d contacts", ErrShopValidate, MaxShopContacts)
+	}
+	for i, contact := range s.contacts {
+		if contact == nil {
+			return fmt.Errorf("%w: contacts", ErrShopValidate)
+		}
+		if slices.ContainsFunc(s.contacts[:i], contact.sameAs) {
+			return fmt.Errorf("%w: duplicate contact %s %s", ErrShopValidate, contact.GetType(), contact.GetValue())
+		}
 	}
 	return nil
 }
@@ -55,9 +68,18 @@ func (p *Shop) ToResponse() reqresp.ShopResponse {
 		Title:       p.title,
 		Description: p.description,
 		UserID:      p.userID,
+		Contacts:    toContactResponses(p.contacts),
 	}
 }
 
+func toContactResponses(contacts []*ContactLink) []reqresp.ContactLinkResponse {
+	res := make([]reqresp.ContactLinkResponse, len(contacts))
+	for i, contact := range contacts {
+		res[i] = contact.ToResponse()
+	}
+	return res
+}
+
 func (s *Shop) GetID() uuid.UUID {
 	return s.id
 }
@@ -73,3 +95,12 @@ func (s *Shop) GetDescription() string {
 func (s *Shop) GetUserID() uuid.UUID {
 	return s.userID
 }
+
+func (s *Shop) GetContacts() []*ContactLink {
+	return slices.Clone(s.contacts)
+}
+
+// SortValue возвращает значение ключа сортировки reqresp.SortByX
+func (s *Shop) SortValue(sortBy string) any {
+	return s.title
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// SuggestionKind - вид записи, название которой подсказывается в строке поиска
+type SuggestionKind string
+
+const (
+	SuggestionShop     SuggestionKind = "shop"
+	SuggestionProduct  SuggestionKind = "product"
+	SuggestionCategory SuggestionKind = "category"
+)
+
+func (k SuggestionKind) Valid() bool {
+	return k == SuggestionShop || k == SuggestionProduct || k == SuggestionCategory
+}
+
+// Suggestion - подсказка для строки поиска; score тем больше, чем ближе название к набранному тексту
+type Suggestion struct {
+	kind  SuggestionKind
+	id    uuid.UUID
+	title string
+	score float64
+}
+
+var (
+	ErrSuggestionValidate = errors.New("model suggestion validate error")
+)
+
+func NewSuggestion(kind SuggestionKind, id uuid.UUID, title string, score float64) (*Suggestion, error) {
+	s := Suggestion{
+		kind:  kind,
+		id:    id,
+		title: title,
+		score: score,
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Suggestion) validate() error {
+	if !s.kind.Valid() {
+		return fmt.Errorf("%w: kind", ErrSuggestionValidate)
+	} else if s.id == uuid.Nil {
+		return fmt.Errorf("%w: id", ErrSuggestionValidate)
+	} else if s.title == "" {
+		return fmt.Errorf("%w: title", ErrSuggestionValidate)
+	}
+	return nil
+}
+
+func (s *Suggestion) ToResponse() reqresp.SuggestionResponse {
+	return reqresp.SuggestionResponse{
+		ID:    s.id.String(),
+		Title: s.title,
+	}
+}
+
+func (s *Suggestion) GetKind() SuggestionKind {
+	return s.kind
+}
+
+func (s *Suggestion) GetID() uuid.UUID {
+	return s.id
+}
+
+func (s *Suggestion) GetTitle() string {
+	return s.title
+}
+
+func (s *Suggestion) GetScore() float64 {
+	return s.score
+}
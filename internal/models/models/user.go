@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"fmt"
+	"net/mail"
 	"strings"
 
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
@@ -12,23 +13,53 @@ import (
 const (
 	MaxLenUsername  = 50
 	MaxLenUserLogin = 50
+	MaxLenEmail     = 254
 )
 
+// Role - роль пользователя на платформе
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator" // может удалять чужие магазины, товары и посты
+	RoleAdmin     Role = "admin"     // управляет справочниками, например категориями
+)
+
+func (r Role) Valid() bool {
+	return r == RoleUser || r == RoleModerator || r == RoleAdmin
+}
+
 type User struct {
 	id             uuid.UUID
+	username       string
 	login          string // unique
 	hashedPassword string
+	role           Role
+	email          string // unique, в нижнем регистре; пусто у пользователей, зарегистрированных до появления почты
+	emailVerified  bool
 }
 
 var (
 	ErrUserValidate = errors.New("model user validate error")
 )
 
-func NewUser(id uuid.UUID, login string, hashedPassword string) (User, error) {
+func NewUser(
+	id uuid.UUID,
+	username string,
+	login string,
+	hashedPassword string,
+	role Role,
+	email string,
+	emailVerified bool,
+) (User, error) {
 	user := User{
 		id:             id,
+		username:       strings.TrimSpace(username),
 		login:          strings.TrimSpace(login),
 		hashedPassword: hashedPassword,
+		role:           role,
+		email:          NormalizeEmail(email),
+		emailVerified:  emailVerified,
 	}
 	err := user.validate()
 	if err != nil {
@@ -38,17 +69,43 @@ func NewUser(id uuid.UUID, login string, hashedPassword string) (User, error) {
 }
 
 func (u *User) validate() error {
-	if u.login == "" || len(u.login) > MaxLenUserLogin {
+	if len(u.username) > MaxLenUsername {
+		return fmt.Errorf("%w username", ErrUserValidate)
+	} else if u.login == "" || len(u.login) > MaxLenUserLogin {
 		return fmt.Errorf("%w login", ErrUserValidate)
 	} else if u.hashedPassword == "" {
 		return fmt.Errorf("%w hashedPassword", ErrUserValidate)
+	} else if !u.role.Valid() {
+		return fmt.Errorf("%w role", ErrUserValidate)
+	} else if u.email != "" && !validEmail(u.email) {
+		return fmt.Errorf("%w email", ErrUserValidate)
+	} else if u.email == "" && u.emailVerified {
+		return fmt.Errorf("%w emailVerified without email", ErrUserValidate)
 	}
 	return nil
 }
 
+// NormalizeEmail приводит адрес почты к виду, в котором он хранится и сравнивается
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// validEmail - голый адрес вида user@example.com, без имени и угловых скобок
+func validEmail(email string) bool {
+	if len(email) > MaxLenEmail {
+		return false
+	}
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Name == "" && addr.Address == email
+}
+
 func (p *User) ToResponse() reqresp.UserResponse {
 	return reqresp.UserResponse{
-		Login: p.GetLogin(),
+		Username:      p.GetUsername(),
+		Login:         p.GetLogin(),
+		Role:          string(p.GetRole()),
+		Email:         p.GetEmail(),
+		EmailVerified: p.IsEmailVerified(),
 	}
 }
 
@@ -56,6 +113,10 @@ func (u *User) GetID() uuid.UUID {
 	return u.id
 }
 
+func (u *User) GetUsername() string {
+	return u.username
+}
+
 func (u *User) GetLogin() string {
 	return u.login
 }
@@ -63,3 +124,15 @@ func (u *User) GetLogin() string {
 func (u *User) GetHashedPassword() string {
 	return u.hashedPassword
 }
+
+func (u *User) GetRole() Role {
+	return u.role
+}
+
+func (u *User) GetEmail() string {
+	return u.email
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.emailVerified
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA - второй фактор пользователя: секрет TOTP (RFC 6238) и хеши одноразовых кодов восстановления.
+// Пока настройка не подтверждена первым кодом из приложения, второй фактор при входе не требуется.
+type UserMFA struct {
+	userID         uuid.UUID
+	secret         string // base32 без выравнивания, как в URI для приложения
+	enabled        bool
+	lastStep       int64    // последний принятый шаг TOTP, коды этого и более ранних шагов не принимаются
+	recoveryHashes []string // хеши неиспользованных кодов восстановления
+}
+
+var (
+	ErrUserMFAValidate = errors.New("model user mfa validate error")
+)
+
+func NewUserMFA(
+	userID uuid.UUID,
+	secret string,
+	enabled bool,
+	lastStep int64,
+	recoveryHashes []string,
+) (*UserMFA, error) {
+	mfa := &UserMFA{
+		userID:         userID,
+		secret:         secret,
+		enabled:        enabled,
+		lastStep:       lastStep,
+		recoveryHashes: slices.Clone(recoveryHashes),
+	}
+	if err := mfa.validate(); err != nil {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+func (m *UserMFA) validate() error {
+	if m.userID == uuid.Nil {
+		return fmt.Errorf("%w: userID", ErrUserMFAValidate)
+	} else if m.secret == "" {
+		return fmt.Errorf("%w: secret", ErrUserMFAValidate)
+	} else if m.lastStep < 0 {
+		return fmt.Errorf("%w: lastStep", ErrUserMFAValidate)
+	} else if slices.Contains(m.recoveryHashes, "") {
+		return fmt.Errorf("%w: recoveryHashes", ErrUserMFAValidate)
+	}
+	return nil
+}
+
+func (m *UserMFA) GetUserID() uuid.UUID {
+	return m.userID
+}
+
+func (m *UserMFA) GetSecret() string {
+	return m.secret
+}
+
+func (m *UserMFA) IsEnabled() bool {
+	return m.enabled
+}
+
+func (m *UserMFA) GetLastStep() int64 {
+	return m.lastStep
+}
+
+func (m *UserMFA) GetRecoveryHashes() []string {
+	return slices.Clone(m.recoveryHashes)
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenPurpose - для чего выдан одноразовый токен из письма
+type TokenPurpose string
+
+const (
+	PurposeVerifyEmail   TokenPurpose = "verify_email"
+	PurposeResetPassword TokenPurpose = "reset_password"
+)
+
+func (p TokenPurpose) Valid() bool {
+	return p == PurposeVerifyEmail || p == PurposeResetPassword
+}
+
+// UserToken - одноразовый токен из письма: подтверждение почты или сброс пароля.
+// Хранится только хеш токена.
+type UserToken struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	purpose   TokenPurpose
+	tokenHash string
+	email     string // адрес, на который отправлен токен
+	expiresAt time.Time
+	used      bool
+}
+
+var (
+	ErrUserTokenValidate = errors.New("model user token validate error")
+)
+
+func NewUserToken(
+	id uuid.UUID,
+	userID uuid.UUID,
+	purpose TokenPurpose,
+	tokenHash string,
+	email string,
+	expiresAt time.Time,
+	used bool,
+) (*UserToken, error) {
+	token := &UserToken{
+		id:        id,
+		userID:    userID,
+		purpose:   purpose,
+		tokenHash: tokenHash,
+		email:     NormalizeEmail(email),
+		expiresAt: expiresAt,
+		used:      used,
+	}
+	if err := token.validate(); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (t *UserToken) validate() error {
+	if t.userID == uuid.Nil {
+		return fmt.Errorf("%w: userID", ErrUserTokenValidate)
+	} else if !t.purpose.Valid() {
+		return fmt.Errorf("%w: purpose", ErrUserTokenValidate)
+	} else if t.tokenHash == "" {
+		return fmt.Errorf("%w: tokenHash", ErrUserTokenValidate)
+	} else if t.email == "" {
+		return fmt.Errorf("%w: email", ErrUserTokenValidate)
+	}
+	return nil
+}
+
+func (t *UserToken) GetID() uuid.UUID {
+	return t.id
+}
+
+func (t *UserToken) GetUserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *UserToken) GetPurpose() TokenPurpose {
+	return t.purpose
+}
+
+func (t *UserToken) GetTokenHash() string {
+	return t.tokenHash
+}
+
+func (t *UserToken) GetEmail() string {
+	return t.email
+}
+
+func (t *UserToken) GetExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *UserToken) IsUsed() bool {
+	return t.used
+}
@@ -0,0 +1,153 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Page - страница списка вместе с общим числом записей и курсором следующей страницы.
+// В ленте подписок общее число не считается и Total равен 0
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	NextCursor string
+}
+
+// Cursor - позиция последней выданной записи в порядке (значение ключа сортировки, id).
+// Клиенту отдается в закодированном виде и для него непрозрачен.
+type Cursor struct {
+	SortBy string    `json:"s"`
+	Desc   bool      `json:"d"`
+	Value  string    `json:"v"`
+	ID     uuid.UUID `json:"id"`
+}
+
+func NewCursor(sortBy string, desc bool, value any, id uuid.UUID) Cursor {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case uint64:
+		str = strconv.FormatUint(v, 10)
+	case time.Time:
+		str = v.UTC().Format(time.RFC3339Nano)
+	default:
+		str = fmt.Sprint(v)
+	}
+	return Cursor{SortBy: sortBy, Desc: desc, Value: str, ID: id}
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode разбирает курсор и проверяет, что он выдан для той же сортировки
+func Decode(s string, sortBy string, desc bool) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.SortBy != sortBy || c.Desc != desc {
+		return nil, fmt.Errorf("%w: issued for another sort order", ErrInvalidCursor)
+	}
+	if _, err := c.TypedValue(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// TypedValue возвращает значение ключа в типе соответствующего поля модели
+func (c *Cursor) TypedValue() (any, error) {
+	switch c.SortBy {
+	case reqresp.SortByTitle:
+		return c.Value, nil
+	case reqresp.SortByCost:
+		v, err := strconv.ParseUint(c.Value, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return v, nil
+	case reqresp.SortByTimePublication:
+		v, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: unknown sort key %q", ErrInvalidCursor, c.SortBy)
+}
+
+// Normalize проверяет параметры сортировки и страницы и подставляет значения по умолчанию.
+// allowed[0] - ключ сортировки по умолчанию.
+func Normalize(sortBy *string, order *string, page *reqresp.PageOps, allowed []string, defaultOrder string) error {
+	if *sortBy == "" {
+		*sortBy = allowed[0]
+	}
+	found := false
+	for _, key := range allowed {
+		if key == *sortBy {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown sort key %q", *sortBy)
+	}
+
+	if *order == "" {
+		*order = defaultOrder
+	}
+	if *order != reqresp.OrderAsc && *order != reqresp.OrderDesc {
+		return fmt.Errorf("unknown order %q", *order)
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = DefaultLimit
+	} else if page.Limit > MaxLimit {
+		page.Limit = MaxLimit
+	}
+	if page.Offset < 0 {
+		return fmt.Errorf("negative offset %d", page.Offset)
+	}
+	if page.Cursor != "" {
+		if page.Offset != 0 {
+			return errors.New("cursor and offset can not be used together")
+		}
+		if _, err := Decode(page.Cursor, *sortBy, *order == reqresp.OrderDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve подставляет сортировку по умолчанию для пустых значений фильтра
+func Resolve(sortBy string, order string, defaultSortBy string, defaultOrder string) (string, bool) {
+	if sortBy == "" {
+		sortBy = defaultSortBy
+	}
+	if order == "" {
+		order = defaultOrder
+	}
+	return sortBy, order == reqresp.OrderDesc
+}
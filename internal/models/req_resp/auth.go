@@ -1,16 +1,82 @@
 package reqresp
 
+import "time"
+
 type LoginUserRequest struct {
 	Login    string `json:"login" binding:"required,min=4,max=50" example:"ulogin"`
-	Password string `json:"password" binding:"required,min=4" example:"12345678"`
+	Password string `json:"password" binding:"required" example:"Password123"`
 }
 
+// LoginUserResponse - при включенном втором факторе вход возвращает только MFAToken для /auth-user/mfa
 type LoginUserResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	MFARequired  bool   `json:"mfa_required"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+}
+
+type CompleteMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required,max=32" example:"123456"` // код приложения или код восстановления
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required,max=32" example:"123456"`
+}
+
+type MFAEnrollResponse struct {
+	Secret          string   `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	ProvisioningURI string   `json:"provisioning_uri" example:"otpauth://totp/CraftPlace:ulogin?secret=JBSWY3DPEHPK3PXP&issuer=CraftPlace"`
+	RecoveryCodes   []string `json:"recovery_codes" example:"ABCDE-FGHIJ"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // необязателен, если передан - отзывается вместе с токеном доступа
 }
 
 type RegisterUserRequest struct {
 	Username string `json:"username" binding:"required,max=50" example:"uname"`
 	Login    string `json:"login" binding:"required,min=4,max=50" example:"ulogin"`
-	Password string `json:"password" binding:"required,min=4" example:"12345678"`
+	Email    string `json:"email" binding:"required,email,max=254" example:"master@example.com"`
+	Password string `json:"password" binding:"required" example:"Password123"` // проверяется политикой паролей
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email,max=254" example:"master@example.com"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required" example:"Password123"` // проверяется политикой паролей
+}
+
+type LoginLockoutResponse struct {
+	Scope       string    `json:"scope" example:"login"` // login или ip
+	Subject     string    `json:"subject" example:"ulogin"`
+	Failures    int       `json:"failures" example:"10"`
+	LockedUntil time.Time `json:"locked_until"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type IntrospectRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// IntrospectResponse - ответ в духе RFC 7662: для недействительного токена только active=false
+type IntrospectResponse struct {
+	Active    bool   `json:"active"`
+	TokenID   string `json:"jti,omitempty"`
+	Subject   string `json:"sub,omitempty"` // id пользователя
+	Role      string `json:"role,omitempty" example:"user_role"`
+	IssuedAt  int64  `json:"iat,omitempty"` // unix-время
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
 }
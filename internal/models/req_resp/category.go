@@ -3,15 +3,41 @@ package reqresp
 type AddCategoryRequest struct {
 	Title       string `json:"title" binding:"required,max=255" example:"Звезды"`
 	Description string `json:"description" binding:"required,max=255" example:"Магазин сережек"`
+	// ParentID - пустая строка для корневой категории
+	ParentID string `json:"parent_id" binding:"omitempty,uuid" example:"aa2e8400-e29b-41d4-a716-446655441111"`
 }
 
 type UpdateCategoryRequest struct {
+	ID          string `json:"id" binding:"required,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
 	Title       string `json:"title" binding:"required,max=255" example:"Лучшие звезды"`
 	Description string `json:"description" binding:"required,max=255" example:"Лучший магазин сережек"`
+	// ParentID - пустая строка делает категорию корневой
+	ParentID string `json:"parent_id" binding:"omitempty,uuid" example:"aa2e8400-e29b-41d4-a716-446655441111"`
+}
+
+type DeleteCategoryRequest struct {
+	ID string `json:"id" binding:"required,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	// DetachProducts - убрать категорию из товаров вместо отказа в удалении
+	DetachProducts bool `json:"detach_products" example:"false"`
 }
 
 type CategoryResponse struct {
 	ID          string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
 	Title       string `json:"title" example:"Eco"`
 	Description string `json:"description" binding:"required,max=255" example:"Лучший магазин сережек"`
+	ParentID    string `json:"parent_id,omitempty" example:"aa2e8400-e29b-41d4-a716-446655441111"`
+	// Path - хлебные крошки от корня до самой категории включительно
+	Path []CategoryCrumb `json:"path"`
+}
+
+type CategoryCrumb struct {
+	ID    string `json:"id" example:"aa2e8400-e29b-41d4-a716-446655441111"`
+	Title string `json:"title" example:"Украшения"`
+}
+
+type CategoryTreeResponse struct {
+	ID          string                 `json:"id" example:"aa2e8400-e29b-41d4-a716-446655441111"`
+	Title       string                 `json:"title" example:"Украшения"`
+	Description string                 `json:"description" example:"Серьги, кольца, броши"`
+	Children    []CategoryTreeResponse `json:"children"`
 }
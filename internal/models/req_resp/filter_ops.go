@@ -2,23 +2,63 @@ package reqresp
 
 import "github.com/google/uuid"
 
+// Нулевое значение любого поля фильтра означает "без ограничения".
+// Title ищется как подстрока без учета регистра.
+
 type ShopFilter struct {
-	Title  string    // default = ""
-	UserID uuid.UUID // default = uuid.Nil
+	Title      string    // default = ""
+	UserID     uuid.UUID // default = uuid.Nil
+	CategoryID uuid.UUID // default = uuid.Nil, магазины, у которых есть товары этой категории
+	SortBy     string    // default = SortByTitle
+	Order      string    // default = OrderAsc
+	Page       PageOps
 }
 
 type ProductFilter struct {
-	Title      string    // default = ""
-	MinCost    uint64    // default = uint64(math.MaxUint64)
-	MaxCost    uint64    // default = 0
-	ShopID     uuid.UUID // default = uuid.Nil
-	CategoryID uuid.UUID // default = uuid.Nil
+	Title              string    // default = ""
+	MinCost            uint64    // default = 0
+	MaxCost            uint64    // default = 0, без верхней границы
+	ShopID             uuid.UUID // default = uuid.Nil
+	CategoryID         uuid.UUID // default = uuid.Nil
+	IncludeDescendants bool      // default = false, учитывать товары дочерних категорий CategoryID
+	SortBy             string    // default = SortByTitle, SortByCost
+	Order              string    // default = OrderAsc
+	Page               PageOps
 }
 
 type CategoryFilter struct {
-	Title string // default = ""
+	Title  string    // default = ""
+	ShopID uuid.UUID // default = uuid.Nil, категории, в которых есть товары магазина
+	SortBy string    // default = SortByTitle
+	Order  string    // default = OrderAsc
+	Page   PageOps
 }
 
 type PostFilter struct {
-	ShopID uuid.UUID // default = uuid.Nil
+	Title      string    // default = "", ищется в описании поста
+	ShopID     uuid.UUID // default = uuid.Nil
+	FollowerID uuid.UUID // default = uuid.Nil, посты магазинов, на которые подписан пользователь
+	SortBy     string    // default = SortByTimePublication
+	Order      string    // default = OrderDesc, новые посты первыми
+	Page       PageOps
+}
+
+// Ключи сортировки списков
+const (
+	SortByTitle           = "title"
+	SortByCost            = "cost"
+	SortByTimePublication = "time_publication"
+)
+
+// Направление сортировки
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// PageOps - параметры страницы. Cursor и Offset взаимоисключающие.
+type PageOps struct {
+	Limit  int    // default = 0 - все записи (в API - pagination.DefaultLimit)
+	Offset int    // default = 0
+	Cursor string // default = "", непрозрачный курсор из PageResponse.NextCursor
 }
@@ -0,0 +1,17 @@
+package reqresp
+
+import "github.com/google/uuid"
+
+type ImageResponse struct {
+	ID           string `json:"id" example:"cc2e8400-e29b-41d4-a716-446655443333"`
+	URL          string `json:"url" example:"/media/products/bb2e8400-e29b-41d4-a716-446655442222/cc2e8400-e29b-41d4-a716-446655443333.jpg"`
+	ThumbnailURL string `json:"thumbnail_url" example:"/media/products/bb2e8400-e29b-41d4-a716-446655442222/cc2e8400-e29b-41d4-a716-446655443333_thumb.jpg"`
+	ContentType  string `json:"content_type" example:"image/jpeg"`
+	Width        int    `json:"width" example:"1200"`
+	Height       int    `json:"height" example:"800"`
+}
+
+// ReorderImagesRequest - все изображения записи в новом порядке
+type ReorderImagesRequest struct {
+	ImageIDs []uuid.UUID `json:"image_ids" binding:"required"`
+}
@@ -0,0 +1,35 @@
+package reqresp
+
+// Конверты списков с пагинацией: страница записей, общее число записей под фильтром
+// и курсор следующей страницы (пустой, если страница последняя)
+
+type CategoryPageResponse struct {
+	Items      []CategoryResponse `json:"items"`
+	Total      int                `json:"total" example:"42"`
+	NextCursor string             `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"`
+}
+
+type ShopPageResponse struct {
+	Items      []ShopResponse `json:"items"`
+	Total      int            `json:"total" example:"42"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"`
+}
+
+type ProductPageResponse struct {
+	Items      []ProductResponse `json:"items"`
+	Total      int               `json:"total" example:"42"`
+	NextCursor string            `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"`
+}
+
+type PostPageResponse struct {
+	Items      []PostResponse `json:"items"`
+	Total      int            `json:"total" example:"42"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJ2IjoiRWNvIn0"`
+}
+
+// FeedPageResponse - страница ленты подписок, без общего числа постов: его подсчет
+// стоил бы больше самой страницы
+type FeedPageResponse struct {
+	Items      []PostResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJzIjoidGltZV9wdWJsaWNhdGlvbiJ9"`
+}
@@ -21,6 +21,8 @@ type PostResponse struct {
 	Description     string    `json:"description" binding:"required,max=255" example:"Лучший магазин сережек"`
 	TimePublication time.Time `json:"timePublication" example:"2023-06-15T10:00:00Z"`
 	ShopID          uuid.UUID `json:"shopID" binding:"required,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	// Images - изображения поста в заданном владельцем порядке
+	Images []ImageResponse `json:"images"`
 }
 
 type DeletePostRequest struct {
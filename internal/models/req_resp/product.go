@@ -26,6 +26,8 @@ type ProductResponse struct {
 	Cost        uint64      `json:"cost" binding:"required,min=0" example:"200"`
 	ShopID      uuid.UUID   `json:"shopID" binding:"required,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
 	CategoryIDs []uuid.UUID `json:"categoryIDs" binding:"required,dive,uuid"`
+	// Images - изображения товара в заданном владельцем порядке
+	Images []ImageResponse `json:"images"`
 }
 
 type DeleteProductRequest struct {
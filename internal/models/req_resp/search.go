@@ -0,0 +1,46 @@
+package reqresp
+
+// SearchFilter - полнотекстовый поиск по товарам, магазинам и постам.
+// Результаты упорядочены по релевантности, поэтому страницы задаются только через Offset.
+type SearchFilter struct {
+	Query string  // поисковая строка, обязательна
+	Kind  string  // default = "", все виды записей; "product", "shop" или "post"
+	Page  PageOps // Cursor не поддерживается
+}
+
+type SearchHitResponse struct {
+	Kind string `json:"kind" example:"product" enums:"product,shop,post"`
+	ID   string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	// ShopID - магазин записи, для магазина совпадает с ID
+	ShopID string `json:"shop_id" example:"aa2e8400-e29b-41d4-a716-446655441111"`
+	// Title - название товара или магазина, у постов пустое
+	Title string `json:"title" example:"Серёжки со звездой"`
+	// Snippet - фрагмент текста, найденные слова выделены <mark>, остальной текст экранирован для HTML
+	Snippet string  `json:"snippet" example:"Сережки со <mark>звездой</mark>. Серебро"`
+	Rank    float64 `json:"rank" example:"0.0759"`
+}
+
+type SearchPageResponse struct {
+	Items []SearchHitResponse `json:"items"`
+	Total int                 `json:"total" example:"42"`
+}
+
+// SuggestFilter - подсказки по началу названия с допуском опечаток
+type SuggestFilter struct {
+	Query string // набранный текст
+	Limit int    // подсказок каждого вида, default = 0 - searcher.DefaultSuggestLimit
+}
+
+type SuggestionResponse struct {
+	ID    string `json:"id" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	Title string `json:"title" example:"Серёжки со звездой"`
+}
+
+// SuggestResponse - подсказки по видам записей, самые близкие к запросу первыми
+type SuggestResponse struct {
+	Shops      []SuggestionResponse `json:"shops"`
+	Products   []SuggestionResponse `json:"products"`
+	Categories []SuggestionResponse `json:"categories"`
+	// TimedOut - подсказки не успели найтись за отведенное время, списки могут быть пустыми
+	TimedOut bool `json:"timed_out,omitempty" example:"false"`
+}
@@ -3,14 +3,17 @@ package reqresp
 import "github.com/google/uuid"
 
 type AddShopRequest struct {
-	Title       string `json:"title" binding:"required,max=255" example:"Звезды"`
-	Description string `json:"description" binding:"required,max=255" example:"Магазин сережек"`
+	Title       string               `json:"title" binding:"required,max=255" example:"Звезды"`
+	Description string               `json:"description" binding:"required,max=255" example:"Магазин сережек"`
+	Contacts    []ContactLinkRequest `json:"contacts" binding:"omitempty,max=10,dive"`
 }
 
 type UpdateShopRequest struct {
 	ShopID      string `json:"id_shop" example:"bb2e8400-e29b-41d4-a716-446655442222"`
 	Title       string `json:"title" binding:"required,max=255" example:"Лучшие звезды"`
 	Description string `json:"description" binding:"required,max=255" example:"Лучший магазин сережек"`
+	// Contacts заменяет контакты магазина целиком; без поля контакты не меняются, [] - удаляет все
+	Contacts []ContactLinkRequest `json:"contacts" binding:"omitempty,max=10,dive"`
 }
 
 type DeleteShopRequest struct {
@@ -22,4 +25,21 @@ type ShopResponse struct {
 	Title       string    `json:"title" example:"Eco"`
 	Description string    `json:"description" binding:"required,max=255" example:"Лучший магазин сережек"`
 	UserID      uuid.UUID `json:"userID" binding:"required,uuid" example:"bb2e8400-e29b-41d4-a716-446655442222"`
+	// Contacts - каналы связи с мастером в заданном им порядке
+	Contacts []ContactLinkResponse `json:"contacts"`
+	// Followers - число подписчиков магазина
+	Followers int `json:"followers" example:"42"`
+}
+
+// ContactLinkRequest - контакт магазина: имя пользователя (@name) или ссылка на профиль
+// для telegram, vk и instagram, адрес для website и email
+type ContactLinkRequest struct {
+	Type  string `json:"type" binding:"required,oneof=telegram vk instagram website email" example:"telegram"`
+	Value string `json:"value" binding:"required,max=255" example:"@craft_star"`
+}
+
+type ContactLinkResponse struct {
+	Type  string `json:"type" example:"telegram"`
+	Value string `json:"value" example:"craft_star"`
+	URL   string `json:"url" example:"https://t.me/craft_star"`
 }
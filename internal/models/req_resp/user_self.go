@@ -5,9 +5,18 @@ type UpdateLoginRequest struct {
 }
 
 type UpdateUserPasswordRequest struct {
-	Password string `json:"password" binding:"required,min=4" example:"12345678"`
+	Password string `json:"password" binding:"required" example:"Password123"` // проверяется политикой паролей
 }
 
 type UserResponse struct {
-	Login string `json:"login" binding:"required,max=50" example:"uname"`
+	Username string `json:"username" example:"uname"`
+	Login    string `json:"login" binding:"required,max=50" example:"uname"`
+	Role     string `json:"role" example:"user"`
+	// Email - пусто у пользователей, зарегистрированных без почты
+	Email         string `json:"email" example:"master@example.com"`
+	EmailVerified bool   `json:"email_verified" example:"true"`
+}
+
+type UpdateEmailRequest struct {
+	Email string `json:"email" binding:"required,email,max=254" example:"master@example.com"`
 }
@@ -0,0 +1,51 @@
+package categoryrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+type CategoryRep interface {
+	// GetAll возвращает страницу записей, подходящих под фильтр, в порядке filterOps.SortBy
+	GetAll(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error)
+	// Count - число записей, подходящих под фильтр, без учета страницы
+	Count(ctx context.Context, filterOps *reqresp.CategoryFilter) (int, error)
+	// GetTree возвращает все категории без фильтра и страниц, их немного и они нужны целиком
+	// для дерева и путей от корня
+	GetTree(ctx context.Context) (*models.CategoryTree, error)
+	GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error)
+	// Add и Update возвращают ErrParentNotFound для несуществующего родителя,
+	// Update - ErrCategoryCycle, если новый родитель лежит в поддереве категории
+	Add(ctx context.Context, category *models.Category) error
+	Update(ctx context.Context, category *models.Category) error
+	// Delete возвращает ErrCategoryInUse, если на категорию ссылаются товары,
+	// и ErrCategoryHasChildren, если у нее есть дочерние категории
+	Delete(ctx context.Context, categoryID uuid.UUID) error
+	// DeleteDetached в одной транзакции отвязывает категорию от товаров и удаляет ее
+	DeleteDetached(ctx context.Context, categoryID uuid.UUID) error
+}
+
+// categorySort - сортировка и страница фильтра с учетом значений по умолчанию
+func categorySort(filterOps *reqresp.CategoryFilter) (string, bool, reqresp.PageOps) {
+	if filterOps == nil {
+		filterOps = &reqresp.CategoryFilter{}
+	}
+	sortBy, desc := pagination.Resolve(filterOps.SortBy, filterOps.Order, reqresp.SortByTitle, reqresp.OrderAsc)
+	return sortBy, desc, filterOps.Page
+}
+
+var (
+	ErrCategoryRep      = errors.New("CategoryRep")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryInUse    = errors.New("category is used by products")
+	ErrDuplicateTitle   = errors.New("category with this title already exists")
+
+	ErrParentNotFound      = errors.New("parent category not found")
+	ErrCategoryCycle       = errors.New("category cannot be moved into its own subtree")
+	ErrCategoryHasChildren = errors.New("category has child categories")
+)
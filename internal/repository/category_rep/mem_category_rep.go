@@ -0,0 +1,181 @@
+package categoryrep
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemCategoryRep(db *memdb.DB) CategoryRep {
+	return &memCategoryRep{
+		db: db,
+	}
+}
+
+type memCategoryRep struct {
+	db *memdb.DB
+}
+
+func (r *memCategoryRep) GetAll(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error) {
+	res := make([]*models.Category, 0)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, category := range t.Categories {
+			if matchCategory(t, category, filterOps) {
+				res = append(res, category)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortBy, desc, page := categorySort(filterOps)
+	res, err = memdb.Paginate(res, sortBy, desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return res, nil
+}
+
+func (r *memCategoryRep) Count(ctx context.Context, filterOps *reqresp.CategoryFilter) (int, error) {
+	count := 0
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, category := range t.Categories {
+			if matchCategory(t, category, filterOps) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (r *memCategoryRep) GetTree(ctx context.Context) (*models.CategoryTree, error) {
+	var res *models.CategoryTree
+	err := r.db.Read(func(t *memdb.Tables) error {
+		res = t.CategoryTree()
+		return nil
+	})
+	return res, err
+}
+
+func (r *memCategoryRep) GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	var res *models.Category
+	err := r.db.Read(func(t *memdb.Tables) error {
+		category, ok := t.Categories[categoryID]
+		if !ok {
+			return ErrCategoryNotFound
+		}
+		res = category
+		return nil
+	})
+	return res, err
+}
+
+func (r *memCategoryRep) Add(ctx context.Context, category *models.Category) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Categories[category.GetID()]; ok {
+			return ErrCategoryRep
+		}
+		if titleTaken(t, category) {
+			return ErrDuplicateTitle
+		}
+		if err := checkParent(t, category); err != nil {
+			return err
+		}
+		t.Categories[category.GetID()] = category
+		return nil
+	})
+}
+
+func (r *memCategoryRep) Update(ctx context.Context, category *models.Category) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Categories[category.GetID()]; !ok {
+			return ErrCategoryNotFound
+		}
+		if titleTaken(t, category) {
+			return ErrDuplicateTitle
+		}
+		if err := checkParent(t, category); err != nil {
+			return err
+		}
+		t.Categories[category.GetID()] = category
+		return nil
+	})
+}
+
+func (r *memCategoryRep) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Categories[categoryID]; !ok {
+			return ErrCategoryNotFound
+		}
+		if t.CategoryTree().HasChildren(categoryID) {
+			return ErrCategoryHasChildren
+		}
+		if t.CategoryInUse(categoryID) {
+			return ErrCategoryInUse
+		}
+		delete(t.Categories, categoryID)
+		return nil
+	})
+}
+
+func (r *memCategoryRep) DeleteDetached(ctx context.Context, categoryID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Categories[categoryID]; !ok {
+			return ErrCategoryNotFound
+		}
+		if t.CategoryTree().HasChildren(categoryID) {
+			return ErrCategoryHasChildren
+		}
+		if err := t.DetachCategory(categoryID); err != nil {
+			return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+		}
+		delete(t.Categories, categoryID)
+		return nil
+	})
+}
+
+// checkParent - родитель существует и не лежит в поддереве самой категории
+func checkParent(t *memdb.Tables, category *models.Category) error {
+	parentID := category.GetParentID()
+	if parentID == uuid.Nil {
+		return nil
+	}
+	if _, ok := t.Categories[parentID]; !ok {
+		return ErrParentNotFound
+	}
+	if slices.Contains(t.CategoryTree().Subtree(category.GetID()), parentID) {
+		return ErrCategoryCycle
+	}
+	return nil
+}
+
+// titleTaken - занято ли название другой категорией, без учета регистра (как индекс по lower(title))
+func titleTaken(t *memdb.Tables, category *models.Category) bool {
+	for id, c := range t.Categories {
+		if id != category.GetID() && strings.EqualFold(c.GetTitle(), category.GetTitle()) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchCategory(t *memdb.Tables, category *models.Category, filterOps *reqresp.CategoryFilter) bool {
+	if filterOps == nil {
+		return true
+	}
+	if filterOps.Title != "" && !memdb.ContainsFold(category.GetTitle(), filterOps.Title) {
+		return false
+	}
+	if filterOps.ShopID != uuid.Nil && !t.ShopHasCategory(filterOps.ShopID, category.GetID()) {
+		return false
+	}
+	return true
+}
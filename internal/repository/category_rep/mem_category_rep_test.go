@@ -0,0 +1,166 @@
+package categoryrep_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemCategoryRepSuite struct {
+	suite.Suite
+	categoryRep categoryrep.CategoryRep
+	productRep  productrep.ProductRep
+	shop        *models.Shop
+}
+
+func TestMemCategoryRep(t *testing.T) {
+	suite.RunSuite(t, new(MemCategoryRepSuite))
+}
+
+func (s *MemCategoryRepSuite) BeforeEach(t provider.T) {
+	ctx := context.Background()
+	db := memdb.NewDB()
+	s.categoryRep = categoryrep.NewMemCategoryRep(db)
+	s.productRep = productrep.NewMemProductRep(db)
+
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userrep.NewMemUserRep(db).Add(ctx, user))
+	shop, err := models.NewShop(uuid.New(), "Звезды", "", user.GetID(), nil)
+	t.Require().NoError(err)
+	t.Require().NoError(shoprep.NewMemShopRep(db).Add(ctx, shop))
+	s.shop = shop
+}
+
+func (s *MemCategoryRepSuite) TestMemCategoryRep_DuplicateTitle(t provider.T) {
+	ctx := context.Background()
+	category := testobj.NewCategoryMother().CategoryP()
+	t.Require().NoError(s.categoryRep.Add(ctx, category))
+
+	t.WithNewStep("add with same title in another case", func(sCtx provider.StepCtx) {
+		duplicate, err := models.NewCategory(uuid.New(), strings.ToUpper(category.GetTitle()), "", uuid.Nil)
+		sCtx.Require().NoError(err)
+
+		err = s.categoryRep.Add(ctx, duplicate)
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrDuplicateTitle)
+	})
+	t.WithNewStep("rename to taken title", func(sCtx provider.StepCtx) {
+		other := testobj.NewCategoryMother().CategoryP()
+		sCtx.Require().NoError(s.categoryRep.Add(ctx, other))
+		renamed, err := models.NewCategory(other.GetID(), category.GetTitle(), "", uuid.Nil)
+		sCtx.Require().NoError(err)
+
+		err = s.categoryRep.Update(ctx, renamed)
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrDuplicateTitle)
+	})
+	t.WithNewStep("update keeps own title", func(sCtx provider.StepCtx) {
+		updated, err := models.NewCategory(category.GetID(), category.GetTitle(), "новое описание", uuid.Nil)
+		sCtx.Require().NoError(err)
+
+		sCtx.Require().NoError(s.categoryRep.Update(ctx, updated))
+	})
+}
+
+func (s *MemCategoryRepSuite) TestMemCategoryRep_DeleteDetached(t provider.T) {
+	ctx := context.Background()
+	category := testobj.NewCategoryMother().CategoryP()
+	other := testobj.NewCategoryMother().CategoryP()
+	t.Require().NoError(s.categoryRep.Add(ctx, category))
+	t.Require().NoError(s.categoryRep.Add(ctx, other))
+	product, err := models.NewProduct(uuid.New(), "Брошь", "", 100, s.shop.GetID(), uuid.UUIDs{category.GetID(), other.GetID()})
+	t.Require().NoError(err)
+	t.Require().NoError(s.productRep.Add(ctx, product))
+
+	t.WithNewStep("delete is blocked", func(sCtx provider.StepCtx) {
+		err := s.categoryRep.Delete(ctx, category.GetID())
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryInUse)
+	})
+	t.WithNewStep("detached delete keeps product", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.categoryRep.DeleteDetached(ctx, category.GetID()))
+
+		_, err := s.categoryRep.GetByID(ctx, category.GetID())
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryNotFound)
+		stored, err := s.productRep.GetByID(ctx, product.GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(uuid.UUIDs{other.GetID()}, stored.GetCategoryIDs())
+	})
+	t.WithNewStep("unknown category", func(sCtx provider.StepCtx) {
+		err := s.categoryRep.DeleteDetached(ctx, uuid.New())
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryNotFound)
+	})
+}
+
+func (s *MemCategoryRepSuite) TestMemCategoryRep_Hierarchy(t provider.T) {
+	ctx := context.Background()
+	categoryCreator := testobj.NewCategoryMother()
+	jewelry := categoryCreator.CategoryP()
+	earrings := categoryCreator.CategoryOfParentP(jewelry.GetID())
+	studs := categoryCreator.CategoryOfParentP(earrings.GetID())
+	for _, category := range []*models.Category{jewelry, earrings, studs} {
+		t.Require().NoError(s.categoryRep.Add(ctx, category))
+	}
+
+	t.WithNewStep("path from root", func(sCtx provider.StepCtx) {
+		tree, err := s.categoryRep.GetTree(ctx)
+		sCtx.Require().NoError(err)
+
+		res := tree.ToResponse(studs)
+
+		sCtx.Require().Len(res.Path, 3)
+		sCtx.Assert().Equal(jewelry.GetID().String(), res.Path[0].ID)
+		sCtx.Assert().Equal(studs.GetID().String(), res.Path[2].ID)
+		sCtx.Assert().Equal(earrings.GetID().String(), res.ParentID)
+	})
+	t.WithNewStep("tree", func(sCtx provider.StepCtx) {
+		tree, err := s.categoryRep.GetTree(ctx)
+		sCtx.Require().NoError(err)
+
+		roots := tree.ToTreeResponse()
+
+		sCtx.Require().Len(roots, 1)
+		sCtx.Require().Len(roots[0].Children, 1)
+		sCtx.Assert().Equal(studs.GetID().String(), roots[0].Children[0].Children[0].ID)
+		sCtx.Assert().ElementsMatch(uuid.UUIDs{jewelry.GetID(), earrings.GetID(), studs.GetID()}, tree.Subtree(jewelry.GetID()))
+	})
+	t.WithNewStep("move into own subtree", func(sCtx provider.StepCtx) {
+		moved, err := models.NewCategory(jewelry.GetID(), jewelry.GetTitle(), "", studs.GetID())
+		sCtx.Require().NoError(err)
+
+		err = s.categoryRep.Update(ctx, moved)
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryCycle)
+	})
+	t.WithNewStep("unknown parent", func(sCtx provider.StepCtx) {
+		err := s.categoryRep.Add(ctx, categoryCreator.CategoryOfParentP(uuid.New()))
+
+		sCtx.Require().ErrorIs(err, categoryrep.ErrParentNotFound)
+	})
+	t.WithNewStep("delete category with children", func(sCtx provider.StepCtx) {
+		err := s.categoryRep.Delete(ctx, earrings.GetID())
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryHasChildren)
+
+		err = s.categoryRep.DeleteDetached(ctx, earrings.GetID())
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryHasChildren)
+	})
+	t.WithNewStep("move to root", func(sCtx provider.StepCtx) {
+		moved, err := models.NewCategory(studs.GetID(), studs.GetTitle(), "", uuid.Nil)
+		sCtx.Require().NoError(err)
+		sCtx.Require().NoError(s.categoryRep.Update(ctx, moved))
+
+		sCtx.Require().NoError(s.categoryRep.Delete(ctx, earrings.GetID()))
+	})
+}
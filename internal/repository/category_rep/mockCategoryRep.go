@@ -0,0 +1,63 @@
+package categoryrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryRep struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRep) GetAll(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Category), args.Error(1)
+}
+
+func (m *MockCategoryRep) Count(ctx context.Context, filterOps *reqresp.CategoryFilter) (int, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryRep) GetTree(ctx context.Context) (*models.CategoryTree, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CategoryTree), args.Error(1)
+}
+
+func (m *MockCategoryRep) GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	args := m.Called(ctx, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+
+func (m *MockCategoryRep) Add(ctx context.Context, category *models.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRep) Update(ctx context.Context, category *models.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRep) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	args := m.Called(ctx, categoryID)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRep) DeleteDetached(ctx context.Context, categoryID uuid.UUID) error {
+	args := m.Called(ctx, categoryID)
+	return args.Error(0)
+}
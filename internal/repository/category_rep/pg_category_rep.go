@@ -0,0 +1,300 @@
+package categoryrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	categoriesTable = "categories"
+	// categoryParentFK - ограничение categories.parent_id, отличает дочерние категории от товаров при удалении
+	categoryParentFK = "categories_parent_id_fkey"
+)
+
+var categoryColumns = []string{"id", "title", "description", "parent_id"}
+
+// ancestorsQuery проверяет, лежит ли категория $2 на пути от $1 к корню
+const ancestorsQuery = `
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM categories WHERE id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+)
+SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`
+
+func NewPgCategoryRep(pool *pgxpool.Pool) CategoryRep {
+	return &pgCategoryRep{
+		pool: pool,
+	}
+}
+
+type pgCategoryRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgCategoryRep) GetAll(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error) {
+	builder := filterCategories(pgdb.Psql.Select(categoryColumns...).From(categoriesTable), filterOps)
+	sortBy, desc, page := categorySort(filterOps)
+	builder, err := pgdb.Paginate(builder, sortBy, "title", "id", desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgCategoryRep) Count(ctx context.Context, filterOps *reqresp.CategoryFilter) (int, error) {
+	query, args, err := filterCategories(pgdb.Psql.Select("COUNT(*)").From(categoriesTable), filterOps).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return count, nil
+}
+
+func (r *pgCategoryRep) GetTree(ctx context.Context) (*models.CategoryTree, error) {
+	query, args, err := pgdb.Psql.Select(categoryColumns...).From(categoriesTable).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	defer rows.Close()
+
+	categories := make([]*models.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return models.NewCategoryTree(categories), nil
+}
+
+func (r *pgCategoryRep) GetByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
+	query, args, err := pgdb.Psql.Select(categoryColumns...).
+		From(categoriesTable).
+		Where(sq.Eq{"id": categoryID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	category, err := scanCategory(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
+	return category, err
+}
+
+func (r *pgCategoryRep) Add(ctx context.Context, category *models.Category) error {
+	query, args, err := pgdb.Psql.Insert(categoriesTable).
+		Columns(categoryColumns...).
+		Values(category.GetID(), category.GetTitle(), category.GetDescription(), nullableID(category.GetParentID())).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return categoryWriteError(err)
+	}
+	return nil
+}
+
+func (r *pgCategoryRep) Update(ctx context.Context, category *models.Category) error {
+	query, args, err := pgdb.Psql.Update(categoriesTable).
+		Set("title", category.GetTitle()).
+		Set("description", category.GetDescription()).
+		Set("parent_id", nullableID(category.GetParentID())).
+		Where(sq.Eq{"id": category.GetID()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return r.inTx(ctx, categoryWriteError, func(tx pgx.Tx) error {
+		// две параллельные смены родителя могут вместе замкнуть цикл, поэтому
+		// изменения категорий выполняются по очереди; чтение не блокируется
+		if _, err := tx.Exec(ctx, "LOCK TABLE "+categoriesTable+" IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+		if parentID := category.GetParentID(); parentID != uuid.Nil {
+			var cycle bool
+			if err := tx.QueryRow(ctx, ancestorsQuery, parentID, category.GetID()).Scan(&cycle); err != nil {
+				return err
+			}
+			if cycle {
+				return ErrCategoryCycle
+			}
+		}
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+}
+
+func (r *pgCategoryRep) Delete(ctx context.Context, categoryID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Delete(categoriesTable).
+		Where(sq.Eq{"id": categoryID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return categoryDeleteError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *pgCategoryRep) DeleteDetached(ctx context.Context, categoryID uuid.UUID) error {
+	detachQuery, detachArgs, err := pgdb.Psql.Delete("product_categories").
+		Where(sq.Eq{"category_id": categoryID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	deleteQuery, deleteArgs, err := pgdb.Psql.Delete(categoriesTable).
+		Where(sq.Eq{"id": categoryID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return r.inTx(ctx, categoryDeleteError, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, detachQuery, detachArgs...); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, deleteQuery, deleteArgs...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+}
+
+// inTx выполняет fn в транзакции, ошибки СУБД переводит mapErr
+func (r *pgCategoryRep) inTx(ctx context.Context, mapErr func(error) error, fn func(tx pgx.Tx) error) error {
+	err := pgx.BeginFunc(ctx, r.pool, fn)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrCategoryCycle):
+		return err
+	default:
+		return mapErr(err)
+	}
+}
+
+// categoryWriteError - ошибка вставки или изменения: внешний ключ здесь только parent_id
+func categoryWriteError(err error) error {
+	switch {
+	case pgdb.IsUniqueViolation(err):
+		return ErrDuplicateTitle
+	case pgdb.IsForeignKeyViolation(err):
+		return ErrParentNotFound
+	default:
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+}
+
+// categoryDeleteError - на удаляемую категорию ссылаются дочерние категории или товары
+func categoryDeleteError(err error) error {
+	switch {
+	case pgdb.IsForeignKeyViolationOf(err, categoryParentFK):
+		return ErrCategoryHasChildren
+	case pgdb.IsForeignKeyViolation(err):
+		return ErrCategoryInUse
+	default:
+		return fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+}
+
+// nullableID - NULL вместо uuid.Nil для необязательной ссылки
+func nullableID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// filterCategories добавляет условия фильтра к выборке из categories
+func filterCategories(builder sq.SelectBuilder, filterOps *reqresp.CategoryFilter) sq.SelectBuilder {
+	if filterOps == nil {
+		return builder
+	}
+	if filterOps.Title != "" {
+		builder = builder.Where(sq.ILike{"title": pgdb.ContainsPattern(filterOps.Title)})
+	}
+	if filterOps.ShopID != uuid.Nil {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM product_categories pc JOIN products p ON p.id = pc.product_id "+
+				"WHERE pc.category_id = categories.id AND p.shop_id = ?)",
+			filterOps.ShopID,
+		)
+	}
+	return builder
+}
+
+func scanCategory(row pgx.Row) (*models.Category, error) {
+	var (
+		id                 uuid.UUID
+		title, description string
+		parentID           uuid.NullUUID
+	)
+	if err := row.Scan(&id, &title, &description, &parentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	category, err := models.NewCategory(id, title, description, parentID.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryRep, err)
+	}
+	return category, nil
+}
@@ -0,0 +1,29 @@
+package followrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// FollowRep - подписки покупателей на магазины
+type FollowRep interface {
+	// Follow подписывает пользователя на магазин, повторная подписка не ошибка.
+	// ErrShopNotFound - магазина нет, ErrUserNotFound - пользователя нет.
+	Follow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error
+	// Unfollow отменяет подписку, если ее нет - ничего не делает
+	Unfollow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error
+	// IsFollowing - подписан ли пользователь на магазин
+	IsFollowing(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) (bool, error)
+	// CountFollowers - число подписчиков магазинов, магазины без подписчиков в ответ не попадают
+	CountFollowers(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error)
+	// FollowerIDs - подписчики магазина
+	FollowerIDs(ctx context.Context, shopID uuid.UUID) (uuid.UUIDs, error)
+}
+
+var (
+	ErrFollowRep    = errors.New("FollowRep")
+	ErrShopNotFound = errors.New("shop not found")
+	ErrUserNotFound = errors.New("user not found")
+)
@@ -0,0 +1,81 @@
+package followrep
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemFollowRep(db *memdb.DB) FollowRep {
+	return &memFollowRep{
+		db: db,
+	}
+}
+
+type memFollowRep struct {
+	db *memdb.DB
+}
+
+func (r *memFollowRep) Follow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Shops[shopID]; !ok {
+			return ErrShopNotFound
+		}
+		if _, ok := t.Users[userID]; !ok {
+			return ErrUserNotFound
+		}
+		follow := memdb.Follow{UserID: userID, ShopID: shopID}
+		if _, ok := t.Follows[follow]; !ok {
+			t.Follows[follow] = time.Now().UTC()
+		}
+		return nil
+	})
+}
+
+func (r *memFollowRep) Unfollow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		delete(t.Follows, memdb.Follow{UserID: userID, ShopID: shopID})
+		return nil
+	})
+}
+
+func (r *memFollowRep) IsFollowing(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) (bool, error) {
+	following := false
+	err := r.db.Read(func(t *memdb.Tables) error {
+		_, following = t.Follows[memdb.Follow{UserID: userID, ShopID: shopID}]
+		return nil
+	})
+	return following, err
+}
+
+func (r *memFollowRep) CountFollowers(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error) {
+	res := make(map[uuid.UUID]int)
+	wanted := make(map[uuid.UUID]struct{}, len(shopIDs))
+	for _, id := range shopIDs {
+		wanted[id] = struct{}{}
+	}
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for follow := range t.Follows {
+			if _, ok := wanted[follow.ShopID]; ok {
+				res[follow.ShopID]++
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (r *memFollowRep) FollowerIDs(ctx context.Context, shopID uuid.UUID) (uuid.UUIDs, error) {
+	var res uuid.UUIDs
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for follow := range t.Follows {
+			if follow.ShopID == shopID {
+				res = append(res, follow.UserID)
+			}
+		}
+		return nil
+	})
+	return res, err
+}
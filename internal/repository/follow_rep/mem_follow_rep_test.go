@@ -0,0 +1,129 @@
+package followrep_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemFollowRepSuite struct {
+	suite.Suite
+	followRep followrep.FollowRep
+	shopRep   shoprep.ShopRep
+	postRep   postrep.PostRep
+	buyer     *models.User
+	shops     []*models.Shop
+}
+
+func TestMemFollowRep(t *testing.T) {
+	suite.RunSuite(t, new(MemFollowRepSuite))
+}
+
+func (s *MemFollowRepSuite) BeforeEach(t provider.T) {
+	ctx := context.Background()
+	db := memdb.NewDB()
+	s.followRep = followrep.NewMemFollowRep(db)
+	s.shopRep = shoprep.NewMemShopRep(db)
+	s.postRep = postrep.NewMemPostRep(db)
+
+	userRep := userrep.NewMemUserRep(db)
+	master := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userRep.Add(ctx, master))
+	s.buyer = testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userRep.Add(ctx, s.buyer))
+
+	s.shops = nil
+	for range 3 {
+		shop := testobj.NewShopMother().ShopOfUserP(master.GetID())
+		t.Require().NoError(s.shopRep.Add(ctx, shop))
+		s.shops = append(s.shops, shop)
+	}
+}
+
+func (s *MemFollowRepSuite) TestMemFollowRep_Follow(t provider.T) {
+	ctx := context.Background()
+
+	t.WithNewStep("follow is idempotent and counted once", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.followRep.Follow(ctx, s.buyer.GetID(), s.shops[0].GetID()))
+		sCtx.Require().NoError(s.followRep.Follow(ctx, s.buyer.GetID(), s.shops[0].GetID()))
+
+		following, err := s.followRep.IsFollowing(ctx, s.buyer.GetID(), s.shops[0].GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(following)
+		counts, err := s.followRep.CountFollowers(ctx, uuid.UUIDs{s.shops[0].GetID(), s.shops[1].GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(map[uuid.UUID]int{s.shops[0].GetID(): 1}, counts)
+	})
+	t.WithNewStep("unfollow", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.followRep.Unfollow(ctx, s.buyer.GetID(), s.shops[0].GetID()))
+		sCtx.Require().NoError(s.followRep.Unfollow(ctx, s.buyer.GetID(), s.shops[0].GetID()), "not following is not an error")
+
+		following, err := s.followRep.IsFollowing(ctx, s.buyer.GetID(), s.shops[0].GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().False(following)
+	})
+	t.WithNewStep("unknown shop", func(sCtx provider.StepCtx) {
+		err := s.followRep.Follow(ctx, s.buyer.GetID(), uuid.New())
+
+		sCtx.Require().ErrorIs(err, followrep.ErrShopNotFound)
+	})
+	t.WithNewStep("follows are deleted with the shop", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.followRep.Follow(ctx, s.buyer.GetID(), s.shops[2].GetID()))
+		sCtx.Require().NoError(s.shopRep.Delete(ctx, s.shops[2].GetID()))
+
+		counts, err := s.followRep.CountFollowers(ctx, uuid.UUIDs{s.shops[2].GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(counts)
+	})
+}
+
+func (s *MemFollowRepSuite) TestMemFollowRep_FeedFilter(t provider.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	posts := make([]*models.Post, 0)
+	for i, shop := range s.shops {
+		for j := range 2 {
+			post, err := models.NewPost(uuid.New(), "пост", start.Add(time.Duration(3*j+i)*time.Hour), shop.GetID())
+			t.Require().NoError(err)
+			t.Require().NoError(s.postRep.Add(ctx, post))
+			posts = append(posts, post)
+		}
+	}
+	t.Require().NoError(s.followRep.Follow(ctx, s.buyer.GetID(), s.shops[0].GetID()))
+	t.Require().NoError(s.followRep.Follow(ctx, s.buyer.GetID(), s.shops[2].GetID()))
+
+	t.WithNewStep("posts of followed shops, newest first", func(sCtx provider.StepCtx) {
+		filterOps := &reqresp.PostFilter{FollowerID: s.buyer.GetID()}
+
+		feed, err := s.postRep.GetAll(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		count, err := s.postRep.Count(ctx, filterOps)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().Equal(4, count)
+		ids := make(uuid.UUIDs, 0, len(feed))
+		for _, post := range feed {
+			ids = append(ids, post.GetID())
+		}
+		// посты shops[i] опубликованы в часы i и i+3
+		sCtx.Assert().Equal(uuid.UUIDs{posts[5].GetID(), posts[1].GetID(), posts[4].GetID(), posts[0].GetID()}, ids)
+	})
+	t.WithNewStep("no follows - empty feed", func(sCtx provider.StepCtx) {
+		feed, err := s.postRep.GetAll(ctx, &reqresp.PostFilter{FollowerID: uuid.New()})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(feed)
+	})
+}
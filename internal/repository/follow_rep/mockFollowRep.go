@@ -0,0 +1,43 @@
+package followrep
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFollowRep struct {
+	mock.Mock
+}
+
+func (m *MockFollowRep) Follow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	args := m.Called(ctx, userID, shopID)
+	return args.Error(0)
+}
+
+func (m *MockFollowRep) Unfollow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	args := m.Called(ctx, userID, shopID)
+	return args.Error(0)
+}
+
+func (m *MockFollowRep) IsFollowing(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, shopID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRep) CountFollowers(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, shopIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+func (m *MockFollowRep) FollowerIDs(ctx context.Context, shopID uuid.UUID) (uuid.UUIDs, error) {
+	args := m.Called(ctx, shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(uuid.UUIDs), args.Error(1)
+}
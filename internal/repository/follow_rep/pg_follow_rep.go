@@ -0,0 +1,139 @@
+package followrep
+
+import (
+	"context"
+	"fmt"
+
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	followsTable = "shop_follows"
+	// внешние ключи shop_follows, см. миграцию 000016
+	followsShopFK = "shop_follows_shop_id_fkey"
+)
+
+func NewPgFollowRep(pool *pgxpool.Pool) FollowRep {
+	return &pgFollowRep{
+		pool: pool,
+	}
+}
+
+type pgFollowRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgFollowRep) Follow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Insert(followsTable).
+		Columns("user_id", "shop_id").
+		Values(userID, shopID).
+		Suffix("ON CONFLICT (user_id, shop_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		if pgdb.IsForeignKeyViolationOf(err, followsShopFK) {
+			return ErrShopNotFound
+		}
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	return nil
+}
+
+func (r *pgFollowRep) Unfollow(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Delete(followsTable).
+		Where(sq.Eq{"user_id": userID, "shop_id": shopID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	return nil
+}
+
+func (r *pgFollowRep) IsFollowing(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) (bool, error) {
+	query, args, err := pgdb.Psql.Select("1").
+		From(followsTable).
+		Where(sq.Eq{"user_id": userID, "shop_id": shopID}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	var following bool
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&following); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	return following, nil
+}
+
+func (r *pgFollowRep) CountFollowers(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error) {
+	res := make(map[uuid.UUID]int)
+	if len(shopIDs) == 0 {
+		return res, nil
+	}
+	query, args, err := pgdb.Psql.Select("shop_id", "COUNT(*)").
+		From(followsTable).
+		Where(sq.Eq{"shop_id": shopIDs.Strings()}).
+		GroupBy("shop_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			shopID uuid.UUID
+			count  int
+		)
+		if err := rows.Scan(&shopID, &count); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+		}
+		res[shopID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgFollowRep) FollowerIDs(ctx context.Context, shopID uuid.UUID) (uuid.UUIDs, error) {
+	query, args, err := pgdb.Psql.Select("user_id").
+		From(followsTable).
+		Where(sq.Eq{"shop_id": shopID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	defer rows.Close()
+	var res uuid.UUIDs
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+		}
+		res = append(res, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowRep, err)
+	}
+	return res, nil
+}
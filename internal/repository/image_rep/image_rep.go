@@ -0,0 +1,66 @@
+package imagerep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+// ImageRep - упорядоченные списки изображений товаров и постов. Позиции в списке
+// записи идут подряд с нуля, их назначает и сдвигает сам репозиторий.
+type ImageRep interface {
+	// GetByOwners - изображения записей в порядке позиций, записи без изображений в ответ не попадают
+	GetByOwners(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]*models.Image, error)
+	// GetByShop - изображения всех товаров и постов магазина
+	GetByShop(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error)
+	// Add ставит изображение в конец списка записи, позиция image не учитывается.
+	// ErrOwnerNotFound - записи нет, ErrTooManyImages - в списке уже models.MaxImagesPerOwner изображений
+	Add(ctx context.Context, image *models.Image) (*models.Image, error)
+	// Delete удаляет изображение из списка записи и возвращает его, чтобы можно было удалить файлы
+	Delete(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) (*models.Image, error)
+	// Reorder задает новый порядок, imageIDs - ровно все изображения записи, иначе ErrInvalidOrder
+	Reorder(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageIDs uuid.UUIDs) error
+}
+
+var (
+	ErrImageRep      = errors.New("ImageRep")
+	ErrImageNotFound = errors.New("image not found")
+	ErrOwnerNotFound = errors.New("product or post of the image not found")
+	ErrTooManyImages = errors.New("too many images")
+	ErrInvalidOrder  = errors.New("image order must list every image exactly once")
+)
+
+// withPosition - копия изображения на новой позиции, модели неизменяемы
+func withPosition(image *models.Image, position int) (*models.Image, error) {
+	return models.NewImage(
+		image.GetID(),
+		image.GetOwner(),
+		image.GetOwnerID(),
+		position,
+		image.GetKey(),
+		image.GetThumbnailKey(),
+		image.GetContentType(),
+		image.GetWidth(),
+		image.GetHeight(),
+	)
+}
+
+// sameSet - imageIDs содержит каждое изображение images ровно один раз
+func sameSet(images []*models.Image, imageIDs uuid.UUIDs) bool {
+	if len(images) != len(imageIDs) {
+		return false
+	}
+	ids := make(map[uuid.UUID]struct{}, len(images))
+	for _, img := range images {
+		ids[img.GetID()] = struct{}{}
+	}
+	for _, id := range imageIDs {
+		if _, ok := ids[id]; !ok {
+			return false
+		}
+		delete(ids, id)
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package imagerep
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemImageRep(db *memdb.DB) ImageRep {
+	return &memImageRep{
+		db: db,
+	}
+}
+
+type memImageRep struct {
+	db *memdb.DB
+}
+
+func (r *memImageRep) GetByOwners(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]*models.Image, error) {
+	res := make(map[uuid.UUID][]*models.Image)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, ownerID := range ownerIDs {
+			if images := ownerImages(t, owner, ownerID); len(images) > 0 {
+				res[ownerID] = images
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (r *memImageRep) GetByShop(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error) {
+	res := make([]*models.Image, 0)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, img := range t.Images {
+			if ownerShopID(t, img.GetOwner(), img.GetOwnerID()) == shopID {
+				res = append(res, img)
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (r *memImageRep) Add(ctx context.Context, image *models.Image) (*models.Image, error) {
+	var res *models.Image
+	err := r.db.Write(func(t *memdb.Tables) error {
+		if !ownerExists(t, image.GetOwner(), image.GetOwnerID()) {
+			return ErrOwnerNotFound
+		}
+		if _, ok := t.Images[image.GetID()]; ok {
+			return ErrImageRep
+		}
+		images := ownerImages(t, image.GetOwner(), image.GetOwnerID())
+		if len(images) >= models.MaxImagesPerOwner {
+			return ErrTooManyImages
+		}
+		added, err := withPosition(image, len(images))
+		if err != nil {
+			return err
+		}
+		t.Images[added.GetID()] = added
+		res = added
+		return nil
+	})
+	return res, err
+}
+
+func (r *memImageRep) Delete(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) (*models.Image, error) {
+	var res *models.Image
+	err := r.db.Write(func(t *memdb.Tables) error {
+		image, ok := t.Images[imageID]
+		if !ok || image.GetOwner() != owner || image.GetOwnerID() != ownerID {
+			return ErrImageNotFound
+		}
+		delete(t.Images, imageID)
+		res = image
+		return setPositions(t, ownerImages(t, owner, ownerID))
+	})
+	return res, err
+}
+
+func (r *memImageRep) Reorder(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageIDs uuid.UUIDs) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		images := ownerImages(t, owner, ownerID)
+		if !sameSet(images, imageIDs) {
+			return ErrInvalidOrder
+		}
+		ordered := make([]*models.Image, len(imageIDs))
+		for i, id := range imageIDs {
+			ordered[i] = t.Images[id]
+		}
+		return setPositions(t, ordered)
+	})
+}
+
+// ownerImages - изображения записи в порядке позиций
+func ownerImages(t *memdb.Tables, owner models.ImageOwner, ownerID uuid.UUID) []*models.Image {
+	res := make([]*models.Image, 0)
+	for _, img := range t.Images {
+		if img.GetOwner() == owner && img.GetOwnerID() == ownerID {
+			res = append(res, img)
+		}
+	}
+	slices.SortFunc(res, func(a, b *models.Image) int {
+		return cmp.Compare(a.GetPosition(), b.GetPosition())
+	})
+	return res
+}
+
+// setPositions нумерует изображения подряд с нуля в порядке images
+func setPositions(t *memdb.Tables, images []*models.Image) error {
+	for i, img := range images {
+		if img.GetPosition() == i {
+			continue
+		}
+		moved, err := withPosition(img, i)
+		if err != nil {
+			return err
+		}
+		t.Images[moved.GetID()] = moved
+	}
+	return nil
+}
+
+func ownerExists(t *memdb.Tables, owner models.ImageOwner, ownerID uuid.UUID) bool {
+	switch owner {
+	case models.ImageOwnerProduct:
+		_, ok := t.Products[ownerID]
+		return ok
+	case models.ImageOwnerPost:
+		_, ok := t.Posts[ownerID]
+		return ok
+	default:
+		return false
+	}
+}
+
+// ownerShopID - магазин записи, uuid.Nil - если записи нет
+func ownerShopID(t *memdb.Tables, owner models.ImageOwner, ownerID uuid.UUID) uuid.UUID {
+	switch owner {
+	case models.ImageOwnerProduct:
+		if product, ok := t.Products[ownerID]; ok {
+			return product.GetShopID()
+		}
+	case models.ImageOwnerPost:
+		if post, ok := t.Posts[ownerID]; ok {
+			return post.GetShopID()
+		}
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,134 @@
+package imagerep_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemImageRepSuite struct {
+	suite.Suite
+	imageRep   imagerep.ImageRep
+	productRep productrep.ProductRep
+	product    *models.Product
+}
+
+func TestMemImageRep(t *testing.T) {
+	suite.RunSuite(t, new(MemImageRepSuite))
+}
+
+func (s *MemImageRepSuite) BeforeEach(t provider.T) {
+	ctx := context.Background()
+	db := memdb.NewDB()
+	s.imageRep = imagerep.NewMemImageRep(db)
+	s.productRep = productrep.NewMemProductRep(db)
+
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userrep.NewMemUserRep(db).Add(ctx, user))
+	shop, err := models.NewShop(uuid.New(), "Звезды", "", user.GetID(), nil)
+	t.Require().NoError(err)
+	t.Require().NoError(shoprep.NewMemShopRep(db).Add(ctx, shop))
+	product, err := models.NewProduct(uuid.New(), "Серьги", "", 1000, shop.GetID(), uuid.UUIDs{})
+	t.Require().NoError(err)
+	t.Require().NoError(s.productRep.Add(ctx, product))
+	s.product = product
+}
+
+func (s *MemImageRepSuite) addImages(t provider.T, n int) uuid.UUIDs {
+	ids := make(uuid.UUIDs, 0, n)
+	for range n {
+		added, err := s.imageRep.Add(context.Background(), testobj.NewImageMother().ImageOfProductP(s.product.GetID()))
+		t.Require().NoError(err)
+		ids = append(ids, added.GetID())
+	}
+	return ids
+}
+
+func (s *MemImageRepSuite) positions(t provider.T) uuid.UUIDs {
+	images, err := s.imageRep.GetByOwners(context.Background(), models.ImageOwnerProduct, uuid.UUIDs{s.product.GetID()})
+	t.Require().NoError(err)
+	ids := make(uuid.UUIDs, 0)
+	for i, img := range images[s.product.GetID()] {
+		t.Require().Equal(i, img.GetPosition())
+		ids = append(ids, img.GetID())
+	}
+	return ids
+}
+
+func (s *MemImageRepSuite) TestMemImageRep_Order(t provider.T) {
+	ctx := context.Background()
+	ids := s.addImages(t, 3)
+
+	t.WithNewStep("images are appended in order", func(sCtx provider.StepCtx) {
+		sCtx.Assert().Equal(ids, s.positions(t))
+	})
+	t.WithNewStep("reorder", func(sCtx provider.StepCtx) {
+		order := uuid.UUIDs{ids[2], ids[0], ids[1]}
+
+		sCtx.Require().NoError(s.imageRep.Reorder(ctx, models.ImageOwnerProduct, s.product.GetID(), order))
+
+		sCtx.Assert().Equal(order, s.positions(t))
+	})
+	t.WithNewStep("reorder must list every image once", func(sCtx provider.StepCtx) {
+		for _, order := range []uuid.UUIDs{{ids[0], ids[1]}, {ids[0], ids[0], ids[1]}, {ids[0], ids[1], uuid.New()}} {
+			err := s.imageRep.Reorder(ctx, models.ImageOwnerProduct, s.product.GetID(), order)
+
+			sCtx.Assert().ErrorIs(err, imagerep.ErrInvalidOrder)
+		}
+	})
+	t.WithNewStep("delete closes the gap", func(sCtx provider.StepCtx) {
+		deleted, err := s.imageRep.Delete(ctx, models.ImageOwnerProduct, s.product.GetID(), ids[0])
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(ids[0], deleted.GetID())
+		sCtx.Assert().Equal(uuid.UUIDs{ids[2], ids[1]}, s.positions(t))
+	})
+	t.WithNewStep("delete image of another product", func(sCtx provider.StepCtx) {
+		_, err := s.imageRep.Delete(ctx, models.ImageOwnerProduct, uuid.New(), ids[1])
+
+		sCtx.Assert().ErrorIs(err, imagerep.ErrImageNotFound)
+	})
+	t.WithNewStep("images of the shop", func(sCtx provider.StepCtx) {
+		images, err := s.imageRep.GetByShop(ctx, s.product.GetShopID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(images, 2)
+
+		images, err = s.imageRep.GetByShop(ctx, uuid.New())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(images)
+	})
+}
+
+func (s *MemImageRepSuite) TestMemImageRep_Limits(t provider.T) {
+	ctx := context.Background()
+
+	t.WithNewStep("product not found", func(sCtx provider.StepCtx) {
+		_, err := s.imageRep.Add(ctx, testobj.NewImageMother().ImageOfProductP(uuid.New()))
+
+		sCtx.Assert().ErrorIs(err, imagerep.ErrOwnerNotFound)
+	})
+	t.WithNewStep("too many images", func(sCtx provider.StepCtx) {
+		s.addImages(t, models.MaxImagesPerOwner)
+
+		_, err := s.imageRep.Add(ctx, testobj.NewImageMother().ImageOfProductP(s.product.GetID()))
+
+		sCtx.Assert().ErrorIs(err, imagerep.ErrTooManyImages)
+	})
+	t.WithNewStep("images are deleted with the product", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.productRep.Delete(ctx, s.product.GetID()))
+
+		images, err := s.imageRep.GetByOwners(ctx, models.ImageOwnerProduct, uuid.UUIDs{s.product.GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(images)
+	})
+}
@@ -0,0 +1,50 @@
+package imagerep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImageRep struct {
+	mock.Mock
+}
+
+func (m *MockImageRep) GetByOwners(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]*models.Image, error) {
+	args := m.Called(ctx, owner, ownerIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]*models.Image), args.Error(1)
+}
+
+func (m *MockImageRep) GetByShop(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error) {
+	args := m.Called(ctx, shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Image), args.Error(1)
+}
+
+func (m *MockImageRep) Add(ctx context.Context, image *models.Image) (*models.Image, error) {
+	args := m.Called(ctx, image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Image), args.Error(1)
+}
+
+func (m *MockImageRep) Delete(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) (*models.Image, error) {
+	args := m.Called(ctx, owner, ownerID, imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Image), args.Error(1)
+}
+
+func (m *MockImageRep) Reorder(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageIDs uuid.UUIDs) error {
+	args := m.Called(ctx, owner, ownerID, imageIDs)
+	return args.Error(0)
+}
@@ -0,0 +1,286 @@
+package imagerep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// imageTable - таблица изображений одного вида записей
+type imageTable struct {
+	name        string
+	ownerColumn string
+	ownerTable  string
+}
+
+var imageTables = map[models.ImageOwner]imageTable{
+	models.ImageOwnerProduct: {name: "product_images", ownerColumn: "product_id", ownerTable: "products"},
+	models.ImageOwnerPost:    {name: "post_images", ownerColumn: "post_id", ownerTable: "posts"},
+}
+
+func (t imageTable) columns() []string {
+	return []string{"id", t.ownerColumn, "position", "storage_key", "thumbnail_key", "content_type", "width", "height"}
+}
+
+func tableOf(owner models.ImageOwner) (imageTable, error) {
+	table, ok := imageTables[owner]
+	if !ok {
+		return imageTable{}, fmt.Errorf("%w: unknown owner %q", ErrImageRep, owner)
+	}
+	return table, nil
+}
+
+func NewPgImageRep(pool *pgxpool.Pool) ImageRep {
+	return &pgImageRep{
+		pool: pool,
+	}
+}
+
+type pgImageRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgImageRep) GetByOwners(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]*models.Image, error) {
+	res := make(map[uuid.UUID][]*models.Image)
+	if len(ownerIDs) == 0 {
+		return res, nil
+	}
+	table, err := tableOf(owner)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := pgdb.Psql.Select(table.columns()...).
+		From(table.name).
+		Where(sq.Eq{table.ownerColumn: ownerIDs.Strings()}).
+		OrderBy(table.ownerColumn, "position").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		image, err := scanImage(rows, owner)
+		if err != nil {
+			return nil, err
+		}
+		res[image.GetOwnerID()] = append(res[image.GetOwnerID()], image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgImageRep) GetByShop(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error) {
+	res := make([]*models.Image, 0)
+	for _, owner := range []models.ImageOwner{models.ImageOwnerProduct, models.ImageOwnerPost} {
+		table := imageTables[owner]
+		columns := make([]string, 0, len(table.columns()))
+		for _, column := range table.columns() {
+			columns = append(columns, "i."+column)
+		}
+		query, args, err := pgdb.Psql.Select(columns...).
+			From(table.name + " i").
+			Join(table.ownerTable + " o ON o.id = i." + table.ownerColumn).
+			Where(sq.Eq{"o.shop_id": shopID}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+		}
+		rows, err := r.pool.Query(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+		}
+		images, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.Image, error) {
+			return scanImage(row, owner)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+		}
+		res = append(res, images...)
+	}
+	return res, nil
+}
+
+func (r *pgImageRep) Add(ctx context.Context, image *models.Image) (*models.Image, error) {
+	table, err := tableOf(image.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	var res *models.Image
+	err = r.inOwnerTx(ctx, table, image.GetOwnerID(), func(tx pgx.Tx) error {
+		countQuery, countArgs, err := pgdb.Psql.Select("COUNT(*)").
+			From(table.name).
+			Where(sq.Eq{table.ownerColumn: image.GetOwnerID()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		var count int
+		if err := tx.QueryRow(ctx, countQuery, countArgs...).Scan(&count); err != nil {
+			return err
+		}
+		if count >= models.MaxImagesPerOwner {
+			return ErrTooManyImages
+		}
+		added, err := withPosition(image, count)
+		if err != nil {
+			return err
+		}
+		query, args, err := pgdb.Psql.Insert(table.name).
+			Columns(table.columns()...).
+			Values(
+				added.GetID(), added.GetOwnerID(), added.GetPosition(), added.GetKey(),
+				added.GetThumbnailKey(), added.GetContentType(), added.GetWidth(), added.GetHeight(),
+			).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+		res = added
+		return nil
+	})
+	return res, err
+}
+
+func (r *pgImageRep) Delete(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) (*models.Image, error) {
+	table, err := tableOf(owner)
+	if err != nil {
+		return nil, err
+	}
+	var res *models.Image
+	err = r.inOwnerTx(ctx, table, ownerID, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Delete(table.name).
+			Where(sq.Eq{"id": imageID, table.ownerColumn: ownerID}).
+			Suffix("RETURNING " + strings.Join(table.columns(), ", ")).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		image, err := scanImage(tx.QueryRow(ctx, query, args...), owner)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrImageNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// уникальность (запись, позиция) проверяется в конце транзакции, сдвиг не конфликтует
+		shiftQuery, shiftArgs, err := pgdb.Psql.Update(table.name).
+			Set("position", sq.Expr("position - 1")).
+			Where(sq.Eq{table.ownerColumn: ownerID}).
+			Where(sq.Gt{"position": image.GetPosition()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, shiftQuery, shiftArgs...); err != nil {
+			return err
+		}
+		res = image
+		return nil
+	})
+	return res, err
+}
+
+func (r *pgImageRep) Reorder(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageIDs uuid.UUIDs) error {
+	table, err := tableOf(owner)
+	if err != nil {
+		return err
+	}
+	return r.inOwnerTx(ctx, table, ownerID, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Select(table.columns()...).
+			From(table.name).
+			Where(sq.Eq{table.ownerColumn: ownerID}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		images, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.Image, error) {
+			return scanImage(row, owner)
+		})
+		if err != nil {
+			return err
+		}
+		if !sameSet(images, imageIDs) {
+			return ErrInvalidOrder
+		}
+		_, err = tx.Exec(ctx,
+			"UPDATE "+table.name+" AS i SET position = o.ord - 1 "+
+				"FROM unnest($1::uuid[]) WITH ORDINALITY AS o(id, ord) "+
+				"WHERE i.id = o.id AND i."+table.ownerColumn+" = $2",
+			imageIDs.Strings(), ownerID,
+		)
+		return err
+	})
+}
+
+// inOwnerTx выполняет fn в транзакции, заблокировав строку записи-владельца:
+// изменения одного списка изображений идут по очереди и позиции не расходятся
+func (r *pgImageRep) inOwnerTx(ctx context.Context, table imageTable, ownerID uuid.UUID, fn func(tx pgx.Tx) error) error {
+	lockQuery, lockArgs, err := pgdb.Psql.Select("id").
+		From(table.ownerTable).
+		Where(sq.Eq{"id": ownerID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var id uuid.UUID
+		if err := tx.QueryRow(ctx, lockQuery, lockArgs...).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOwnerNotFound
+			}
+			return err
+		}
+		return fn(tx)
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrOwnerNotFound), errors.Is(err, ErrImageNotFound),
+		errors.Is(err, ErrTooManyImages), errors.Is(err, ErrInvalidOrder):
+		return err
+	default:
+		return fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+}
+
+func scanImage(row pgx.Row, owner models.ImageOwner) (*models.Image, error) {
+	var (
+		id, ownerID                    uuid.UUID
+		position, width, height        int
+		key, thumbnailKey, contentType string
+	)
+	if err := row.Scan(&id, &ownerID, &position, &key, &thumbnailKey, &contentType, &width, &height); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	image, err := models.NewImage(id, owner, ownerID, position, key, thumbnailKey, contentType, width, height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageRep, err)
+	}
+	return image, nil
+}
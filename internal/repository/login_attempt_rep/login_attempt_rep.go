@@ -0,0 +1,36 @@
+package loginattemptrep
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+)
+
+// LoginAttemptRep - счетчики неудачных попыток входа по логину и по IP-адресу и журнал блокировок
+type LoginAttemptRep interface {
+	// Get возвращает счетчик, для логина или адреса без неудач - нулевой
+	Get(ctx context.Context, scope models.LoginScope, subject string) (Counter, error)
+	// AddFailure атомарно добавляет неудачу в момент now. Если с прошлой неудачи прошло больше window,
+	// счет начинается заново. Возвращает счетчик после изменения.
+	AddFailure(ctx context.Context, scope models.LoginScope, subject string, now time.Time, window time.Duration) (Counter, error)
+	// Block запрещает попытки до until, уже назначенный более поздний срок не сокращается
+	Block(ctx context.Context, scope models.LoginScope, subject string, until time.Time) error
+	// Reset забывает неудачи после успешного входа
+	Reset(ctx context.Context, scope models.LoginScope, subject string) error
+	AddLockout(ctx context.Context, lockout *models.LoginLockout) error
+	// Lockouts - последние limit записей журнала блокировок, новые первыми
+	Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error)
+}
+
+// Counter - неудачные попытки входа подряд
+type Counter struct {
+	Failures     int
+	LastFailure  time.Time
+	BlockedUntil time.Time // до этого момента попытки запрещены, нулевое - не запрещены
+}
+
+var (
+	ErrLoginAttemptRep = errors.New("LoginAttemptRep")
+)
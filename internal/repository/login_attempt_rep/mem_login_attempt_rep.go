@@ -0,0 +1,99 @@
+package loginattemptrep
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+)
+
+func NewMemLoginAttemptRep(db *memdb.DB) LoginAttemptRep {
+	return &memLoginAttemptRep{
+		db: db,
+	}
+}
+
+type memLoginAttemptRep struct {
+	db *memdb.DB
+}
+
+func (r *memLoginAttemptRep) Get(ctx context.Context, scope models.LoginScope, subject string) (Counter, error) {
+	var res Counter
+	err := r.db.Read(func(t *memdb.Tables) error {
+		res = Counter(t.LoginAttempts[memdb.LoginAttemptKey{Scope: scope, Subject: subject}])
+		return nil
+	})
+	return res, err
+}
+
+func (r *memLoginAttemptRep) AddFailure(
+	ctx context.Context, scope models.LoginScope, subject string, now time.Time, window time.Duration,
+) (Counter, error) {
+	var res Counter
+	err := r.db.Write(func(t *memdb.Tables) error {
+		// счетчики без блокировки, у которых истекло окно, больше не нужны
+		forgetBefore := now.Add(-window)
+		for key, attempts := range t.LoginAttempts {
+			if attempts.LastFailure.Before(forgetBefore) && attempts.BlockedUntil.Before(now) {
+				delete(t.LoginAttempts, key)
+			}
+		}
+		key := memdb.LoginAttemptKey{Scope: scope, Subject: subject}
+		attempts := t.LoginAttempts[key]
+		if attempts.LastFailure.Before(forgetBefore) {
+			attempts.Failures = 0
+		}
+		attempts.Failures++
+		attempts.LastFailure = now
+		t.LoginAttempts[key] = attempts
+		res = Counter(attempts)
+		return nil
+	})
+	return res, err
+}
+
+func (r *memLoginAttemptRep) Block(ctx context.Context, scope models.LoginScope, subject string, until time.Time) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		key := memdb.LoginAttemptKey{Scope: scope, Subject: subject}
+		attempts, ok := t.LoginAttempts[key]
+		if ok && attempts.BlockedUntil.Before(until) {
+			attempts.BlockedUntil = until
+			t.LoginAttempts[key] = attempts
+		}
+		return nil
+	})
+}
+
+func (r *memLoginAttemptRep) Reset(ctx context.Context, scope models.LoginScope, subject string) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		delete(t.LoginAttempts, memdb.LoginAttemptKey{Scope: scope, Subject: subject})
+		return nil
+	})
+}
+
+func (r *memLoginAttemptRep) AddLockout(ctx context.Context, lockout *models.LoginLockout) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		t.LoginLockouts[lockout.GetID()] = lockout
+		return nil
+	})
+}
+
+func (r *memLoginAttemptRep) Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error) {
+	var res []*models.LoginLockout
+	err := r.db.Read(func(t *memdb.Tables) error {
+		res = make([]*models.LoginLockout, 0, len(t.LoginLockouts))
+		for _, lockout := range t.LoginLockouts {
+			res = append(res, lockout)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(res, func(a, b *models.LoginLockout) int {
+		return b.GetCreatedAt().Compare(a.GetCreatedAt())
+	})
+	return res[:min(limit, len(res))], nil
+}
@@ -0,0 +1,62 @@
+package loginattemptrep_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemLoginAttemptRepSuite struct {
+	suite.Suite
+}
+
+func TestMemLoginAttemptRep(t *testing.T) {
+	suite.RunSuite(t, new(MemLoginAttemptRepSuite))
+}
+
+func (s *MemLoginAttemptRepSuite) TestMemLoginAttemptRep_AddFailure(t provider.T) {
+	t.WithNewStep("failures are counted per scope and restart after the window", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB())
+		now := time.Now()
+
+		_, err := rep.AddFailure(ctx, models.LoginScopeLogin, "anna", now, time.Hour)
+		sCtx.Require().NoError(err)
+		counter, err := rep.AddFailure(ctx, models.LoginScopeLogin, "anna", now.Add(time.Minute), time.Hour)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(2, counter.Failures)
+
+		other, err := rep.Get(ctx, models.LoginScopeIP, "anna")
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Zero(other.Failures)
+
+		counter, err = rep.AddFailure(ctx, models.LoginScopeLogin, "anna", now.Add(2*time.Hour), time.Hour)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(1, counter.Failures)
+	})
+	t.WithNewStep("block does not shorten a later block", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB())
+		now := time.Now()
+		_, err := rep.AddFailure(ctx, models.LoginScopeIP, "10.0.0.1", now, time.Hour)
+		sCtx.Require().NoError(err)
+
+		sCtx.Require().NoError(rep.Block(ctx, models.LoginScopeIP, "10.0.0.1", now.Add(time.Hour)))
+		sCtx.Require().NoError(rep.Block(ctx, models.LoginScopeIP, "10.0.0.1", now.Add(time.Minute)))
+
+		counter, err := rep.Get(ctx, models.LoginScopeIP, "10.0.0.1")
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(counter.BlockedUntil.Equal(now.Add(time.Hour)))
+
+		sCtx.Require().NoError(rep.Reset(ctx, models.LoginScopeIP, "10.0.0.1"))
+		counter, err = rep.Get(ctx, models.LoginScopeIP, "10.0.0.1")
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(loginattemptrep.Counter{}, counter)
+	})
+}
@@ -0,0 +1,48 @@
+package loginattemptrep
+
+import (
+	"context"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginAttemptRep struct {
+	mock.Mock
+}
+
+func (m *MockLoginAttemptRep) Get(ctx context.Context, scope models.LoginScope, subject string) (Counter, error) {
+	args := m.Called(ctx, scope, subject)
+	return args.Get(0).(Counter), args.Error(1)
+}
+
+func (m *MockLoginAttemptRep) AddFailure(
+	ctx context.Context, scope models.LoginScope, subject string, now time.Time, window time.Duration,
+) (Counter, error) {
+	args := m.Called(ctx, scope, subject, now, window)
+	return args.Get(0).(Counter), args.Error(1)
+}
+
+func (m *MockLoginAttemptRep) Block(ctx context.Context, scope models.LoginScope, subject string, until time.Time) error {
+	args := m.Called(ctx, scope, subject, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRep) Reset(ctx context.Context, scope models.LoginScope, subject string) error {
+	args := m.Called(ctx, scope, subject)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRep) AddLockout(ctx context.Context, lockout *models.LoginLockout) error {
+	args := m.Called(ctx, lockout)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRep) Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LoginLockout), args.Error(1)
+}
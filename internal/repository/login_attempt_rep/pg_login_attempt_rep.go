@@ -0,0 +1,178 @@
+package loginattemptrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	loginAttemptsTable = "login_attempts"
+	loginLockoutsTable = "login_lockouts"
+)
+
+func NewPgLoginAttemptRep(pool *pgxpool.Pool) LoginAttemptRep {
+	return &pgLoginAttemptRep{
+		pool: pool,
+	}
+}
+
+type pgLoginAttemptRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgLoginAttemptRep) Get(ctx context.Context, scope models.LoginScope, subject string) (Counter, error) {
+	query, args, err := pgdb.Psql.Select("failures", "last_failure", "blocked_until").
+		From(loginAttemptsTable).
+		Where(sq.Eq{"scope": string(scope), "subject": subject}).
+		ToSql()
+	if err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	res, err := scanCounter(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Counter{}, nil
+	} else if err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgLoginAttemptRep) AddFailure(
+	ctx context.Context, scope models.LoginScope, subject string, now time.Time, window time.Duration,
+) (Counter, error) {
+	forgetBefore := now.Add(-window)
+	// счетчики без блокировки, у которых истекло окно, больше не нужны
+	query, args, err := pgdb.Psql.Delete(loginAttemptsTable).
+		Where(sq.Lt{"last_failure": forgetBefore}).
+		Where(sq.Or{sq.Eq{"blocked_until": nil}, sq.Lt{"blocked_until": now}}).
+		ToSql()
+	if err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+
+	query, args, err = pgdb.Psql.Insert(loginAttemptsTable).
+		Columns("scope", "subject", "failures", "last_failure").
+		Values(string(scope), subject, 1, now).
+		Suffix("ON CONFLICT (scope, subject) DO UPDATE SET "+
+			"failures = CASE WHEN "+loginAttemptsTable+".last_failure < ? THEN 1 ELSE "+loginAttemptsTable+".failures + 1 END, "+
+			"last_failure = EXCLUDED.last_failure", forgetBefore).
+		Suffix("RETURNING failures, last_failure, blocked_until").
+		ToSql()
+	if err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	res, err := scanCounter(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return Counter{}, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgLoginAttemptRep) Block(ctx context.Context, scope models.LoginScope, subject string, until time.Time) error {
+	// GREATEST пропускает NULL, поэтому срок без прежней блокировки просто задается
+	query, args, err := pgdb.Psql.Update(loginAttemptsTable).
+		Set("blocked_until", sq.Expr("GREATEST(blocked_until, ?)", until)).
+		Where(sq.Eq{"scope": string(scope), "subject": subject}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return nil
+}
+
+func (r *pgLoginAttemptRep) Reset(ctx context.Context, scope models.LoginScope, subject string) error {
+	query, args, err := pgdb.Psql.Delete(loginAttemptsTable).
+		Where(sq.Eq{"scope": string(scope), "subject": subject}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return nil
+}
+
+func (r *pgLoginAttemptRep) AddLockout(ctx context.Context, lockout *models.LoginLockout) error {
+	query, args, err := pgdb.Psql.Insert(loginLockoutsTable).
+		Columns("id", "scope", "subject", "failures", "locked_until", "created_at").
+		Values(
+			lockout.GetID(), string(lockout.GetScope()), lockout.GetSubject(),
+			lockout.GetFailures(), lockout.GetLockedUntil(), lockout.GetCreatedAt(),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return nil
+}
+
+func (r *pgLoginAttemptRep) Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error) {
+	query, args, err := pgdb.Psql.Select("id", "scope", "subject", "failures", "locked_until", "created_at").
+		From(loginLockoutsTable).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.LoginLockout, 0)
+	for rows.Next() {
+		var (
+			id                     uuid.UUID
+			scope, subject         string
+			failures               int
+			lockedUntil, createdAt time.Time
+		)
+		if err := rows.Scan(&id, &scope, &subject, &failures, &lockedUntil, &createdAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+		}
+		lockout, err := models.NewLoginLockout(id, models.LoginScope(scope), subject, failures, lockedUntil, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+		}
+		res = append(res, lockout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoginAttemptRep, err)
+	}
+	return res, nil
+}
+
+func scanCounter(row pgx.Row) (Counter, error) {
+	var (
+		res          Counter
+		blockedUntil *time.Time
+	)
+	if err := row.Scan(&res.Failures, &res.LastFailure, &blockedUntil); err != nil {
+		return Counter{}, err
+	}
+	if blockedUntil != nil {
+		res.BlockedUntil = *blockedUntil
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package mediastorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewLocalMediaStorage - файлы в каталоге dir, раздаются по адресу baseURL (например, "/media")
+func NewLocalMediaStorage(dir string, baseURL string) (MediaStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	return &localMediaStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+type localMediaStorage struct {
+	dir     string
+	baseURL string
+}
+
+func (s *localMediaStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	// запись во временный файл и переименование: читатель не увидит файл наполовину
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	return nil
+}
+
+func (s *localMediaStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	return nil
+}
+
+func (s *localMediaStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,45 @@
+package mediastorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+// MediaStorage хранит файлы изображений по ключам вида "products/{id}/{file}".
+// Ключи создает сервис, хранилище только проверяет, что они не выходят за его корень.
+type MediaStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete не считает ошибкой отсутствие файла
+	Delete(ctx context.Context, key string) error
+	// URL - публичный адрес файла для ответов API
+	URL(key string) string
+}
+
+var (
+	ErrMediaStorage = errors.New("MediaStorage")
+	ErrInvalidKey   = errors.New("invalid media key")
+)
+
+// NewMediaStorage выбирает реализацию по config.StorageType
+func NewMediaStorage(config cnfg.MediaConfig) (MediaStorage, error) {
+	switch config.StorageType {
+	case cnfg.MediaLocal:
+		return NewLocalMediaStorage(config.LocalDir, config.BaseURL)
+	case cnfg.MediaS3:
+		return NewS3MediaStorage(config, nil)
+	default:
+		return nil, fmt.Errorf("%w: unknown storage %q", ErrMediaStorage, config.StorageType)
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
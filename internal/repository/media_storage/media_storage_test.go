@@ -0,0 +1,129 @@
+package mediastorage_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MediaStorageSuite struct {
+	suite.Suite
+}
+
+func TestMediaStorage(t *testing.T) {
+	suite.RunSuite(t, new(MediaStorageSuite))
+}
+
+func (s *MediaStorageSuite) TestLocalMediaStorage(t provider.T) {
+	dir := t.TempDir()
+	storage, err := mediastorage.NewLocalMediaStorage(dir, "/media/")
+	t.Require().NoError(err)
+	ctx := context.Background()
+
+	t.WithNewStep("put and delete", func(sCtx provider.StepCtx) {
+		key := "products/1/a.png"
+		sCtx.Require().NoError(storage.Put(ctx, key, []byte("png"), "image/png"))
+
+		data, err := os.ReadFile(filepath.Join(dir, "products", "1", "a.png"))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("png", string(data))
+		sCtx.Assert().Equal("/media/products/1/a.png", storage.URL(key))
+
+		sCtx.Require().NoError(storage.Delete(ctx, key))
+		_, err = os.Stat(filepath.Join(dir, "products", "1", "a.png"))
+		sCtx.Assert().True(os.IsNotExist(err))
+		sCtx.Require().NoError(storage.Delete(ctx, key), "deleting a missing file is not an error")
+	})
+	t.WithNewStep("key outside of the storage", func(sCtx provider.StepCtx) {
+		for _, key := range []string{"", "/etc/passwd", "../a.png", "products/../../a.png"} {
+			sCtx.Assert().ErrorIs(storage.Put(ctx, key, []byte("x"), "image/png"), mediastorage.ErrInvalidKey, key)
+		}
+	})
+}
+
+// fakeS3 - минимальная замена S3: хранит объекты в памяти и проверяет подпись запроса по формату
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	hash := sha256.Sum256(body)
+	auth := r.Header.Get("Authorization")
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(hash[:]) ||
+		!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") ||
+		!strings.Contains(auth, "/us-east-1/s3/aws4_request, SignedHeaders=") ||
+		!strings.Contains(auth, "x-amz-date") || !strings.Contains(auth, ", Signature=") {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *MediaStorageSuite) TestS3MediaStorage(t provider.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	config := cnfg.MediaConfig{
+		StorageType: cnfg.MediaS3,
+		S3Endpoint:  server.URL,
+		S3Region:    "us-east-1",
+		S3Bucket:    "craft",
+		S3AccessKey: "access",
+		S3SecretKey: "secret",
+		BaseURL:     "https://cdn.example.com/craft",
+	}
+	ctx := context.Background()
+
+	t.WithNewStep("put and delete", func(sCtx provider.StepCtx) {
+		storage, err := mediastorage.NewS3MediaStorage(config, server.Client())
+		sCtx.Require().NoError(err)
+		key := "posts/1/a.jpg"
+
+		sCtx.Require().NoError(storage.Put(ctx, key, []byte("jpeg"), "image/jpeg"))
+		sCtx.Assert().Equal("jpeg", string(fake.objects["/craft/posts/1/a.jpg"]))
+		sCtx.Assert().Equal("image/jpeg", fake.types["/craft/posts/1/a.jpg"])
+		sCtx.Assert().Equal("https://cdn.example.com/craft/posts/1/a.jpg", storage.URL(key))
+
+		sCtx.Require().NoError(storage.Delete(ctx, key))
+		sCtx.Assert().NotContains(fake.objects, "/craft/posts/1/a.jpg")
+	})
+	t.WithNewStep("error response", func(sCtx provider.StepCtx) {
+		badConfig := config
+		badConfig.S3AccessKey = "other"
+		storage, err := mediastorage.NewS3MediaStorage(badConfig, server.Client())
+		sCtx.Require().NoError(err)
+
+		err = storage.Put(ctx, "posts/1/b.jpg", []byte("jpeg"), "image/jpeg")
+
+		sCtx.Require().ErrorIs(err, mediastorage.ErrMediaStorage)
+		sCtx.Assert().Contains(err.Error(), "status 403")
+	})
+}
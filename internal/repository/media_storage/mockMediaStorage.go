@@ -0,0 +1,26 @@
+package mediastorage
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMediaStorage struct {
+	mock.Mock
+}
+
+func (m *MockMediaStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockMediaStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockMediaStorage) URL(key string) string {
+	args := m.Called(key)
+	return args.String(0)
+}
@@ -0,0 +1,109 @@
+package mediastorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+const s3RequestTimeout = 30 * time.Second
+
+// NewS3MediaStorage - S3-совместимое хранилище (AWS S3, MinIO и т.п.), запросы подписываются AWS Signature V4.
+// client = nil - клиент с таймаутом s3RequestTimeout.
+func NewS3MediaStorage(config cnfg.MediaConfig, client *http.Client) (MediaStorage, error) {
+	if config.S3Endpoint == "" || config.S3Bucket == "" {
+		return nil, fmt.Errorf("%w: S3 endpoint and bucket are required", ErrMediaStorage)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: s3RequestTimeout}
+	}
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = config.S3Endpoint + "/" + config.S3Bucket
+	}
+	return &s3MediaStorage{
+		client:    client,
+		endpoint:  strings.TrimSuffix(config.S3Endpoint, "/"),
+		bucket:    config.S3Bucket,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		signer:    s3Signer{accessKey: config.S3AccessKey, secretKey: config.S3SecretKey, region: config.S3Region},
+		timeNowFn: time.Now,
+	}, nil
+}
+
+type s3MediaStorage struct {
+	client    *http.Client
+	endpoint  string
+	bucket    string
+	baseURL   string
+	signer    s3Signer
+	timeNowFn func() time.Time
+}
+
+func (s *s3MediaStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return s.do(req, key, http.StatusOK)
+}
+
+func (s *s3MediaStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	// S3 отвечает 204 и на удаление отсутствующего объекта, часть совместимых хранилищ - 404
+	return s.do(req, key, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *s3MediaStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *s3MediaStorage) newRequest(ctx context.Context, method string, key string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+"/"+s.bucket+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	req.ContentLength = int64(len(body))
+	return req, nil
+}
+
+func (s *s3MediaStorage) do(req *http.Request, key string, okStatuses ...int) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaStorage, err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	payloadHash := sha256.Sum256(body)
+	s.signer.sign(req, hex.EncodeToString(payloadHash[:]), s.timeNowFn())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s: %w", ErrMediaStorage, req.Method, key, err)
+	}
+	defer resp.Body.Close()
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%w: %s %s: status %d: %s", ErrMediaStorage, req.Method, key, resp.StatusCode, bytes.TrimSpace(msg))
+}
@@ -0,0 +1,262 @@
+package memdb
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+// DB - общее потокобезопасное хранилище для in-memory репозиториев.
+// Повторяет ограничения схемы БД: уникальность логина и каскадное удаление.
+type DB struct {
+	mu         sync.RWMutex
+	users      map[uuid.UUID]*models.User
+	shops      map[uuid.UUID]*models.Shop
+	products   map[uuid.UUID]*models.Product
+	categories map[uuid.UUID]*models.Category
+	posts      map[uuid.UUID]*models.Post
+	images     map[uuid.UUID]*models.Image
+	follows    map[Follow]time.Time
+
+	refreshTokens   map[uuid.UUID]*models.RefreshToken
+	revokedTokens   map[uuid.UUID]time.Time
+	userRevocations map[uuid.UUID]time.Time
+	userTokens      map[uuid.UUID]*models.UserToken
+	userMFA         map[uuid.UUID]*models.UserMFA
+
+	loginAttempts map[LoginAttemptKey]LoginAttempts
+	loginLockouts map[uuid.UUID]*models.LoginLockout
+}
+
+func NewDB() *DB {
+	return &DB{
+		users:      make(map[uuid.UUID]*models.User),
+		shops:      make(map[uuid.UUID]*models.Shop),
+		products:   make(map[uuid.UUID]*models.Product),
+		categories: make(map[uuid.UUID]*models.Category),
+		posts:      make(map[uuid.UUID]*models.Post),
+		images:     make(map[uuid.UUID]*models.Image),
+		follows:    make(map[Follow]time.Time),
+
+		refreshTokens:   make(map[uuid.UUID]*models.RefreshToken),
+		revokedTokens:   make(map[uuid.UUID]time.Time),
+		userRevocations: make(map[uuid.UUID]time.Time),
+		userTokens:      make(map[uuid.UUID]*models.UserToken),
+		userMFA:         make(map[uuid.UUID]*models.UserMFA),
+
+		loginAttempts: make(map[LoginAttemptKey]LoginAttempts),
+		loginLockouts: make(map[uuid.UUID]*models.LoginLockout),
+	}
+}
+
+// Tables - таблицы хранилища, доступные внутри Read/Write
+type Tables struct {
+	Users      map[uuid.UUID]*models.User
+	Shops      map[uuid.UUID]*models.Shop
+	Products   map[uuid.UUID]*models.Product
+	Categories map[uuid.UUID]*models.Category
+	Posts      map[uuid.UUID]*models.Post
+	Images     map[uuid.UUID]*models.Image
+	Follows    map[Follow]time.Time // подписка -> когда оформлена
+
+	RefreshTokens   map[uuid.UUID]*models.RefreshToken
+	RevokedTokens   map[uuid.UUID]time.Time // id токена -> срок его действия
+	UserRevocations map[uuid.UUID]time.Time // id пользователя -> токены, выпущенные раньше, отозваны
+	UserTokens      map[uuid.UUID]*models.UserToken
+	UserMFA         map[uuid.UUID]*models.UserMFA // id пользователя -> второй фактор
+
+	LoginAttempts map[LoginAttemptKey]LoginAttempts
+	LoginLockouts map[uuid.UUID]*models.LoginLockout // журнал блокировок входа
+}
+
+func (db *DB) tables() *Tables {
+	return &Tables{
+		Users:      db.users,
+		Shops:      db.shops,
+		Products:   db.products,
+		Categories: db.categories,
+		Posts:      db.posts,
+		Images:     db.images,
+		Follows:    db.follows,
+
+		RefreshTokens:   db.refreshTokens,
+		RevokedTokens:   db.revokedTokens,
+		UserRevocations: db.userRevocations,
+		UserTokens:      db.userTokens,
+		UserMFA:         db.userMFA,
+
+		LoginAttempts: db.loginAttempts,
+		LoginLockouts: db.loginLockouts,
+	}
+}
+
+// Follow - подписка пользователя на магазин, ключ таблицы Follows
+type Follow struct {
+	UserID uuid.UUID
+	ShopID uuid.UUID
+}
+
+// LoginAttemptKey - логин или адрес, по которому считаются неудачные попытки входа, ключ таблицы LoginAttempts
+type LoginAttemptKey struct {
+	Scope   models.LoginScope
+	Subject string
+}
+
+// LoginAttempts - счетчик неудачных попыток входа подряд
+type LoginAttempts struct {
+	Failures     int
+	LastFailure  time.Time
+	BlockedUntil time.Time
+}
+
+// Read выполняет fn под блокировкой на чтение
+func (db *DB) Read(fn func(t *Tables) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return fn(db.tables())
+}
+
+// Write выполняет fn под эксклюзивной блокировкой
+func (db *DB) Write(fn func(t *Tables) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return fn(db.tables())
+}
+
+// DeleteUser удаляет пользователя вместе с его магазинами и токенами (ON DELETE CASCADE)
+func (t *Tables) DeleteUser(userID uuid.UUID) {
+	delete(t.Users, userID)
+	delete(t.UserRevocations, userID)
+	delete(t.UserMFA, userID)
+	for follow := range t.Follows {
+		if follow.UserID == userID {
+			delete(t.Follows, follow)
+		}
+	}
+	for id, token := range t.RefreshTokens {
+		if token.GetUserID() == userID {
+			delete(t.RefreshTokens, id)
+		}
+	}
+	for id, token := range t.UserTokens {
+		if token.GetUserID() == userID {
+			delete(t.UserTokens, id)
+		}
+	}
+	for id, shop := range t.Shops {
+		if shop.GetUserID() == userID {
+			t.DeleteShop(id)
+		}
+	}
+}
+
+// DeleteShop удаляет магазин вместе с его товарами, постами и подписками (ON DELETE CASCADE)
+func (t *Tables) DeleteShop(shopID uuid.UUID) {
+	delete(t.Shops, shopID)
+	for follow := range t.Follows {
+		if follow.ShopID == shopID {
+			delete(t.Follows, follow)
+		}
+	}
+	for id, product := range t.Products {
+		if product.GetShopID() == shopID {
+			t.DeleteProduct(id)
+		}
+	}
+	for id, post := range t.Posts {
+		if post.GetShopID() == shopID {
+			t.DeletePost(id)
+		}
+	}
+}
+
+// DeleteProduct удаляет товар вместе с записями о его изображениях (ON DELETE CASCADE)
+func (t *Tables) DeleteProduct(productID uuid.UUID) {
+	delete(t.Products, productID)
+	t.deleteImages(models.ImageOwnerProduct, productID)
+}
+
+// DeletePost удаляет пост вместе с записями о его изображениях (ON DELETE CASCADE)
+func (t *Tables) DeletePost(postID uuid.UUID) {
+	delete(t.Posts, postID)
+	t.deleteImages(models.ImageOwnerPost, postID)
+}
+
+func (t *Tables) deleteImages(owner models.ImageOwner, ownerID uuid.UUID) {
+	for id, img := range t.Images {
+		if img.GetOwner() == owner && img.GetOwnerID() == ownerID {
+			delete(t.Images, id)
+		}
+	}
+}
+
+// FollowedShops - магазины, на которые подписан пользователь
+func (t *Tables) FollowedShops(userID uuid.UUID) map[uuid.UUID]struct{} {
+	res := make(map[uuid.UUID]struct{})
+	for follow := range t.Follows {
+		if follow.UserID == userID {
+			res[follow.ShopID] = struct{}{}
+		}
+	}
+	return res
+}
+
+// ShopHasCategory - есть ли у магазина товар указанной категории
+func (t *Tables) ShopHasCategory(shopID uuid.UUID, categoryID uuid.UUID) bool {
+	for _, product := range t.Products {
+		if product.GetShopID() == shopID && slices.Contains(product.GetCategoryIDs(), categoryID) {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryInUse - есть ли товары, ссылающиеся на категорию (ON DELETE RESTRICT)
+func (t *Tables) CategoryInUse(categoryID uuid.UUID) bool {
+	for _, product := range t.Products {
+		if slices.Contains(product.GetCategoryIDs(), categoryID) {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryTree - дерево из всех категорий
+func (t *Tables) CategoryTree() *models.CategoryTree {
+	return models.NewCategoryTree(slices.Collect(maps.Values(t.Categories)))
+}
+
+// DetachCategory убирает категорию из всех товаров, модели неизменяемы и пересоздаются
+func (t *Tables) DetachCategory(categoryID uuid.UUID) error {
+	for id, product := range t.Products {
+		if !slices.Contains(product.GetCategoryIDs(), categoryID) {
+			continue
+		}
+		categoryIDs := slices.DeleteFunc(slices.Clone(product.GetCategoryIDs()), func(cid uuid.UUID) bool {
+			return cid == categoryID
+		})
+		detached, err := models.NewProduct(
+			product.GetID(),
+			product.GetTitle(),
+			product.GetDescription(),
+			product.GetCost(),
+			product.GetShopID(),
+			categoryIDs,
+		)
+		if err != nil {
+			return err
+		}
+		t.Products[id] = detached
+	}
+	return nil
+}
+
+// ContainsFold - регистронезависимый поиск подстроки, аналог ILIKE '%substr%'
+func ContainsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
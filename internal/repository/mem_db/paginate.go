@@ -0,0 +1,76 @@
+package memdb
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+// Sortable - модель, которую можно упорядочить по ключу reqresp.SortByX
+type Sortable interface {
+	GetID() uuid.UUID
+	SortValue(sortBy string) any
+}
+
+// Paginate сортирует items по (ключ, id) и возвращает страницу page,
+// повторяя поведение pgdb.Paginate
+func Paginate[T Sortable](items []T, sortBy string, desc bool, page reqresp.PageOps) ([]T, error) {
+	sign := 1
+	if desc {
+		sign = -1
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		return sign * compareKeys(a.SortValue(sortBy), a.GetID(), b.SortValue(sortBy), b.GetID())
+	})
+
+	start := 0
+	if page.Cursor != "" {
+		cursor, err := pagination.Decode(page.Cursor, sortBy, desc)
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursor.TypedValue()
+		if err != nil {
+			return nil, err
+		}
+		start = len(items)
+		for i, item := range items {
+			if sign*compareKeys(item.SortValue(sortBy), item.GetID(), value, cursor.ID) > 0 {
+				start = i
+				break
+			}
+		}
+	} else {
+		start = min(page.Offset, len(items))
+	}
+	end := len(items)
+	if page.Limit > 0 {
+		end = min(start+page.Limit, end)
+	}
+	return items[start:end], nil
+}
+
+func compareKeys(a any, aID uuid.UUID, b any, bID uuid.UUID) int {
+	if c := compareValues(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(aID.String(), bID.String())
+}
+
+func compareValues(a, b any) int {
+	switch av := a.(type) {
+	case string:
+		return strings.Compare(av, b.(string))
+	case uint64:
+		return cmp.Compare(av, b.(uint64))
+	case time.Time:
+		return av.Compare(b.(time.Time))
+	}
+	panic(fmt.Sprintf("memdb: unsupported sort value %T", a))
+}
@@ -0,0 +1,88 @@
+package mfarep
+
+import (
+	"context"
+	"slices"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemMFARep(db *memdb.DB) MFARep {
+	return &memMFARep{
+		db: db,
+	}
+}
+
+type memMFARep struct {
+	db *memdb.DB
+}
+
+func (r *memMFARep) Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	var res *models.UserMFA
+	err := r.db.Read(func(t *memdb.Tables) error {
+		mfa, ok := t.UserMFA[userID]
+		if !ok {
+			return ErrMFANotFound
+		}
+		res = mfa
+		return nil
+	})
+	return res, err
+}
+
+func (r *memMFARep) Save(ctx context.Context, mfa *models.UserMFA) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[mfa.GetUserID()]; !ok {
+			return ErrUserNotFound
+		}
+		t.UserMFA[mfa.GetUserID()] = mfa
+		return nil
+	})
+}
+
+func (r *memMFARep) Delete(ctx context.Context, userID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		delete(t.UserMFA, userID)
+		return nil
+	})
+}
+
+func (r *memMFARep) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		mfa, ok := t.UserMFA[userID]
+		if !ok {
+			return ErrMFANotFound
+		}
+		if step <= mfa.GetLastStep() {
+			return ErrStepUsed
+		}
+		return r.replace(t, mfa, step, mfa.GetRecoveryHashes())
+	})
+}
+
+func (r *memMFARep) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		mfa, ok := t.UserMFA[userID]
+		if !ok {
+			return ErrMFANotFound
+		}
+		hashes := mfa.GetRecoveryHashes()
+		i := slices.Index(hashes, codeHash)
+		if i < 0 {
+			return ErrRecoveryCodeNotFound
+		}
+		return r.replace(t, mfa, mfa.GetLastStep(), slices.Delete(hashes, i, i+1))
+	})
+}
+
+// replace сохраняет копию mfa с новым шагом и кодами, модели неизменяемы
+func (r *memMFARep) replace(t *memdb.Tables, mfa *models.UserMFA, lastStep int64, recoveryHashes []string) error {
+	updated, err := models.NewUserMFA(mfa.GetUserID(), mfa.GetSecret(), mfa.IsEnabled(), lastStep, recoveryHashes)
+	if err != nil {
+		return err
+	}
+	t.UserMFA[mfa.GetUserID()] = updated
+	return nil
+}
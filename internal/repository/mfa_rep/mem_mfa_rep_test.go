@@ -0,0 +1,84 @@
+package mfarep_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemMFARepSuite struct {
+	suite.Suite
+}
+
+func TestMemMFARep(t *testing.T) {
+	suite.RunSuite(t, new(MemMFARepSuite))
+}
+
+func newMemMFARep(t provider.StepCtx) (mfarep.MFARep, userrep.UserRep, uuid.UUID) {
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userRep.Add(context.Background(), user))
+	rep := mfarep.NewMemMFARep(db)
+	mfa, err := models.NewUserMFA(user.GetID(), "JBSWY3DPEHPK3PXP", true, 10, []string{"hash-1", "hash-2"})
+	t.Require().NoError(err)
+	t.Require().NoError(rep.Save(context.Background(), mfa))
+	return rep, userRep, user.GetID()
+}
+
+func (s *MemMFARepSuite) TestMemMFARep_UseStep(t provider.T) {
+	t.WithNewStep("only later steps are accepted", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep, _, userID := newMemMFARep(sCtx)
+
+		sCtx.Assert().ErrorIs(rep.UseStep(ctx, userID, 10), mfarep.ErrStepUsed)
+		sCtx.Require().NoError(rep.UseStep(ctx, userID, 11))
+		sCtx.Assert().ErrorIs(rep.UseStep(ctx, userID, 11), mfarep.ErrStepUsed)
+
+		mfa, err := rep.Get(ctx, userID)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(int64(11), mfa.GetLastStep())
+		sCtx.Assert().Len(mfa.GetRecoveryHashes(), 2)
+	})
+}
+
+func (s *MemMFARepSuite) TestMemMFARep_UseRecoveryCode(t provider.T) {
+	t.WithNewStep("code is removed after use", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep, _, userID := newMemMFARep(sCtx)
+
+		sCtx.Require().NoError(rep.UseRecoveryCode(ctx, userID, "hash-1"))
+		sCtx.Assert().ErrorIs(rep.UseRecoveryCode(ctx, userID, "hash-1"), mfarep.ErrRecoveryCodeNotFound)
+
+		mfa, err := rep.Get(ctx, userID)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]string{"hash-2"}, mfa.GetRecoveryHashes())
+	})
+}
+
+func (s *MemMFARepSuite) TestMemMFARep_DeleteUser(t provider.T) {
+	t.WithNewStep("mfa is deleted with the user", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep, userRep, userID := newMemMFARep(sCtx)
+
+		sCtx.Require().NoError(userRep.Delete(ctx, userID))
+
+		_, err := rep.Get(ctx, userID)
+		sCtx.Assert().ErrorIs(err, mfarep.ErrMFANotFound)
+	})
+	t.WithNewStep("unknown user", func(sCtx provider.StepCtx) {
+		rep, _, _ := newMemMFARep(sCtx)
+		mfa, err := models.NewUserMFA(uuid.New(), "JBSWY3DPEHPK3PXP", false, 0, nil)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().ErrorIs(rep.Save(context.Background(), mfa), mfarep.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,30 @@
+package mfarep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+// MFARep - второй фактор пользователей
+type MFARep interface {
+	// Get - ErrMFANotFound, если пользователь не начинал настройку
+	Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error)
+	// Save создает или целиком заменяет второй фактор пользователя вместе с кодами восстановления
+	Save(ctx context.Context, mfa *models.UserMFA) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// UseStep атомарно запоминает принятый шаг TOTP, ErrStepUsed - если принят этот или более поздний шаг
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseRecoveryCode атомарно удаляет код восстановления, ErrRecoveryCodeNotFound - если его нет
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+var (
+	ErrMFARep               = errors.New("MFARep")
+	ErrMFANotFound          = errors.New("mfa not found")
+	ErrStepUsed             = errors.New("totp code has already been used")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+	ErrUserNotFound         = errors.New("user of the mfa not found")
+)
@@ -0,0 +1,41 @@
+package mfarep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMFARep struct {
+	mock.Mock
+}
+
+func (m *MockMFARep) Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserMFA), args.Error(1)
+}
+
+func (m *MockMFARep) Save(ctx context.Context, mfa *models.UserMFA) error {
+	args := m.Called(ctx, mfa)
+	return args.Error(0)
+}
+
+func (m *MockMFARep) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockMFARep) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	args := m.Called(ctx, userID, step)
+	return args.Error(0)
+}
+
+func (m *MockMFARep) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Error(0)
+}
@@ -0,0 +1,171 @@
+package mfarep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	userMFATable       = "user_mfa"
+	recoveryCodesTable = "mfa_recovery_codes"
+)
+
+func NewPgMFARep(pool *pgxpool.Pool) MFARep {
+	return &pgMFARep{
+		pool: pool,
+	}
+}
+
+type pgMFARep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgMFARep) Get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	query, args, err := pgdb.Psql.Select("secret", "enabled", "last_step").
+		From(userMFATable).
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	var (
+		secret   string
+		enabled  bool
+		lastStep int64
+	)
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&secret, &enabled, &lastStep)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrMFANotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+
+	query, args, err = pgdb.Psql.Select("code_hash").
+		From(recoveryCodesTable).
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	hashes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+
+	mfa, err := models.NewUserMFA(userID, secret, enabled, lastStep, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	return mfa, nil
+}
+
+func (r *pgMFARep) Save(ctx context.Context, mfa *models.UserMFA) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Insert(userMFATable).
+			Columns("user_id", "secret", "enabled", "last_step").
+			Values(mfa.GetUserID(), mfa.GetSecret(), mfa.IsEnabled(), mfa.GetLastStep()).
+			Suffix("ON CONFLICT (user_id) DO UPDATE SET " +
+				"secret = EXCLUDED.secret, enabled = EXCLUDED.enabled, last_step = EXCLUDED.last_step").
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+
+		query, args, err = pgdb.Psql.Delete(recoveryCodesTable).
+			Where(sq.Eq{"user_id": mfa.GetUserID()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+
+		hashes := mfa.GetRecoveryHashes()
+		if len(hashes) == 0 {
+			return nil
+		}
+		insert := pgdb.Psql.Insert(recoveryCodesTable).Columns("user_id", "code_hash")
+		for _, hash := range hashes {
+			insert = insert.Values(mfa.GetUserID(), hash)
+		}
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	return nil
+}
+
+func (r *pgMFARep) Delete(ctx context.Context, userID uuid.UUID) error {
+	// коды восстановления удаляются каскадно
+	query, args, err := pgdb.Psql.Delete(userMFATable).
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	return nil
+}
+
+func (r *pgMFARep) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	query, args, err := pgdb.Psql.Update(userMFATable).
+		Set("last_step", step).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Lt{"last_step": step}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStepUsed
+	}
+	return nil
+}
+
+func (r *pgMFARep) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query, args, err := pgdb.Psql.Delete(recoveryCodesTable).
+		Where(sq.Eq{"user_id": userID, "code_hash": codeHash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFARep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
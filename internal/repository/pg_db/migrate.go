@@ -0,0 +1,59 @@
+package pgdb
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+var (
+	ErrMigrate = errors.New("migrate")
+)
+
+type MigrationStatus struct {
+	Version uint
+	Applied bool
+}
+
+// NewMigrator создает golang-migrate с миграциями, встроенными в бинарник
+func NewMigrator(config cnfg.DatabaseConfig) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMigrate, err)
+	}
+	// драйвер pgx/v5 зарегистрирован под схемой pgx5://
+	dsn := "pgx5" + strings.TrimPrefix(config.DSN(), "postgres")
+	m, err := migrate.NewWithSourceInstance("iofs", src, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMigrate, err)
+	}
+	return m, nil
+}
+
+// MigrationsStatus возвращает список всех известных миграций с отметкой о применении
+func MigrationsStatus(current uint) ([]MigrationStatus, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMigrate, err)
+	}
+	defer src.Close()
+
+	res := make([]MigrationStatus, 0)
+	version, err := src.First()
+	for err == nil {
+		res = append(res, MigrationStatus{Version: version, Applied: version <= current})
+		version, err = src.Next(version)
+	}
+	// fs.ErrNotExist - миграций больше нет, остальное - ошибка чтения источника
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrMigrate, err)
+	}
+	return res, nil
+}
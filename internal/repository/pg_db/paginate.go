@@ -0,0 +1,37 @@
+package pgdb
+
+import (
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Paginate добавляет к выборке сортировку по (sortColumn, idColumn) и страницу page.
+// Страница по курсору выбирается по ключу сортировки, а не через OFFSET.
+func Paginate(b sq.SelectBuilder, sortBy string, sortColumn string, idColumn string, desc bool, page reqresp.PageOps) (sq.SelectBuilder, error) {
+	dir, cmp := "ASC", ">"
+	if desc {
+		dir, cmp = "DESC", "<"
+	}
+	b = b.OrderBy(sortColumn+" "+dir, idColumn+" "+dir)
+
+	if page.Cursor != "" {
+		cursor, err := pagination.Decode(page.Cursor, sortBy, desc)
+		if err != nil {
+			return b, err
+		}
+		value, err := cursor.TypedValue()
+		if err != nil {
+			return b, err
+		}
+		b = b.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", sortColumn, idColumn, cmp), value, cursor.ID)
+	} else if page.Offset > 0 {
+		b = b.Offset(uint64(page.Offset))
+	}
+	if page.Limit > 0 {
+		b = b.Limit(uint64(page.Limit))
+	}
+	return b, nil
+}
@@ -0,0 +1,72 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+var (
+	ErrConnect = errors.New("failed to connect to postgres")
+)
+
+// Psql - построитель запросов squirrel с плейсхолдерами $1, $2, ...
+var Psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+func NewPool(ctx context.Context, config cnfg.DatabaseConfig) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, config.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnect, err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("%w: %w", ErrConnect, err)
+	}
+	return pool, nil
+}
+
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// IsUniqueViolationOf - нарушено именно ограничение уникальности constraint
+func IsUniqueViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == constraint
+}
+
+func IsForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
+
+// IsForeignKeyViolationOf - нарушен именно внешний ключ constraint
+func IsForeignKeyViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode && pgErr.ConstraintName == constraint
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsPattern - шаблон для (I)LIKE, совпадающий с любой строкой, содержащей substr.
+// Спецсимволы LIKE в substr экранируются.
+func ContainsPattern(substr string) string {
+	return "%" + likeEscaper.Replace(substr) + "%"
+}
+
+// PrefixPattern - шаблон для (I)LIKE, совпадающий со строками, которые начинаются с prefix
+func PrefixPattern(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
@@ -0,0 +1,132 @@
+package postrep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemPostRep(db *memdb.DB) PostRep {
+	return &memPostRep{
+		db: db,
+	}
+}
+
+type memPostRep struct {
+	db *memdb.DB
+}
+
+func (r *memPostRep) GetAll(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error) {
+	res := make([]*models.Post, 0)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		shopIDs := followedScope(t, filterOps)
+		for _, post := range t.Posts {
+			if matchPost(post, filterOps, shopIDs) {
+				res = append(res, post)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortBy, desc, page := postSort(filterOps)
+	res, err = memdb.Paginate(res, sortBy, desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	return res, nil
+}
+
+func (r *memPostRep) Count(ctx context.Context, filterOps *reqresp.PostFilter) (int, error) {
+	count := 0
+	err := r.db.Read(func(t *memdb.Tables) error {
+		shopIDs := followedScope(t, filterOps)
+		for _, post := range t.Posts {
+			if matchPost(post, filterOps, shopIDs) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (r *memPostRep) GetByID(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	var res *models.Post
+	err := r.db.Read(func(t *memdb.Tables) error {
+		post, ok := t.Posts[postID]
+		if !ok {
+			return ErrPostNotFound
+		}
+		res = post
+		return nil
+	})
+	return res, err
+}
+
+func (r *memPostRep) Add(ctx context.Context, post *models.Post) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Posts[post.GetID()]; ok {
+			return ErrPostRep
+		}
+		if _, ok := t.Shops[post.GetShopID()]; !ok {
+			return ErrShopNotFound
+		}
+		t.Posts[post.GetID()] = post
+		return nil
+	})
+}
+
+func (r *memPostRep) Update(ctx context.Context, post *models.Post) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Posts[post.GetID()]; !ok {
+			return ErrPostNotFound
+		}
+		if _, ok := t.Shops[post.GetShopID()]; !ok {
+			return ErrShopNotFound
+		}
+		t.Posts[post.GetID()] = post
+		return nil
+	})
+}
+
+func (r *memPostRep) Delete(ctx context.Context, postID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Posts[postID]; !ok {
+			return ErrPostNotFound
+		}
+		t.DeletePost(postID)
+		return nil
+	})
+}
+
+// followedScope - магазины подписки filterOps.FollowerID, nil - если фильтра по подписке нет
+func followedScope(t *memdb.Tables, filterOps *reqresp.PostFilter) map[uuid.UUID]struct{} {
+	if filterOps == nil || filterOps.FollowerID == uuid.Nil {
+		return nil
+	}
+	return t.FollowedShops(filterOps.FollowerID)
+}
+
+func matchPost(post *models.Post, filterOps *reqresp.PostFilter, followedShopIDs map[uuid.UUID]struct{}) bool {
+	if filterOps == nil {
+		return true
+	}
+	if filterOps.Title != "" && !memdb.ContainsFold(post.GetDescription(), filterOps.Title) {
+		return false
+	}
+	if filterOps.ShopID != uuid.Nil && post.GetShopID() != filterOps.ShopID {
+		return false
+	}
+	if followedShopIDs != nil {
+		if _, ok := followedShopIDs[post.GetShopID()]; !ok {
+			return false
+		}
+	}
+	return true
+}
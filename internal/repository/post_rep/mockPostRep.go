@@ -0,0 +1,50 @@
+package postrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPostRep struct {
+	mock.Mock
+}
+
+func (m *MockPostRep) GetAll(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Post), args.Error(1)
+}
+
+func (m *MockPostRep) Count(ctx context.Context, filterOps *reqresp.PostFilter) (int, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPostRep) GetByID(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostRep) Add(ctx context.Context, post *models.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRep) Update(ctx context.Context, post *models.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRep) Delete(ctx context.Context, postID uuid.UUID) error {
+	args := m.Called(ctx, postID)
+	return args.Error(0)
+}
@@ -0,0 +1,225 @@
+package postrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const postsTable = "posts"
+
+var postColumns = []string{"id", "description", "time_publication", "shop_id"}
+
+func NewPgPostRep(pool *pgxpool.Pool) PostRep {
+	return &pgPostRep{
+		pool: pool,
+	}
+}
+
+type pgPostRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgPostRep) GetAll(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error) {
+	var (
+		builder sq.SelectBuilder
+		err     error
+	)
+	if filterOps != nil && filterOps.FollowerID != uuid.Nil {
+		builder, err = feedPosts(filterOps)
+	} else {
+		sortBy, desc, page := postSort(filterOps)
+		builder, err = pgdb.Paginate(
+			filterPosts(pgdb.Psql.Select(postColumns...).From(postsTable), filterOps),
+			sortBy, "time_publication", "id", desc, page,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Post, 0)
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgPostRep) Count(ctx context.Context, filterOps *reqresp.PostFilter) (int, error) {
+	query, args, err := filterPosts(pgdb.Psql.Select("COUNT(*)").From(postsTable), filterOps).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	return count, nil
+}
+
+func (r *pgPostRep) GetByID(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	query, args, err := pgdb.Psql.Select(postColumns...).
+		From(postsTable).
+		Where(sq.Eq{"id": postID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	post, err := scanPost(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPostNotFound
+	}
+	return post, err
+}
+
+func (r *pgPostRep) Add(ctx context.Context, post *models.Post) error {
+	query, args, err := pgdb.Psql.Insert(postsTable).
+		Columns(postColumns...).
+		Values(post.GetID(), post.GetDescription(), post.GetTimePublication(), post.GetShopID()).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrShopNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	return nil
+}
+
+func (r *pgPostRep) Update(ctx context.Context, post *models.Post) error {
+	query, args, err := pgdb.Psql.Update(postsTable).
+		Set("description", post.GetDescription()).
+		Set("time_publication", post.GetTimePublication()).
+		Set("shop_id", post.GetShopID()).
+		Where(sq.Eq{"id": post.GetID()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrShopNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+func (r *pgPostRep) Delete(ctx context.Context, postID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Delete(postsTable).
+		Where(sq.Eq{"id": postID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+// feedPosts - страница постов магазинов подписки. Для каждого магазина по индексу
+// (shop_id, time_publication, id) берется не больше страницы его постов после курсора,
+// и уже они сливаются и сортируются: работа зависит от числа подписок и размера страницы,
+// а не от числа всех постов этих магазинов.
+func feedPosts(filterOps *reqresp.PostFilter) (sq.SelectBuilder, error) {
+	sortBy, desc, page := postSort(filterOps)
+	perShop := page
+	perShop.Offset = 0
+	if page.Limit > 0 {
+		perShop.Limit = page.Offset + page.Limit
+	}
+	shopFilter := *filterOps
+	shopFilter.FollowerID = uuid.Nil
+	latest, err := pgdb.Paginate(
+		filterPosts(pgdb.Psql.Select(postColumns...).From(postsTable).Where("posts.shop_id = f.shop_id"), &shopFilter),
+		sortBy, "time_publication", "id", desc, perShop,
+	)
+	if err != nil {
+		return latest, err
+	}
+	columns := make([]string, len(postColumns))
+	for i, column := range postColumns {
+		columns[i] = "p." + column
+	}
+	builder := pgdb.Psql.Select(columns...).
+		From("shop_follows f").
+		JoinClause(latest.Prefix("CROSS JOIN LATERAL (").Suffix(") AS p")).
+		Where(sq.Eq{"f.user_id": filterOps.FollowerID})
+	// курсор уже учтен в подзапросе, снаружи остаются порядок, смещение и лимит
+	page.Cursor = ""
+	return pgdb.Paginate(builder, sortBy, "p.time_publication", "p.id", desc, page)
+}
+
+// filterPosts добавляет условия фильтра к выборке из posts
+func filterPosts(builder sq.SelectBuilder, filterOps *reqresp.PostFilter) sq.SelectBuilder {
+	if filterOps == nil {
+		return builder
+	}
+	if filterOps.Title != "" {
+		builder = builder.Where(sq.ILike{"description": pgdb.ContainsPattern(filterOps.Title)})
+	}
+	if filterOps.ShopID != uuid.Nil {
+		builder = builder.Where(sq.Eq{"shop_id": filterOps.ShopID})
+	}
+	if filterOps.FollowerID != uuid.Nil {
+		builder = builder.Where("shop_id IN (SELECT shop_id FROM shop_follows WHERE user_id = ?)", filterOps.FollowerID)
+	}
+	return builder
+}
+
+func scanPost(row pgx.Row) (*models.Post, error) {
+	var (
+		id, shopID      uuid.UUID
+		description     string
+		timePublication time.Time
+	)
+	if err := row.Scan(&id, &description, &timePublication, &shopID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	post, err := models.NewPost(id, description, timePublication.UTC(), shopID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostRep, err)
+	}
+	return post, nil
+}
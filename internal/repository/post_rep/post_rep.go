@@ -0,0 +1,37 @@
+package postrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+type PostRep interface {
+	// GetAll возвращает страницу записей, подходящих под фильтр, в порядке filterOps.SortBy
+	GetAll(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error)
+	// Count - число записей, подходящих под фильтр, без учета страницы
+	Count(ctx context.Context, filterOps *reqresp.PostFilter) (int, error)
+	GetByID(ctx context.Context, postID uuid.UUID) (*models.Post, error)
+	Add(ctx context.Context, post *models.Post) error
+	Update(ctx context.Context, post *models.Post) error
+	Delete(ctx context.Context, postID uuid.UUID) error
+}
+
+// postSort - сортировка и страница фильтра с учетом значений по умолчанию
+func postSort(filterOps *reqresp.PostFilter) (string, bool, reqresp.PageOps) {
+	if filterOps == nil {
+		filterOps = &reqresp.PostFilter{}
+	}
+	sortBy, desc := pagination.Resolve(filterOps.SortBy, filterOps.Order, reqresp.SortByTimePublication, reqresp.OrderDesc)
+	return sortBy, desc, filterOps.Page
+}
+
+var (
+	ErrPostRep      = errors.New("PostRep")
+	ErrPostNotFound = errors.New("post not found")
+	ErrShopNotFound = errors.New("shop of the post not found")
+)
@@ -0,0 +1,172 @@
+package productrep
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemProductRep(db *memdb.DB) ProductRep {
+	return &memProductRep{
+		db: db,
+	}
+}
+
+type memProductRep struct {
+	db *memdb.DB
+}
+
+func (r *memProductRep) GetAll(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error) {
+	res := make([]*models.Product, 0)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		categoryIDs := categoryScope(t, filterOps)
+		for _, product := range t.Products {
+			if matchProduct(product, filterOps, categoryIDs) {
+				res = append(res, product)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortBy, desc, page := productSort(filterOps)
+	res, err = memdb.Paginate(res, sortBy, desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	return res, nil
+}
+
+func (r *memProductRep) Count(ctx context.Context, filterOps *reqresp.ProductFilter) (int, error) {
+	count := 0
+	err := r.db.Read(func(t *memdb.Tables) error {
+		categoryIDs := categoryScope(t, filterOps)
+		for _, product := range t.Products {
+			if matchProduct(product, filterOps, categoryIDs) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (r *memProductRep) GetByID(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	var res *models.Product
+	err := r.db.Read(func(t *memdb.Tables) error {
+		product, ok := t.Products[productID]
+		if !ok {
+			return ErrProductNotFound
+		}
+		res = product
+		return nil
+	})
+	return res, err
+}
+
+func (r *memProductRep) Add(ctx context.Context, product *models.Product) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Products[product.GetID()]; ok {
+			return ErrProductRep
+		}
+		stored, err := normalizeProduct(t, product)
+		if err != nil {
+			return err
+		}
+		t.Products[product.GetID()] = stored
+		return nil
+	})
+}
+
+func (r *memProductRep) Update(ctx context.Context, product *models.Product) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Products[product.GetID()]; !ok {
+			return ErrProductNotFound
+		}
+		stored, err := normalizeProduct(t, product)
+		if err != nil {
+			return err
+		}
+		t.Products[product.GetID()] = stored
+		return nil
+	})
+}
+
+func (r *memProductRep) Delete(ctx context.Context, productID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Products[productID]; !ok {
+			return ErrProductNotFound
+		}
+		t.DeleteProduct(productID)
+		return nil
+	})
+}
+
+// normalizeProduct проверяет внешние ключи и убирает повторы категорий,
+// как это делает таблица product_categories
+func normalizeProduct(t *memdb.Tables, product *models.Product) (*models.Product, error) {
+	if _, ok := t.Shops[product.GetShopID()]; !ok {
+		return nil, ErrInvalidRelation
+	}
+	seen := make(map[uuid.UUID]struct{}, len(product.GetCategoryIDs()))
+	categoryIDs := make(uuid.UUIDs, 0, len(product.GetCategoryIDs()))
+	for _, id := range product.GetCategoryIDs() {
+		if _, ok := t.Categories[id]; !ok {
+			return nil, ErrInvalidRelation
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		categoryIDs = append(categoryIDs, id)
+	}
+	return models.NewProduct(
+		product.GetID(),
+		product.GetTitle(),
+		product.GetDescription(),
+		product.GetCost(),
+		product.GetShopID(),
+		categoryIDs,
+	)
+}
+
+// categoryScope - категории, товары которых подходят под фильтр: CategoryID и, если нужно, ее потомки
+func categoryScope(t *memdb.Tables, filterOps *reqresp.ProductFilter) uuid.UUIDs {
+	if filterOps == nil || filterOps.CategoryID == uuid.Nil {
+		return nil
+	}
+	if !filterOps.IncludeDescendants {
+		return uuid.UUIDs{filterOps.CategoryID}
+	}
+	return t.CategoryTree().Subtree(filterOps.CategoryID)
+}
+
+func matchProduct(product *models.Product, filterOps *reqresp.ProductFilter, categoryIDs uuid.UUIDs) bool {
+	if filterOps == nil {
+		return true
+	}
+	if filterOps.Title != "" && !memdb.ContainsFold(product.GetTitle(), filterOps.Title) {
+		return false
+	}
+	if filterOps.MinCost > 0 && product.GetCost() < filterOps.MinCost {
+		return false
+	}
+	if filterOps.MaxCost > 0 && product.GetCost() > filterOps.MaxCost {
+		return false
+	}
+	if filterOps.ShopID != uuid.Nil && product.GetShopID() != filterOps.ShopID {
+		return false
+	}
+	if filterOps.CategoryID != uuid.Nil && !slices.ContainsFunc(product.GetCategoryIDs(), func(id uuid.UUID) bool {
+		return slices.Contains(categoryIDs, id)
+	}) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package productrep_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemProductRepSuite struct {
+	suite.Suite
+	db          *memdb.DB
+	productRep  productrep.ProductRep
+	shopRep     shoprep.ShopRep
+	categoryRep categoryrep.CategoryRep
+	shop        *models.Shop
+	category    *models.Category
+}
+
+func TestMemProductRep(t *testing.T) {
+	suite.RunSuite(t, new(MemProductRepSuite))
+}
+
+func (s *MemProductRepSuite) BeforeEach(t provider.T) {
+	ctx := context.Background()
+	s.db = memdb.NewDB()
+	s.productRep = productrep.NewMemProductRep(s.db)
+	s.shopRep = shoprep.NewMemShopRep(s.db)
+	s.categoryRep = categoryrep.NewMemCategoryRep(s.db)
+
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userrep.NewMemUserRep(s.db).Add(ctx, user))
+
+	shop, err := models.NewShop(uuid.New(), "Звезды", "", user.GetID(), nil)
+	t.Require().NoError(err)
+	t.Require().NoError(s.shopRep.Add(ctx, shop))
+	s.shop = shop
+
+	category := testobj.NewCategoryMother().CategoryP()
+	t.Require().NoError(s.categoryRep.Add(ctx, category))
+	s.category = category
+}
+
+func (s *MemProductRepSuite) newProduct(t provider.T, title string, categoryIDs uuid.UUIDs) *models.Product {
+	product, err := models.NewProduct(uuid.New(), title, "", 100, s.shop.GetID(), categoryIDs)
+	t.Require().NoError(err)
+	return product
+}
+
+func (s *MemProductRepSuite) TestMemProductRep_Filter(t provider.T) {
+	ctx := context.Background()
+	earrings := s.newProduct(t, "Серьги Звезды", uuid.UUIDs{s.category.GetID()})
+	ring := s.newProduct(t, "Кольцо", nil)
+	t.Require().NoError(s.productRep.Add(ctx, earrings))
+	t.Require().NoError(s.productRep.Add(ctx, ring))
+
+	t.WithNewStep("case insensitive title", func(sCtx provider.StepCtx) {
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{Title: "звезд"})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(res, 1)
+		sCtx.Assert().Equal(earrings.GetID(), res[0].GetID())
+	})
+	t.WithNewStep("category", func(sCtx provider.StepCtx) {
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{CategoryID: s.category.GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(res, 1)
+		sCtx.Assert().Equal(earrings.GetID(), res[0].GetID())
+	})
+	t.WithNewStep("cost range", func(sCtx provider.StepCtx) {
+		cheap, err := models.NewProduct(uuid.New(), "Значок", "", 50, s.shop.GetID(), nil)
+		sCtx.Require().NoError(err)
+		sCtx.Require().NoError(s.productRep.Add(ctx, cheap))
+		defer s.productRep.Delete(ctx, cheap.GetID())
+
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{MaxCost: 60})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(res, 1)
+		sCtx.Assert().Equal(cheap.GetID(), res[0].GetID())
+
+		res, err = s.productRep.GetAll(ctx, &reqresp.ProductFilter{MinCost: 60})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(res, 2)
+
+		res, err = s.productRep.GetAll(ctx, &reqresp.ProductFilter{MinCost: 50, MaxCost: 100})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(res, 3, "bounds are inclusive")
+	})
+	t.WithNewStep("shop", func(sCtx provider.StepCtx) {
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{ShopID: s.shop.GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(res, 2)
+
+		res, err = s.productRep.GetAll(ctx, &reqresp.ProductFilter{ShopID: uuid.New()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(res)
+	})
+}
+
+func (s *MemProductRepSuite) TestMemProductRep_Relations(t provider.T) {
+	ctx := context.Background()
+
+	t.WithNewStep("unknown category", func(sCtx provider.StepCtx) {
+		err := s.productRep.Add(ctx, s.newProduct(t, "Брошь", uuid.UUIDs{uuid.New()}))
+		sCtx.Require().ErrorIs(err, productrep.ErrInvalidRelation)
+	})
+	t.WithNewStep("category in use", func(sCtx provider.StepCtx) {
+		product := s.newProduct(t, "Брошь", uuid.UUIDs{s.category.GetID(), s.category.GetID()})
+		sCtx.Require().NoError(s.productRep.Add(ctx, product))
+
+		stored, err := s.productRep.GetByID(ctx, product.GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(stored.GetCategoryIDs(), 1, "duplicate categories are stored once")
+
+		err = s.categoryRep.Delete(ctx, s.category.GetID())
+		sCtx.Require().ErrorIs(err, categoryrep.ErrCategoryInUse)
+	})
+	t.WithNewStep("shop deletion cascades", func(sCtx provider.StepCtx) {
+		sCtx.Require().NoError(s.shopRep.Delete(ctx, s.shop.GetID()))
+
+		res, err := s.productRep.GetAll(ctx, nil)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(res)
+	})
+}
+
+func (s *MemProductRepSuite) TestMemProductRep_CategoryDescendants(t provider.T) {
+	ctx := context.Background()
+	earrings := testobj.NewCategoryMother().CategoryOfParentP(s.category.GetID())
+	studs := testobj.NewCategoryMother().CategoryOfParentP(earrings.GetID())
+	t.Require().NoError(s.categoryRep.Add(ctx, earrings))
+	t.Require().NoError(s.categoryRep.Add(ctx, studs))
+	t.Require().NoError(s.productRep.Add(ctx, s.newProduct(t, "Кольцо", uuid.UUIDs{s.category.GetID()})))
+	t.Require().NoError(s.productRep.Add(ctx, s.newProduct(t, "Пусеты", uuid.UUIDs{studs.GetID()})))
+
+	t.WithNewStep("only category itself", func(sCtx provider.StepCtx) {
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{CategoryID: s.category.GetID()})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(res, 1)
+	})
+	t.WithNewStep("with descendants", func(sCtx provider.StepCtx) {
+		filterOps := &reqresp.ProductFilter{CategoryID: s.category.GetID(), IncludeDescendants: true}
+
+		res, err := s.productRep.GetAll(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(res, 2)
+
+		count, err := s.productRep.Count(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(2, count)
+	})
+	t.WithNewStep("subtree of a leaf", func(sCtx provider.StepCtx) {
+		res, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{CategoryID: earrings.GetID(), IncludeDescendants: true})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(res, 1)
+		sCtx.Assert().Equal("Пусеты", res[0].GetTitle())
+	})
+}
+
+func (s *MemProductRepSuite) TestMemProductRep_Pagination(t provider.T) {
+	ctx := context.Background()
+	costs := []uint64{300, 100, 200, 200, 500}
+	for i, cost := range costs {
+		product, err := models.NewProduct(uuid.New(), fmt.Sprintf("Товар %d", i), "", cost, s.shop.GetID(), nil)
+		t.Require().NoError(err)
+		t.Require().NoError(s.productRep.Add(ctx, product))
+	}
+
+	t.WithNewStep("cursor walks all pages without gaps", func(sCtx provider.StepCtx) {
+		filterOps := &reqresp.ProductFilter{
+			SortBy: reqresp.SortByCost,
+			Order:  reqresp.OrderDesc,
+			Page:   reqresp.PageOps{Limit: 2},
+		}
+		got := make([]uint64, 0, len(costs))
+		for range costs {
+			page, err := s.productRep.GetAll(ctx, filterOps)
+			sCtx.Require().NoError(err)
+			if len(page) == 0 {
+				break
+			}
+			for _, p := range page {
+				got = append(got, p.GetCost())
+			}
+			last := page[len(page)-1]
+			filterOps.Page.Cursor = pagination.NewCursor(reqresp.SortByCost, true, last.GetCost(), last.GetID()).Encode()
+		}
+		sCtx.Assert().Equal([]uint64{500, 300, 200, 200, 100}, got)
+	})
+	t.WithNewStep("offset and count", func(sCtx provider.StepCtx) {
+		filterOps := &reqresp.ProductFilter{
+			MinCost: 200,
+			SortBy:  reqresp.SortByCost,
+			Page:    reqresp.PageOps{Limit: 2, Offset: 2},
+		}
+		page, err := s.productRep.GetAll(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(page, 2)
+		sCtx.Assert().Equal(uint64(300), page[0].GetCost())
+		sCtx.Assert().Equal(uint64(500), page[1].GetCost())
+
+		count, err := s.productRep.Count(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(4, count)
+	})
+	t.WithNewStep("cursor of another sort order", func(sCtx provider.StepCtx) {
+		cursor := pagination.NewCursor(reqresp.SortByTitle, false, "Товар", uuid.New()).Encode()
+		_, err := s.productRep.GetAll(ctx, &reqresp.ProductFilter{
+			SortBy: reqresp.SortByCost,
+			Page:   reqresp.PageOps{Cursor: cursor},
+		})
+		sCtx.Require().ErrorIs(err, pagination.ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,50 @@
+package productrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductRep struct {
+	mock.Mock
+}
+
+func (m *MockProductRep) GetAll(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRep) Count(ctx context.Context, filterOps *reqresp.ProductFilter) (int, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProductRep) GetByID(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRep) Add(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRep) Update(ctx context.Context, product *models.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRep) Delete(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
@@ -0,0 +1,259 @@
+package productrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	productsTable          = "products"
+	productCategoriesTable = "product_categories"
+)
+
+var productColumns = []string{"id", "title", "description", "cost", "shop_id"}
+
+func NewPgProductRep(pool *pgxpool.Pool) ProductRep {
+	return &pgProductRep{
+		pool: pool,
+	}
+}
+
+type pgProductRep struct {
+	pool *pgxpool.Pool
+}
+
+// selectProducts - выборка товаров вместе с массивом идентификаторов их категорий
+func selectProducts() sq.SelectBuilder {
+	return pgdb.Psql.Select(
+		"p.id", "p.title", "p.description", "p.cost", "p.shop_id",
+		"COALESCE(array_agg(pc.category_id) FILTER (WHERE pc.category_id IS NOT NULL), '{}')",
+	).
+		From(productsTable + " p").
+		LeftJoin(productCategoriesTable + " pc ON pc.product_id = p.id").
+		GroupBy("p.id")
+}
+
+func (r *pgProductRep) GetAll(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error) {
+	builder := filterProducts(selectProducts(), filterOps)
+	sortBy, desc, page := productSort(filterOps)
+	sortColumn := "p.title"
+	if sortBy == reqresp.SortByCost {
+		sortColumn = "p.cost"
+	}
+	builder, err := pgdb.Paginate(builder, sortBy, sortColumn, "p.id", desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Product, 0)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgProductRep) Count(ctx context.Context, filterOps *reqresp.ProductFilter) (int, error) {
+	query, args, err := filterProducts(pgdb.Psql.Select("COUNT(*)").From(productsTable+" p"), filterOps).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	return count, nil
+}
+
+func (r *pgProductRep) GetByID(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	query, args, err := selectProducts().
+		Where(sq.Eq{"p.id": productID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	product, err := scanProduct(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrProductNotFound
+	}
+	return product, err
+}
+
+func (r *pgProductRep) Add(ctx context.Context, product *models.Product) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Insert(productsTable).
+			Columns(productColumns...).
+			Values(product.GetID(), product.GetTitle(), product.GetDescription(), product.GetCost(), product.GetShopID()).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+		return insertProductCategories(ctx, tx, product)
+	})
+}
+
+func (r *pgProductRep) Update(ctx context.Context, product *models.Product) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Update(productsTable).
+			Set("title", product.GetTitle()).
+			Set("description", product.GetDescription()).
+			Set("cost", product.GetCost()).
+			Set("shop_id", product.GetShopID()).
+			Where(sq.Eq{"id": product.GetID()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrProductNotFound
+		}
+
+		query, args, err = pgdb.Psql.Delete(productCategoriesTable).
+			Where(sq.Eq{"product_id": product.GetID()}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+		return insertProductCategories(ctx, tx, product)
+	})
+}
+
+func (r *pgProductRep) Delete(ctx context.Context, productID uuid.UUID) error {
+	// связи с категориями удаляются каскадно
+	query, args, err := pgdb.Psql.Delete(productsTable).
+		Where(sq.Eq{"id": productID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *pgProductRep) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	err := pgx.BeginFunc(ctx, r.pool, fn)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrProductNotFound):
+		return err
+	case pgdb.IsForeignKeyViolation(err):
+		return ErrInvalidRelation
+	default:
+		return fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+}
+
+// filterProducts добавляет условия фильтра к выборке из products p
+func filterProducts(builder sq.SelectBuilder, filterOps *reqresp.ProductFilter) sq.SelectBuilder {
+	if filterOps == nil {
+		return builder
+	}
+	if filterOps.Title != "" {
+		builder = builder.Where(sq.ILike{"p.title": pgdb.ContainsPattern(filterOps.Title)})
+	}
+	if filterOps.MinCost > 0 {
+		builder = builder.Where(sq.GtOrEq{"p.cost": filterOps.MinCost})
+	}
+	if filterOps.MaxCost > 0 {
+		builder = builder.Where(sq.LtOrEq{"p.cost": filterOps.MaxCost})
+	}
+	if filterOps.ShopID != uuid.Nil {
+		builder = builder.Where(sq.Eq{"p.shop_id": filterOps.ShopID})
+	}
+	if filterOps.CategoryID != uuid.Nil && filterOps.IncludeDescendants {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM "+productCategoriesTable+" f WHERE f.product_id = p.id AND f.category_id IN ("+
+				"WITH RECURSIVE subtree AS ("+
+				"SELECT id FROM categories WHERE id = ? "+
+				"UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id"+
+				") SELECT id FROM subtree))",
+			filterOps.CategoryID,
+		)
+	} else if filterOps.CategoryID != uuid.Nil {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM "+productCategoriesTable+" f WHERE f.product_id = p.id AND f.category_id = ?)",
+			filterOps.CategoryID,
+		)
+	}
+	return builder
+}
+
+func insertProductCategories(ctx context.Context, tx pgx.Tx, product *models.Product) error {
+	categoryIDs := product.GetCategoryIDs()
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	builder := pgdb.Psql.Insert(productCategoriesTable).
+		Columns("product_id", "category_id")
+	for _, categoryID := range categoryIDs {
+		builder = builder.Values(product.GetID(), categoryID)
+	}
+	query, args, err := builder.Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+func scanProduct(row pgx.Row) (*models.Product, error) {
+	var (
+		id, shopID         uuid.UUID
+		title, description string
+		cost               int64
+		categoryIDs        []uuid.UUID
+	)
+	if err := row.Scan(&id, &title, &description, &cost, &shopID, &categoryIDs); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	product, err := models.NewProduct(id, title, description, uint64(cost), shopID, categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProductRep, err)
+	}
+	return product, nil
+}
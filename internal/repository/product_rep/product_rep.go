@@ -0,0 +1,38 @@
+package productrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+type ProductRep interface {
+	// GetAll возвращает страницу товаров, подходящих под фильтр, в порядке filterOps.SortBy
+	GetAll(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error)
+	// Count - число товаров, подходящих под фильтр, без учета страницы
+	Count(ctx context.Context, filterOps *reqresp.ProductFilter) (int, error)
+	GetByID(ctx context.Context, productID uuid.UUID) (*models.Product, error)
+	// Add и Update сохраняют товар вместе со списком его категорий
+	Add(ctx context.Context, product *models.Product) error
+	Update(ctx context.Context, product *models.Product) error
+	Delete(ctx context.Context, productID uuid.UUID) error
+}
+
+// productSort - сортировка и страница фильтра с учетом значений по умолчанию
+func productSort(filterOps *reqresp.ProductFilter) (string, bool, reqresp.PageOps) {
+	if filterOps == nil {
+		filterOps = &reqresp.ProductFilter{}
+	}
+	sortBy, desc := pagination.Resolve(filterOps.SortBy, filterOps.Order, reqresp.SortByTitle, reqresp.OrderAsc)
+	return sortBy, desc, filterOps.Page
+}
+
+var (
+	ErrProductRep      = errors.New("ProductRep")
+	ErrProductNotFound = errors.New("product not found")
+	ErrInvalidRelation = errors.New("shop or category of the product not found")
+)
@@ -0,0 +1,116 @@
+package refreshtokenrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemRefreshTokenRep(db *memdb.DB) RefreshTokenRep {
+	return &memRefreshTokenRep{
+		db: db,
+	}
+}
+
+type memRefreshTokenRep struct {
+	db *memdb.DB
+}
+
+func (r *memRefreshTokenRep) Add(ctx context.Context, token *models.RefreshToken) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		return addToken(t, token)
+	})
+}
+
+func (r *memRefreshTokenRep) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var res *models.RefreshToken
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, token := range t.RefreshTokens {
+			if token.GetTokenHash() == tokenHash {
+				res = token
+				return nil
+			}
+		}
+		return ErrRefreshTokenNotFound
+	})
+	return res, err
+}
+
+func (r *memRefreshTokenRep) Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		old, ok := t.RefreshTokens[oldID]
+		if !ok {
+			return ErrRefreshTokenNotFound
+		}
+		if old.IsRotated() || old.IsRevoked() {
+			return ErrAlreadyRotated
+		}
+		rotated, err := withState(old, true, false)
+		if err != nil {
+			return err
+		}
+		if err := addToken(t, next); err != nil {
+			return err
+		}
+		t.RefreshTokens[oldID] = rotated
+		return nil
+	})
+}
+
+func (r *memRefreshTokenRep) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		return revokeWhere(t, func(token *models.RefreshToken) bool {
+			return token.GetFamilyID() == familyID
+		})
+	})
+}
+
+func (r *memRefreshTokenRep) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		return revokeWhere(t, func(token *models.RefreshToken) bool {
+			return token.GetUserID() == userID
+		})
+	})
+}
+
+func revokeWhere(t *memdb.Tables, match func(token *models.RefreshToken) bool) error {
+	for id, token := range t.RefreshTokens {
+		if !match(token) || token.IsRevoked() {
+			continue
+		}
+		revoked, err := withState(token, token.IsRotated(), true)
+		if err != nil {
+			return err
+		}
+		t.RefreshTokens[id] = revoked
+	}
+	return nil
+}
+
+func addToken(t *memdb.Tables, token *models.RefreshToken) error {
+	if _, ok := t.Users[token.GetUserID()]; !ok {
+		return ErrUserNotFound
+	}
+	for id, stored := range t.RefreshTokens {
+		if id == token.GetID() || stored.GetTokenHash() == token.GetTokenHash() {
+			return ErrRefreshTokenRep
+		}
+	}
+	t.RefreshTokens[token.GetID()] = token
+	return nil
+}
+
+// withState - копия токена с новым состоянием, модели неизменяемы
+func withState(token *models.RefreshToken, rotated bool, revoked bool) (*models.RefreshToken, error) {
+	return models.NewRefreshToken(
+		token.GetID(),
+		token.GetFamilyID(),
+		token.GetUserID(),
+		token.GetTokenHash(),
+		token.GetExpiresAt(),
+		rotated,
+		revoked,
+	)
+}
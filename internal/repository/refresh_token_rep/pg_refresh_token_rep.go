@@ -0,0 +1,142 @@
+package refreshtokenrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const refreshTokensTable = "refresh_tokens"
+
+func NewPgRefreshTokenRep(pool *pgxpool.Pool) RefreshTokenRep {
+	return &pgRefreshTokenRep{
+		pool: pool,
+	}
+}
+
+type pgRefreshTokenRep struct {
+	pool *pgxpool.Pool
+}
+
+// execer - общий интерфейс пула и транзакции
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func (r *pgRefreshTokenRep) Add(ctx context.Context, token *models.RefreshToken) error {
+	if err := insertToken(ctx, r.pool, token); err != nil {
+		return mapInsertErr(err)
+	}
+	return nil
+}
+
+func (r *pgRefreshTokenRep) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query, args, err := pgdb.Psql.Select(
+		"id", "family_id", "user_id", "token_hash", "expires_at",
+		"rotated_at IS NOT NULL", "revoked_at IS NOT NULL",
+	).
+		From(refreshTokensTable).
+		Where(sq.Eq{"token_hash": tokenHash}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+	}
+
+	var (
+		id, familyID, userID uuid.UUID
+		hash                 string
+		expiresAt            time.Time
+		rotated, revoked     bool
+	)
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&id, &familyID, &userID, &hash, &expiresAt, &rotated, &revoked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRefreshTokenNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+	}
+	token, err := models.NewRefreshToken(id, familyID, userID, hash, expiresAt.UTC(), rotated, revoked)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+	}
+	return token, nil
+}
+
+func (r *pgRefreshTokenRep) Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		// условие в WHERE не дает двум параллельным запросам обменять один токен
+		query, args, err := pgdb.Psql.Update(refreshTokensTable).
+			Set("rotated_at", sq.Expr("now()")).
+			Where(sq.Eq{"id": oldID, "rotated_at": nil, "revoked_at": nil}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrAlreadyRotated
+		}
+		return insertToken(ctx, tx, next)
+	})
+	if errors.Is(err, ErrAlreadyRotated) {
+		return err
+	}
+	if err != nil {
+		return mapInsertErr(err)
+	}
+	return nil
+}
+
+func (r *pgRefreshTokenRep) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	return r.revokeWhere(ctx, sq.Eq{"family_id": familyID})
+}
+
+func (r *pgRefreshTokenRep) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	return r.revokeWhere(ctx, sq.Eq{"user_id": userID})
+}
+
+func (r *pgRefreshTokenRep) revokeWhere(ctx context.Context, cond sq.Eq) error {
+	query, args, err := pgdb.Psql.Update(refreshTokensTable).
+		Set("revoked_at", sq.Expr("now()")).
+		Where(cond).
+		Where(sq.Eq{"revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+	}
+	return nil
+}
+
+func insertToken(ctx context.Context, db execer, token *models.RefreshToken) error {
+	query, args, err := pgdb.Psql.Insert(refreshTokensTable).
+		Columns("id", "family_id", "user_id", "token_hash", "expires_at").
+		Values(token.GetID(), token.GetFamilyID(), token.GetUserID(), token.GetTokenHash(), token.GetExpiresAt()).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(ctx, query, args...)
+	return err
+}
+
+func mapInsertErr(err error) error {
+	if pgdb.IsForeignKeyViolation(err) {
+		return ErrUserNotFound
+	}
+	return fmt.Errorf("%w: %w", ErrRefreshTokenRep, err)
+}
@@ -0,0 +1,28 @@
+package refreshtokenrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+type RefreshTokenRep interface {
+	Add(ctx context.Context, token *models.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// Rotate атомарно помечает токен oldID обменянным и сохраняет next.
+	// ErrAlreadyRotated - если oldID уже обменян или отозван (в т.ч. параллельным запросом).
+	Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error
+	// RevokeFamily отзывает все токены семейства
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	// RevokeUser отзывает все токены пользователя
+	RevokeUser(ctx context.Context, userID uuid.UUID) error
+}
+
+var (
+	ErrRefreshTokenRep      = errors.New("RefreshTokenRep")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrAlreadyRotated       = errors.New("refresh token already rotated or revoked")
+	ErrUserNotFound         = errors.New("owner of the refresh token not found")
+)
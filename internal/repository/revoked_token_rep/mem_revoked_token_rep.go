@@ -0,0 +1,59 @@
+package revokedtokenrep
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemRevokedTokenRep(db *memdb.DB) RevokedTokenRep {
+	return &memRevokedTokenRep{
+		db: db,
+	}
+}
+
+type memRevokedTokenRep struct {
+	db *memdb.DB
+}
+
+func (r *memRevokedTokenRep) Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		// истекшие токены и так не пройдут проверку, их можно забыть
+		now := time.Now()
+		for id, exp := range t.RevokedTokens {
+			if exp.Before(now) {
+				delete(t.RevokedTokens, id)
+			}
+		}
+		t.RevokedTokens[tokenID] = expiresAt
+		return nil
+	})
+}
+
+func (r *memRevokedTokenRep) RevokeUser(ctx context.Context, userID uuid.UUID, issuedBefore time.Time) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[userID]; !ok {
+			return ErrUserNotFound
+		}
+		if prev, ok := t.UserRevocations[userID]; !ok || prev.Before(issuedBefore) {
+			t.UserRevocations[userID] = issuedBefore
+		}
+		return nil
+	})
+}
+
+func (r *memRevokedTokenRep) IsRevoked(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := r.db.Read(func(t *memdb.Tables) error {
+		if _, ok := t.RevokedTokens[tokenID]; ok {
+			revoked = true
+			return nil
+		}
+		before, ok := t.UserRevocations[userID]
+		revoked = ok && issuedAt.Before(before)
+		return nil
+	})
+	return revoked, err
+}
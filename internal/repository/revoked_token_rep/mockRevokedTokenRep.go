@@ -0,0 +1,28 @@
+package revokedtokenrep
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRevokedTokenRep struct {
+	mock.Mock
+}
+
+func (m *MockRevokedTokenRep) Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockRevokedTokenRep) RevokeUser(ctx context.Context, userID uuid.UUID, issuedBefore time.Time) error {
+	args := m.Called(ctx, userID, issuedBefore)
+	return args.Error(0)
+}
+
+func (m *MockRevokedTokenRep) IsRevoked(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, tokenID, userID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
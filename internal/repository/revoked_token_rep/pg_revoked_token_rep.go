@@ -0,0 +1,95 @@
+package revokedtokenrep
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	revokedTokensTable   = "revoked_tokens"
+	userRevocationsTable = "user_token_revocations"
+)
+
+func NewPgRevokedTokenRep(pool *pgxpool.Pool) RevokedTokenRep {
+	return &pgRevokedTokenRep{
+		pool: pool,
+	}
+}
+
+type pgRevokedTokenRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgRevokedTokenRep) Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	query, args, err := pgdb.Psql.Insert(revokedTokensTable).
+		Columns("token_id", "expires_at").
+		Values(tokenID, expiresAt).
+		Suffix("ON CONFLICT (token_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+
+	// истекшие токены и так не пройдут проверку, их можно забыть
+	query, args, err = pgdb.Psql.Delete(revokedTokensTable).
+		Where("expires_at < now()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	return nil
+}
+
+func (r *pgRevokedTokenRep) RevokeUser(ctx context.Context, userID uuid.UUID, issuedBefore time.Time) error {
+	query, args, err := pgdb.Psql.Insert(userRevocationsTable).
+		Columns("user_id", "revoked_before").
+		Values(userID, issuedBefore).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET revoked_before = GREATEST(" +
+			userRevocationsTable + ".revoked_before, EXCLUDED.revoked_before)").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	return nil
+}
+
+func (r *pgRevokedTokenRep) IsRevoked(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	// подзапросы с плейсхолдерами "?", нумерацию $n расставит внешний запрос
+	byToken := sq.Select("1").
+		From(revokedTokensTable).
+		Where(sq.Eq{"token_id": tokenID})
+	byUser := sq.Select("1").
+		From(userRevocationsTable).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Gt{"revoked_before": issuedAt})
+	query, args, err := pgdb.Psql.Select().
+		Column(sq.Expr("EXISTS(?) OR EXISTS(?)", byToken, byUser)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+
+	var revoked bool
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRevokedTokenRep, err)
+	}
+	return revoked, nil
+}
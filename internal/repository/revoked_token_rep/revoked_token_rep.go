@@ -0,0 +1,25 @@
+package revokedtokenrep
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedTokenRep - denylist токенов доступа.
+// Отдельный токен хранится до истечения его срока, для выхода со всех устройств
+// хранится момент, раньше которого все токены пользователя недействительны.
+type RevokedTokenRep interface {
+	// Revoke отзывает токен tokenID, запись нужна только до expiresAt
+	Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error
+	// RevokeUser отзывает все токены пользователя, выпущенные до issuedBefore
+	RevokeUser(ctx context.Context, userID uuid.UUID, issuedBefore time.Time) error
+	IsRevoked(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, issuedAt time.Time) (bool, error)
+}
+
+var (
+	ErrRevokedTokenRep = errors.New("RevokedTokenRep")
+	ErrUserNotFound    = errors.New("user of the revoked tokens not found")
+)
@@ -0,0 +1,228 @@
+package searchrep
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+// Веса полей повторяют веса ts_rank для меток A (название) и B (описание)
+const (
+	memTitleWeight       = 1.0
+	memDescriptionWeight = 0.4
+	// memMinWordLen - более короткие слова считаются стоп-словами ("и", "со", "на")
+	memMinWordLen = 3
+	// memSnippetWords - сколько слов текста попадает во фрагмент
+	memSnippetWords = 25
+)
+
+// NewMemSearchRep - поиск для хранилища в памяти. Русского словаря здесь нет, поэтому
+// формы слова сравниваются приближенно: по общему началу без последних букв окончания.
+func NewMemSearchRep(db *memdb.DB) SearchRep {
+	return &memSearchRep{
+		db: db,
+	}
+}
+
+type memSearchRep struct {
+	db *memdb.DB
+}
+
+func (r *memSearchRep) Search(ctx context.Context, filterOps *reqresp.SearchFilter) ([]*models.SearchHit, error) {
+	hits, err := r.find(filterOps)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(hits, func(a, b *models.SearchHit) int {
+		if c := cmp.Compare(b.GetRank(), a.GetRank()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetID().String(), b.GetID().String())
+	})
+	start := min(filterOps.Page.Offset, len(hits))
+	end := len(hits)
+	if filterOps.Page.Limit > 0 {
+		end = min(start+filterOps.Page.Limit, end)
+	}
+	return hits[start:end], nil
+}
+
+func (r *memSearchRep) Count(ctx context.Context, filterOps *reqresp.SearchFilter) (int, error) {
+	hits, err := r.find(filterOps)
+	return len(hits), err
+}
+
+// memDoc - запись с текстом, разбитым на поля с весами
+type memDoc struct {
+	kind        models.SearchKind
+	id          uuid.UUID
+	shopID      uuid.UUID
+	title       string
+	fields      []string
+	fieldWeight []float64
+}
+
+func (r *memSearchRep) find(filterOps *reqresp.SearchFilter) ([]*models.SearchHit, error) {
+	terms := make([]string, 0)
+	for _, term := range QueryTerms(filterOps.Query) {
+		if utf8.RuneCountInString(term) >= memMinWordLen {
+			terms = append(terms, term)
+		}
+	}
+	res := make([]*models.SearchHit, 0)
+	if len(terms) == 0 {
+		return res, nil
+	}
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, doc := range memDocs(t, searchKinds(filterOps)) {
+			rank := 0.0
+			for i, field := range doc.fields {
+				rank += doc.fieldWeight[i] * float64(matchedTerms(field, terms))
+			}
+			if rank == 0 {
+				continue
+			}
+			hit, err := models.NewSearchHit(doc.kind, doc.id, doc.shopID, doc.title,
+				renderSnippet(highlight(strings.Join(doc.fields, ". "), terms)), rank)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrSearchRep, err)
+			}
+			res = append(res, hit)
+		}
+		return nil
+	})
+	return res, err
+}
+
+func memDocs(t *memdb.Tables, kinds []models.SearchKind) []memDoc {
+	docs := make([]memDoc, 0)
+	for _, kind := range kinds {
+		switch kind {
+		case models.SearchKindProduct:
+			for _, p := range t.Products {
+				fields, weights := []string{p.GetTitle()}, []float64{memTitleWeight}
+				if p.GetDescription() != "" {
+					fields, weights = append(fields, p.GetDescription()), append(weights, memDescriptionWeight)
+				}
+				docs = append(docs, memDoc{kind, p.GetID(), p.GetShopID(), p.GetTitle(), fields, weights})
+			}
+		case models.SearchKindShop:
+			for _, s := range t.Shops {
+				docs = append(docs, memDoc{kind, s.GetID(), s.GetID(), s.GetTitle(), []string{s.GetTitle()}, []float64{memTitleWeight}})
+			}
+		case models.SearchKindPost:
+			for _, p := range t.Posts {
+				docs = append(docs, memDoc{kind, p.GetID(), p.GetShopID(), "", []string{p.GetDescription()}, []float64{memDescriptionWeight}})
+			}
+		}
+	}
+	return docs
+}
+
+// matchedTerms - сколько слов запроса встречается в тексте
+func matchedTerms(text string, terms []string) int {
+	words := strings.FieldsFunc(strings.ToLower(Normalize(text)), isSeparator)
+	count := 0
+	for _, term := range terms {
+		for _, word := range words {
+			if sameStem(word, term) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// sameStem - приближение стемминга: слова совпадают, если у них общее начало,
+// которое короче более короткого слова не больше чем на две буквы ("звезды" - "звездой")
+func sameStem(word, term string) bool {
+	a, b := []rune(word), []rune(term)
+	if len(a) < memMinWordLen || len(b) < memMinWordLen {
+		return false
+	}
+	common := 0
+	for common < len(a) && common < len(b) && a[common] == b[common] {
+		common++
+	}
+	return common >= max(memMinWordLen, min(len(a), len(b))-2)
+}
+
+// highlight окружает маркерами найденные слова и обрезает текст до memSnippetWords слов
+// вокруг первого совпадения. Как и ts_headline, возвращает текст с ё, замененной на е.
+func highlight(text string, terms []string) string {
+	text = Normalize(text)
+	type span struct{ start, end int }
+	spans := make([]span, 0)
+	start := -1
+	for i, r := range text {
+		if isSeparator(r) {
+			if start >= 0 {
+				spans = append(spans, span{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(text)})
+	}
+	if len(spans) == 0 {
+		return text
+	}
+
+	first := -1
+	marked := make([]bool, len(spans))
+	for i, sp := range spans {
+		word := strings.ToLower(text[sp.start:sp.end])
+		for _, term := range terms {
+			if sameStem(word, term) {
+				marked[i] = true
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+	from := 0
+	if first > memSnippetWords/3 {
+		from = first - memSnippetWords/3
+	}
+	to := min(from+memSnippetWords, len(spans))
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("… ")
+	}
+	pos := spans[from].start
+	for i := from; i < to; i++ {
+		b.WriteString(text[pos:spans[i].start])
+		word := text[spans[i].start:spans[i].end]
+		if marked[i] {
+			word = markStart + word + markStop
+		}
+		b.WriteString(word)
+		pos = spans[i].end
+	}
+	if to < len(spans) {
+		b.WriteString(" …")
+	} else {
+		b.WriteString(text[pos:])
+	}
+	return b.String()
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,169 @@
+package searchrep_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemSearchRepSuite struct {
+	suite.Suite
+	searchRep searchrep.SearchRep
+	shop      *models.Shop
+	earrings  *models.Product
+	star      *models.Product
+	post      *models.Post
+	category  *models.Category
+}
+
+func TestMemSearchRep(t *testing.T) {
+	suite.RunSuite(t, new(MemSearchRepSuite))
+}
+
+func (s *MemSearchRepSuite) BeforeEach(t provider.T) {
+	ctx := context.Background()
+	db := memdb.NewDB()
+	s.searchRep = searchrep.NewMemSearchRep(db)
+
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userrep.NewMemUserRep(db).Add(ctx, user))
+	shop, err := models.NewShop(uuid.New(), "Звёздная мастерская", "", user.GetID(), nil)
+	t.Require().NoError(err)
+	t.Require().NoError(shoprep.NewMemShopRep(db).Add(ctx, shop))
+	s.shop = shop
+
+	productRep := productrep.NewMemProductRep(db)
+	s.earrings, err = models.NewProduct(uuid.New(), "Серёжки со звездой", "Серебро <925>", 1500, shop.GetID(), uuid.UUIDs{})
+	t.Require().NoError(err)
+	t.Require().NoError(productRep.Add(ctx, s.earrings))
+	s.star, err = models.NewProduct(uuid.New(), "Брошь", "Брошь в форме звезды", 900, shop.GetID(), uuid.UUIDs{})
+	t.Require().NoError(err)
+	t.Require().NoError(productRep.Add(ctx, s.star))
+	other, err := models.NewProduct(uuid.New(), "Шарф", "Вязаный", 2000, shop.GetID(), uuid.UUIDs{})
+	t.Require().NoError(err)
+	t.Require().NoError(productRep.Add(ctx, other))
+
+	s.post, err = models.NewPost(uuid.New(), "Новые серьги уже в магазине", time.Now().UTC(), shop.GetID())
+	t.Require().NoError(err)
+	t.Require().NoError(postrep.NewMemPostRep(db).Add(ctx, s.post))
+
+	s.category, err = models.NewCategory(uuid.New(), "Серьги", "", uuid.Nil)
+	t.Require().NoError(err)
+	t.Require().NoError(categoryrep.NewMemCategoryRep(db).Add(ctx, s.category))
+}
+
+func (s *MemSearchRepSuite) TestMemSearchRep_Search(t provider.T) {
+	ctx := context.Background()
+
+	t.WithNewStep("word forms and ё", func(sCtx provider.StepCtx) {
+		filterOps := &reqresp.SearchFilter{Query: "звезды"}
+
+		hits, err := s.searchRep.Search(ctx, filterOps)
+		sCtx.Require().NoError(err)
+		count, err := s.searchRep.Count(ctx, filterOps)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().Equal(3, count)
+		sCtx.Require().Len(hits, 3)
+		ids := []uuid.UUID{hits[0].GetID(), hits[1].GetID(), hits[2].GetID()}
+		sCtx.Assert().ElementsMatch([]uuid.UUID{s.earrings.GetID(), s.shop.GetID(), s.star.GetID()}, ids)
+		sCtx.Assert().Equal(s.star.GetID(), hits[2].GetID(), "match in description ranks below title")
+	})
+	t.WithNewStep("more matched words rank higher", func(sCtx provider.StepCtx) {
+		hits, err := s.searchRep.Search(ctx, &reqresp.SearchFilter{Query: "серебро со звездой"})
+
+		sCtx.Require().NoError(err)
+		sCtx.Require().NotEmpty(hits)
+		sCtx.Assert().Equal(s.earrings.GetID(), hits[0].GetID())
+	})
+	t.WithNewStep("snippet is highlighted and escaped", func(sCtx provider.StepCtx) {
+		hits, err := s.searchRep.Search(ctx, &reqresp.SearchFilter{Query: "серебро", Kind: string(models.SearchKindProduct)})
+
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(hits, 1)
+		sCtx.Assert().Equal("Сережки со звездой. <mark>Серебро</mark> &lt;925&gt;", hits[0].GetSnippet())
+	})
+	t.WithNewStep("kind and page", func(sCtx provider.StepCtx) {
+		hits, err := s.searchRep.Search(ctx, &reqresp.SearchFilter{Query: "серьги", Kind: string(models.SearchKindPost)})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(hits, 1)
+		sCtx.Assert().Equal(s.post.GetID(), hits[0].GetID())
+		sCtx.Assert().Equal(s.shop.GetID(), hits[0].GetShopID())
+
+		page, err := s.searchRep.Search(ctx, &reqresp.SearchFilter{Query: "звезды", Page: reqresp.PageOps{Limit: 1, Offset: 2}})
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(page, 1)
+		sCtx.Assert().Equal(s.star.GetID(), page[0].GetID())
+	})
+	t.WithNewStep("stop words only", func(sCtx provider.StepCtx) {
+		hits, err := s.searchRep.Search(ctx, &reqresp.SearchFilter{Query: "со в"})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(hits)
+	})
+}
+
+func (s *MemSearchRepSuite) TestMemSearchRep_Suggest(t provider.T) {
+	ctx := context.Background()
+	ids := func(suggestions []*models.Suggestion) []uuid.UUID {
+		res := make([]uuid.UUID, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			res = append(res, suggestion.GetID())
+		}
+		return res
+	}
+
+	t.WithNewStep("prefix of title and of a word", func(sCtx provider.StepCtx) {
+		suggestions, err := s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "Звезд", Limit: 5})
+
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(suggestions, 2)
+		sCtx.Assert().Equal(s.shop.GetID(), suggestions[0].GetID())
+		sCtx.Assert().Equal(models.SuggestionShop, suggestions[0].GetKind())
+		sCtx.Assert().Equal(s.earrings.GetID(), suggestions[1].GetID())
+		sCtx.Assert().Equal(models.SuggestionProduct, suggestions[1].GetKind())
+	})
+	t.WithNewStep("typo", func(sCtx provider.StepCtx) {
+		suggestions, err := s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "сережкт", Limit: 5})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]uuid.UUID{s.earrings.GetID()}, ids(suggestions))
+	})
+	t.WithNewStep("closer title first within kind", func(sCtx provider.StepCtx) {
+		suggestions, err := s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "серь", Limit: 5})
+
+		sCtx.Require().NoError(err)
+		sCtx.Require().NotEmpty(suggestions)
+		sCtx.Assert().Equal(s.category.GetID(), suggestions[len(suggestions)-1].GetID())
+		sCtx.Assert().Equal(models.SuggestionCategory, suggestions[len(suggestions)-1].GetKind())
+	})
+	t.WithNewStep("limit per kind", func(sCtx provider.StepCtx) {
+		suggestions, err := s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "брошь", Limit: 1})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]uuid.UUID{s.star.GetID()}, ids(suggestions))
+
+		suggestions, err = s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "с", Limit: 1})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(suggestions, 3, "one of each kind")
+	})
+	t.WithNewStep("nothing similar", func(sCtx provider.StepCtx) {
+		suggestions, err := s.searchRep.Suggest(ctx, &reqresp.SuggestFilter{Query: "абвгд", Limit: 5})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(suggestions)
+	})
+}
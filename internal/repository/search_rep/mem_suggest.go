@@ -0,0 +1,123 @@
+package searchrep
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func (r *memSearchRep) Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) ([]*models.Suggestion, error) {
+	res := make([]*models.Suggestion, 0)
+	text := SuggestText(filterOps.Query)
+	if text == "" || filterOps.Limit <= 0 {
+		return res, nil
+	}
+	type titled struct {
+		id    uuid.UUID
+		title string
+	}
+	byKind := make(map[models.SuggestionKind][]titled)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, s := range t.Shops {
+			byKind[models.SuggestionShop] = append(byKind[models.SuggestionShop], titled{s.GetID(), s.GetTitle()})
+		}
+		for _, p := range t.Products {
+			byKind[models.SuggestionProduct] = append(byKind[models.SuggestionProduct], titled{p.GetID(), p.GetTitle()})
+		}
+		for _, c := range t.Categories {
+			byKind[models.SuggestionCategory] = append(byKind[models.SuggestionCategory], titled{c.GetID(), c.GetTitle()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range []models.SuggestionKind{models.SuggestionShop, models.SuggestionProduct, models.SuggestionCategory} {
+		found := make([]*models.Suggestion, 0)
+		for _, item := range byKind[kind] {
+			score, ok := suggestScore(text, SuggestText(item.title))
+			if !ok {
+				continue
+			}
+			suggestion, err := models.NewSuggestion(kind, item.id, item.title, score)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+			}
+			found = append(found, suggestion)
+		}
+		slices.SortFunc(found, func(a, b *models.Suggestion) int {
+			if c := cmp.Compare(b.GetScore(), a.GetScore()); c != 0 {
+				return c
+			}
+			return strings.Compare(a.GetTitle(), b.GetTitle())
+		})
+		res = append(res, found[:min(filterOps.Limit, len(found))]...)
+	}
+	return res, nil
+}
+
+// suggestScore повторяет условие и оценку запроса подсказок в PostgreSQL;
+// text и name уже приведены через SuggestText
+func suggestScore(text, name string) (float64, bool) {
+	similarity := wordSimilarity(text, name)
+	if !strings.Contains(name, text) && similarity < SuggestThreshold {
+		return 0, false
+	}
+	switch {
+	case strings.HasPrefix(name, text):
+		similarity += suggestPrefixBonus
+	case strings.Contains(name, " "+text):
+		similarity += suggestWordPrefixBonus
+	}
+	return similarity, true
+}
+
+// wordSimilarity - word_similarity из pg_trgm: наибольшая похожесть множества триграмм запроса
+// на непрерывный отрезок последовательности триграмм названия
+func wordSimilarity(text, name string) float64 {
+	query := make(map[string]struct{})
+	for _, trigram := range trigrams(text) {
+		query[trigram] = struct{}{}
+	}
+	if len(query) == 0 {
+		return 0
+	}
+	sequence := trigrams(name)
+	best := 0.0
+	for from := range sequence {
+		extent := make(map[string]struct{})
+		common := 0
+		for _, trigram := range sequence[from:] {
+			if _, ok := extent[trigram]; ok {
+				continue
+			}
+			extent[trigram] = struct{}{}
+			if _, ok := query[trigram]; ok {
+				common++
+			}
+			best = max(best, float64(common)/float64(len(query)+len(extent)-common))
+		}
+	}
+	return best
+}
+
+// trigrams - триграммы слов по порядку так же, как их строит pg_trgm:
+// каждое слово дополняется двумя пробелами в начале и одним в конце
+func trigrams(text string) []string {
+	res := make([]string, 0)
+	for _, word := range strings.FieldsFunc(text, isSeparator) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			res = append(res, string(runes[i:i+3]))
+		}
+	}
+	return res
+}
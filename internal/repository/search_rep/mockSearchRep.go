@@ -0,0 +1,34 @@
+package searchrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchRep struct {
+	mock.Mock
+}
+
+func (m *MockSearchRep) Search(ctx context.Context, filterOps *reqresp.SearchFilter) ([]*models.SearchHit, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SearchHit), args.Error(1)
+}
+
+func (m *MockSearchRep) Count(ctx context.Context, filterOps *reqresp.SearchFilter) (int, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSearchRep) Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) ([]*models.Suggestion, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Suggestion), args.Error(1)
+}
@@ -0,0 +1,213 @@
+package searchrep
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// searchBranches - запросы по таблицам с колонкой search_vector (см. миграцию 000013),
+// все возвращают (kind, id, shop_id, title, doc, rank); doc - текст для фрагмента
+var searchBranches = map[models.SearchKind]string{
+	models.SearchKindProduct: `SELECT 'product'::text AS kind, p.id, p.shop_id, p.title,
+		concat_ws('. ', p.title, NULLIF(p.description, '')) AS doc,
+		ts_rank(p.search_vector, q.query)::float8 AS rank
+		FROM products p, q WHERE p.search_vector @@ q.query`,
+	models.SearchKindShop: `SELECT 'shop'::text AS kind, s.id, s.id AS shop_id, s.title,
+		s.title AS doc,
+		ts_rank(s.search_vector, q.query)::float8 AS rank
+		FROM shops s, q WHERE s.search_vector @@ q.query`,
+	models.SearchKindPost: `SELECT 'post'::text AS kind, po.id, po.shop_id, ''::text AS title,
+		po.description AS doc,
+		ts_rank(po.search_vector, q.query)::float8 AS rank
+		FROM posts po, q WHERE po.search_vector @@ q.query`,
+}
+
+// headlineOptions - параметры ts_headline: до двух фрагментов, найденные слова между маркерами
+var headlineOptions = fmt.Sprintf(
+	`StartSel=%s, StopSel=%s, MaxWords=25, MinWords=8, MaxFragments=2, FragmentDelimiter=" … "`,
+	markStart, markStop,
+)
+
+// suggestTables - таблицы с названиями для подсказок, по порядку видов в ответе
+var suggestTables = []struct {
+	kind  models.SuggestionKind
+	table string
+}{
+	{models.SuggestionShop, "shops"},
+	{models.SuggestionProduct, "products"},
+	{models.SuggestionCategory, "categories"},
+}
+
+// suggestBranch - подсказки одного вида. Название приводится к виду, по которому построен
+// триграммный индекс (см. миграцию 000014): подстрока ищется через LIKE, опечатки - оператором <%.
+var suggestBranch = fmt.Sprintf(`(SELECT '%%s'::text AS kind, t.id, t.title,
+		word_similarity(q.text, t.name)::float8 + CASE
+			WHEN t.name LIKE q.prefix THEN %g::float8
+			WHEN t.name LIKE q.word_prefix THEN %g::float8
+			ELSE 0::float8 END AS score
+		FROM (SELECT id, title, lower(translate(title, 'ёЁ', 'еЕ')) AS name FROM %%s) AS t, q
+		WHERE t.name LIKE q.contains OR q.text <%%%% t.name
+		ORDER BY score DESC, t.title
+		LIMIT ?)`, suggestPrefixBonus, suggestWordPrefixBonus)
+
+func NewPgSearchRep(pool *pgxpool.Pool) SearchRep {
+	return &pgSearchRep{
+		pool: pool,
+	}
+}
+
+type pgSearchRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgSearchRep) Search(ctx context.Context, filterOps *reqresp.SearchFilter) ([]*models.SearchHit, error) {
+	res := make([]*models.SearchHit, 0)
+	tsQuery, ok := tsQueryText(filterOps)
+	if !ok {
+		return res, nil
+	}
+	// порядок аргументов - порядок плейсхолдеров в тексте запроса
+	args := []any{tsQuery, headlineOptions}
+	hits := unionBranches(filterOps) + " ORDER BY rank DESC, id"
+	if filterOps.Page.Limit > 0 {
+		hits += " LIMIT ?"
+		args = append(args, filterOps.Page.Limit)
+	}
+	if filterOps.Page.Offset > 0 {
+		hits += " OFFSET ?"
+		args = append(args, filterOps.Page.Offset)
+	}
+	// фрагменты строятся только для записей страницы: ts_headline заметно дороже поиска по индексу
+	query, err := sq.Dollar.ReplacePlaceholders(`WITH q AS (SELECT to_tsquery('russian', ?) AS query)
+		SELECT hits.kind, hits.id, hits.shop_id, hits.title,
+			ts_headline('russian', translate(hits.doc, 'ёЁ', 'еЕ'), q.query, ?) AS snippet, hits.rank
+		FROM (` + hits + `) AS hits, q
+		ORDER BY hits.rank DESC, hits.id`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			kind, title, snippet string
+			id, shopID           uuid.UUID
+			rank                 float64
+		)
+		if err := rows.Scan(&kind, &id, &shopID, &title, &snippet, &rank); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+		}
+		hit, err := models.NewSearchHit(models.SearchKind(kind), id, shopID, title, renderSnippet(snippet), rank)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+		}
+		res = append(res, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgSearchRep) Count(ctx context.Context, filterOps *reqresp.SearchFilter) (int, error) {
+	tsQuery, ok := tsQueryText(filterOps)
+	if !ok {
+		return 0, nil
+	}
+	query, err := sq.Dollar.ReplacePlaceholders(`WITH q AS (SELECT to_tsquery('russian', ?) AS query)
+		SELECT COUNT(*) FROM (` + unionBranches(filterOps) + `) AS hits`)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+	var count int
+	if err := r.pool.QueryRow(ctx, query, tsQuery).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+	return count, nil
+}
+
+func (r *pgSearchRep) Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) ([]*models.Suggestion, error) {
+	res := make([]*models.Suggestion, 0)
+	text := SuggestText(filterOps.Query)
+	if text == "" || filterOps.Limit <= 0 {
+		return res, nil
+	}
+	args := []any{text, pgdb.ContainsPattern(text), pgdb.PrefixPattern(text), "% " + pgdb.PrefixPattern(text)}
+	branches := make([]string, 0, len(suggestTables))
+	for _, t := range suggestTables {
+		branches = append(branches, fmt.Sprintf(suggestBranch, t.kind, t.table))
+		args = append(args, filterOps.Limit)
+	}
+	query, err := sq.Dollar.ReplacePlaceholders(`WITH q AS (SELECT ?::text AS text, ?::text AS contains,
+			?::text AS prefix, ?::text AS word_prefix)
+		` + strings.Join(branches, " UNION ALL "))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+
+	// порог оператора <% действует только внутри транзакции
+	err = pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)",
+			strconv.FormatFloat(SuggestThreshold, 'f', -1, 64)); err != nil {
+			return err
+		}
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				kind, title string
+				id          uuid.UUID
+				score       float64
+			)
+			if err := rows.Scan(&kind, &id, &title, &score); err != nil {
+				return err
+			}
+			suggestion, err := models.NewSuggestion(models.SuggestionKind(kind), id, title, score)
+			if err != nil {
+				return err
+			}
+			res = append(res, suggestion)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchRep, err)
+	}
+	return res, nil
+}
+
+// tsQueryText - слова запроса через ИЛИ в синтаксисе to_tsquery. Слова состоят только
+// из букв и цифр, поэтому операторы tsquery в них не попадут.
+func tsQueryText(filterOps *reqresp.SearchFilter) (string, bool) {
+	terms := QueryTerms(filterOps.Query)
+	if len(terms) == 0 {
+		return "", false
+	}
+	return strings.Join(terms, " | "), true
+}
+
+func unionBranches(filterOps *reqresp.SearchFilter) string {
+	kinds := searchKinds(filterOps)
+	branches := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		branches = append(branches, searchBranches[kind])
+	}
+	return strings.Join(branches, " UNION ALL ")
+}
@@ -0,0 +1,93 @@
+package searchrep
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+)
+
+// SearchRep - полнотекстовый поиск по названиям и описаниям товаров, названиям магазинов
+// и текстам постов. Слова запроса объединяются через ИЛИ: запись подходит, если в ней есть
+// хотя бы одно слово, а чем больше слов совпало, тем выше ранг.
+type SearchRep interface {
+	// Search возвращает страницу filterOps.Page, самые релевантные записи первыми
+	Search(ctx context.Context, filterOps *reqresp.SearchFilter) ([]*models.SearchHit, error)
+	// Count - число найденных записей без учета страницы
+	Count(ctx context.Context, filterOps *reqresp.SearchFilter) (int, error)
+	// Suggest - подсказки по названиям магазинов, товаров и категорий: не больше filterOps.Limit
+	// каждого вида, внутри вида самые близкие к запросу первыми. Название подходит, если содержит
+	// запрос или похоже на него по триграммам (допускаются опечатки).
+	Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) ([]*models.Suggestion, error)
+}
+
+var (
+	ErrSearchRep = errors.New("SearchRep")
+)
+
+const (
+	// MaxQueryTerms - слова запроса сверх этого числа не учитываются
+	MaxQueryTerms = 10
+	// маркеры найденных слов в сырых фрагментах: символы из области частного использования
+	// не встречаются в обычном тексте и переживают экранирование HTML
+	markStart = "\ue000"
+	markStop  = "\ue001"
+
+	// SuggestThreshold - минимальная триграммная похожесть запроса на слова названия
+	SuggestThreshold = 0.4
+	// бонусы к похожести: название начинается с запроса или одно из слов названия начинается с него
+	suggestPrefixBonus     = 1.0
+	suggestWordPrefixBonus = 0.5
+)
+
+var yoReplacer = strings.NewReplacer("ё", "е", "Ё", "Е")
+
+// Normalize заменяет ё на е: в текстах и запросах буква пишется непоследовательно
+func Normalize(text string) string {
+	return yoReplacer.Replace(text)
+}
+
+// QueryTerms - нормализованные слова запроса в нижнем регистре, без знаков препинания.
+// Из слов состоит tsquery, поэтому в них только буквы и цифры.
+func QueryTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(Normalize(query)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, min(len(words), MaxQueryTerms))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		terms = append(terms, word)
+		if len(terms) == MaxQueryTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// SuggestText - запрос подсказок в том виде, в котором он сравнивается с названиями:
+// нижний регистр, ё заменена на е, пробелы схлопнуты
+func SuggestText(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(Normalize(query))), " ")
+}
+
+// renderSnippet экранирует фрагмент для HTML и заменяет маркеры на <mark>
+func renderSnippet(raw string) string {
+	escaped := html.EscapeString(strings.TrimSpace(raw))
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(escaped)
+}
+
+// searchKinds - виды записей, по которым идет поиск
+func searchKinds(filterOps *reqresp.SearchFilter) []models.SearchKind {
+	if filterOps.Kind != "" {
+		return []models.SearchKind{models.SearchKind(filterOps.Kind)}
+	}
+	return []models.SearchKind{models.SearchKindProduct, models.SearchKindShop, models.SearchKindPost}
+}
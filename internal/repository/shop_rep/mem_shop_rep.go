@@ -0,0 +1,120 @@
+package shoprep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemShopRep(db *memdb.DB) ShopRep {
+	return &memShopRep{
+		db: db,
+	}
+}
+
+type memShopRep struct {
+	db *memdb.DB
+}
+
+func (r *memShopRep) GetAll(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error) {
+	res := make([]*models.Shop, 0)
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, shop := range t.Shops {
+			if matchShop(t, shop, filterOps) {
+				res = append(res, shop)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortBy, desc, page := shopSort(filterOps)
+	res, err = memdb.Paginate(res, sortBy, desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	return res, nil
+}
+
+func (r *memShopRep) Count(ctx context.Context, filterOps *reqresp.ShopFilter) (int, error) {
+	count := 0
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, shop := range t.Shops {
+			if matchShop(t, shop, filterOps) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (r *memShopRep) GetByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error) {
+	var res *models.Shop
+	err := r.db.Read(func(t *memdb.Tables) error {
+		shop, ok := t.Shops[shopID]
+		if !ok {
+			return ErrShopNotFound
+		}
+		res = shop
+		return nil
+	})
+	return res, err
+}
+
+func (r *memShopRep) Add(ctx context.Context, shop *models.Shop) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Shops[shop.GetID()]; ok {
+			return ErrShopRep
+		}
+		if _, ok := t.Users[shop.GetUserID()]; !ok {
+			return ErrUserNotFound
+		}
+		t.Shops[shop.GetID()] = shop
+		return nil
+	})
+}
+
+func (r *memShopRep) Update(ctx context.Context, shop *models.Shop) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Shops[shop.GetID()]; !ok {
+			return ErrShopNotFound
+		}
+		if _, ok := t.Users[shop.GetUserID()]; !ok {
+			return ErrUserNotFound
+		}
+		t.Shops[shop.GetID()] = shop
+		return nil
+	})
+}
+
+func (r *memShopRep) Delete(ctx context.Context, shopID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Shops[shopID]; !ok {
+			return ErrShopNotFound
+		}
+		t.DeleteShop(shopID)
+		return nil
+	})
+}
+
+func matchShop(t *memdb.Tables, shop *models.Shop, filterOps *reqresp.ShopFilter) bool {
+	if filterOps == nil {
+		return true
+	}
+	if filterOps.Title != "" && !memdb.ContainsFold(shop.GetTitle(), filterOps.Title) {
+		return false
+	}
+	if filterOps.UserID != uuid.Nil && shop.GetUserID() != filterOps.UserID {
+		return false
+	}
+	if filterOps.CategoryID != uuid.Nil && !t.ShopHasCategory(shop.GetID(), filterOps.CategoryID) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package shoprep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShopRep struct {
+	mock.Mock
+}
+
+func (m *MockShopRep) GetAll(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error) {
+	args := m.Called(ctx, filterOps)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Shop), args.Error(1)
+}
+
+func (m *MockShopRep) Count(ctx context.Context, filterOps *reqresp.ShopFilter) (int, error) {
+	args := m.Called(ctx, filterOps)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockShopRep) GetByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error) {
+	args := m.Called(ctx, shopID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Shop), args.Error(1)
+}
+
+func (m *MockShopRep) Add(ctx context.Context, shop *models.Shop) error {
+	args := m.Called(ctx, shop)
+	return args.Error(0)
+}
+
+func (m *MockShopRep) Update(ctx context.Context, shop *models.Shop) error {
+	args := m.Called(ctx, shop)
+	return args.Error(0)
+}
+
+func (m *MockShopRep) Delete(ctx context.Context, shopID uuid.UUID) error {
+	args := m.Called(ctx, shopID)
+	return args.Error(0)
+}
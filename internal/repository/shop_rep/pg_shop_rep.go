@@ -0,0 +1,223 @@
+package shoprep
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const shopsTable = "shops"
+
+var shopColumns = []string{"id", "title", "description", "user_id", "contacts"}
+
+// contactRow - элемент JSON-массива shops.contacts
+type contactRow struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func NewPgShopRep(pool *pgxpool.Pool) ShopRep {
+	return &pgShopRep{
+		pool: pool,
+	}
+}
+
+type pgShopRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgShopRep) GetAll(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error) {
+	builder := filterShops(pgdb.Psql.Select(shopColumns...).From(shopsTable), filterOps)
+	sortBy, desc, page := shopSort(filterOps)
+	builder, err := pgdb.Paginate(builder, sortBy, "title", "id", desc, page)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Shop, 0)
+	for rows.Next() {
+		shop, err := scanShop(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, shop)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	return res, nil
+}
+
+func (r *pgShopRep) Count(ctx context.Context, filterOps *reqresp.ShopFilter) (int, error) {
+	query, args, err := filterShops(pgdb.Psql.Select("COUNT(*)").From(shopsTable), filterOps).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	return count, nil
+}
+
+func (r *pgShopRep) GetByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error) {
+	query, args, err := pgdb.Psql.Select(shopColumns...).
+		From(shopsTable).
+		Where(sq.Eq{"id": shopID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	shop, err := scanShop(r.pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrShopNotFound
+	}
+	return shop, err
+}
+
+func (r *pgShopRep) Add(ctx context.Context, shop *models.Shop) error {
+	contacts, err := marshalContacts(shop)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	query, args, err := pgdb.Psql.Insert(shopsTable).
+		Columns(shopColumns...).
+		Values(shop.GetID(), shop.GetTitle(), shop.GetDescription(), shop.GetUserID(), contacts).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	return nil
+}
+
+func (r *pgShopRep) Update(ctx context.Context, shop *models.Shop) error {
+	contacts, err := marshalContacts(shop)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	query, args, err := pgdb.Psql.Update(shopsTable).
+		Set("title", shop.GetTitle()).
+		Set("description", shop.GetDescription()).
+		Set("user_id", shop.GetUserID()).
+		Set("contacts", contacts).
+		Where(sq.Eq{"id": shop.GetID()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShopNotFound
+	}
+	return nil
+}
+
+func (r *pgShopRep) Delete(ctx context.Context, shopID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Delete(shopsTable).
+		Where(sq.Eq{"id": shopID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrShopNotFound
+	}
+	return nil
+}
+
+// filterShops добавляет условия фильтра к выборке из shops
+func filterShops(builder sq.SelectBuilder, filterOps *reqresp.ShopFilter) sq.SelectBuilder {
+	if filterOps == nil {
+		return builder
+	}
+	if filterOps.Title != "" {
+		builder = builder.Where(sq.ILike{"title": pgdb.ContainsPattern(filterOps.Title)})
+	}
+	if filterOps.UserID != uuid.Nil {
+		builder = builder.Where(sq.Eq{"user_id": filterOps.UserID})
+	}
+	if filterOps.CategoryID != uuid.Nil {
+		builder = builder.Where(
+			"EXISTS (SELECT 1 FROM products p JOIN product_categories pc ON pc.product_id = p.id "+
+				"WHERE p.shop_id = shops.id AND pc.category_id = ?)",
+			filterOps.CategoryID,
+		)
+	}
+	return builder
+}
+
+// marshalContacts - контакты магазина в виде JSON для колонки contacts
+func marshalContacts(shop *models.Shop) (string, error) {
+	rows := make([]contactRow, 0, len(shop.GetContacts()))
+	for _, contact := range shop.GetContacts() {
+		rows = append(rows, contactRow{Type: string(contact.GetType()), Value: contact.GetValue()})
+	}
+	data, err := json.Marshal(rows)
+	return string(data), err
+}
+
+func scanShop(row pgx.Row) (*models.Shop, error) {
+	var (
+		id, userID         uuid.UUID
+		title, description string
+		contactsJSON       []byte
+	)
+	if err := row.Scan(&id, &title, &description, &userID, &contactsJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	var rows []contactRow
+	if err := json.Unmarshal(contactsJSON, &rows); err != nil {
+		return nil, fmt.Errorf("%w: contacts: %w", ErrShopRep, err)
+	}
+	contacts := make([]*models.ContactLink, 0, len(rows))
+	for _, row := range rows {
+		contact, err := models.NewContactLink(models.ContactType(row.Type), row.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+		}
+		contacts = append(contacts, contact)
+	}
+	shop, err := models.NewShop(id, title, description, userID, contacts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopRep, err)
+	}
+	return shop, nil
+}
@@ -0,0 +1,37 @@
+package shoprep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/google/uuid"
+)
+
+type ShopRep interface {
+	// GetAll возвращает страницу записей, подходящих под фильтр, в порядке filterOps.SortBy
+	GetAll(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error)
+	// Count - число записей, подходящих под фильтр, без учета страницы
+	Count(ctx context.Context, filterOps *reqresp.ShopFilter) (int, error)
+	GetByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error)
+	Add(ctx context.Context, shop *models.Shop) error
+	Update(ctx context.Context, shop *models.Shop) error
+	Delete(ctx context.Context, shopID uuid.UUID) error
+}
+
+// shopSort - сортировка и страница фильтра с учетом значений по умолчанию
+func shopSort(filterOps *reqresp.ShopFilter) (string, bool, reqresp.PageOps) {
+	if filterOps == nil {
+		filterOps = &reqresp.ShopFilter{}
+	}
+	sortBy, desc := pagination.Resolve(filterOps.SortBy, filterOps.Order, reqresp.SortByTitle, reqresp.OrderAsc)
+	return sortBy, desc, filterOps.Page
+}
+
+var (
+	ErrShopRep      = errors.New("ShopRep")
+	ErrShopNotFound = errors.New("shop not found")
+	ErrUserNotFound = errors.New("owner of the shop not found")
+)
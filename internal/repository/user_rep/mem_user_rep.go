@@ -0,0 +1,132 @@
+package userrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemUserRep(db *memdb.DB) UserRep {
+	return &memUserRep{
+		db: db,
+	}
+}
+
+type memUserRep struct {
+	db *memdb.DB
+}
+
+func (r *memUserRep) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var res *models.User
+	err := r.db.Read(func(t *memdb.Tables) error {
+		user, ok := t.Users[userID]
+		if !ok {
+			return ErrUserNotFound
+		}
+		res = user
+		return nil
+	})
+	return res, err
+}
+
+func (r *memUserRep) GetByLogin(ctx context.Context, login string) (*models.User, error) {
+	var res *models.User
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, user := range t.Users {
+			if user.GetLogin() == login {
+				res = user
+				return nil
+			}
+		}
+		return ErrUserNotFound
+	})
+	return res, err
+}
+
+func (r *memUserRep) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	email = models.NormalizeEmail(email)
+	var res *models.User
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, user := range t.Users {
+			if email != "" && user.GetEmail() == email {
+				res = user
+				return nil
+			}
+		}
+		return ErrUserNotFound
+	})
+	return res, err
+}
+
+func (r *memUserRep) Add(ctx context.Context, user *models.User) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[user.GetID()]; ok {
+			return ErrUserRep
+		}
+		if err := checkUnique(t, user); err != nil {
+			return err
+		}
+		t.Users[user.GetID()] = user
+		return nil
+	})
+}
+
+func (r *memUserRep) Update(ctx context.Context, user *models.User) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[user.GetID()]; !ok {
+			return ErrUserNotFound
+		}
+		if err := checkUnique(t, user); err != nil {
+			return err
+		}
+		t.Users[user.GetID()] = user
+		return nil
+	})
+}
+
+func (r *memUserRep) ReplacePasswordHash(ctx context.Context, userID uuid.UUID, oldHash string, newHash string) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		user, ok := t.Users[userID]
+		if !ok {
+			return ErrUserNotFound
+		}
+		if user.GetHashedPassword() != oldHash {
+			return ErrPasswordHashChanged
+		}
+		updated, err := models.NewUser(user.GetID(), user.GetUsername(), user.GetLogin(), newHash, user.GetRole(),
+			user.GetEmail(), user.IsEmailVerified())
+		if err != nil {
+			return err
+		}
+		t.Users[userID] = &updated
+		return nil
+	})
+}
+
+func (r *memUserRep) Delete(ctx context.Context, userID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[userID]; !ok {
+			return ErrUserNotFound
+		}
+		t.DeleteUser(userID)
+		return nil
+	})
+}
+
+// checkUnique - не заняты ли логин и почта другим пользователем
+func checkUnique(t *memdb.Tables, user *models.User) error {
+	for id, u := range t.Users {
+		if id == user.GetID() {
+			continue
+		}
+		if u.GetLogin() == user.GetLogin() {
+			return ErrDuplicateLogin
+		}
+		if user.GetEmail() != "" && u.GetEmail() == user.GetEmail() {
+			return ErrDuplicateEmail
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package userrep_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MemUserRepSuite struct {
+	suite.Suite
+}
+
+func TestMemUserRep(t *testing.T) {
+	suite.RunSuite(t, new(MemUserRepSuite))
+}
+
+func (s *MemUserRepSuite) TestMemUserRep_AddAndGet(t provider.T) {
+	userCreator := testobj.NewUserMother()
+
+	t.WithNewStep("success", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+		user := userCreator.DefaultUserP(uuid.New())
+
+		sCtx.Require().NoError(rep.Add(ctx, user))
+
+		byID, err := rep.GetByID(ctx, user.GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(user.GetLogin(), byID.GetLogin())
+
+		byLogin, err := rep.GetByLogin(ctx, user.GetLogin())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(user.GetID(), byLogin.GetID())
+	})
+	t.WithNewStep("not found", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+
+		_, err := rep.GetByID(ctx, uuid.New())
+		sCtx.Require().ErrorIs(err, userrep.ErrUserNotFound)
+		_, err = rep.GetByLogin(ctx, "nobody")
+		sCtx.Require().ErrorIs(err, userrep.ErrUserNotFound)
+	})
+}
+
+func (s *MemUserRepSuite) TestMemUserRep_UniqueLogin(t provider.T) {
+	userCreator := testobj.NewUserMother()
+
+	t.WithNewStep("add with duplicate login", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.UserWithLoginP(uuid.New(), "ulogin")))
+
+		err := rep.Add(ctx, userCreator.UserWithLoginP(uuid.New(), "ulogin"))
+		sCtx.Require().ErrorIs(err, userrep.ErrDuplicateLogin)
+	})
+	t.WithNewStep("update to taken login", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+		userID := uuid.New()
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.UserWithLoginP(uuid.New(), "first")))
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.UserWithLoginP(userID, "second")))
+
+		err := rep.Update(ctx, userCreator.UserWithLoginP(userID, "first"))
+		sCtx.Require().ErrorIs(err, userrep.ErrDuplicateLogin)
+
+		err = rep.Update(ctx, userCreator.UserWithLoginP(userID, "second"))
+		sCtx.Require().NoError(err, "own login is not a duplicate")
+	})
+	t.WithNewStep("concurrent registration", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+
+		const n = 20
+		errs := make(chan error, n)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- rep.Add(ctx, userCreator.UserWithLoginP(uuid.New(), "same-login"))
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		success := 0
+		for err := range errs {
+			if err == nil {
+				success++
+			} else {
+				sCtx.Require().ErrorIs(err, userrep.ErrDuplicateLogin, fmt.Sprint(err))
+			}
+		}
+		sCtx.Assert().Equal(1, success)
+	})
+}
+
+func (s *MemUserRepSuite) TestMemUserRep_Email(t provider.T) {
+	userCreator := testobj.NewUserMother()
+
+	t.WithNewStep("get by email ignores case", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+		user := userCreator.UserWithEmailP(uuid.New(), "Anna@Example.com", false)
+		sCtx.Require().NoError(rep.Add(ctx, user))
+
+		byEmail, err := rep.GetByEmail(ctx, "anna@EXAMPLE.com")
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(user.GetID(), byEmail.GetID())
+		_, err = rep.GetByEmail(ctx, "nobody@example.com")
+		sCtx.Assert().ErrorIs(err, userrep.ErrUserNotFound)
+	})
+	t.WithNewStep("email is unique, users without email are not", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		rep := userrep.NewMemUserRep(memdb.NewDB())
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.UserWithEmailP(uuid.New(), "anna@example.com", true)))
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.DefaultUserP(uuid.New())))
+		sCtx.Require().NoError(rep.Add(ctx, userCreator.DefaultUserP(uuid.New())))
+
+		err := rep.Add(ctx, userCreator.UserWithEmailP(uuid.New(), "ANNA@example.com", false))
+		sCtx.Require().ErrorIs(err, userrep.ErrDuplicateEmail)
+	})
+}
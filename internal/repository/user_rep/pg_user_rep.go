@@ -0,0 +1,181 @@
+package userrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	usersTable = "users"
+	// ограничения уникальности users, см. миграции 000001 и 000017
+	usersEmailUnique = "users_email_unique"
+)
+
+var userColumns = []string{"id", "username", "login", "hashed_password", "role", "email", "email_verified"}
+
+func NewPgUserRep(pool *pgxpool.Pool) UserRep {
+	return &pgUserRep{
+		pool: pool,
+	}
+}
+
+type pgUserRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgUserRep) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	return r.getOne(ctx, sq.Eq{"id": userID})
+}
+
+func (r *pgUserRep) GetByLogin(ctx context.Context, login string) (*models.User, error) {
+	return r.getOne(ctx, sq.Eq{"login": login})
+}
+
+func (r *pgUserRep) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	email = models.NormalizeEmail(email)
+	if email == "" {
+		return nil, ErrUserNotFound
+	}
+	return r.getOne(ctx, sq.Eq{"email": email})
+}
+
+func (r *pgUserRep) Add(ctx context.Context, user *models.User) error {
+	query, args, err := pgdb.Psql.Insert(usersTable).
+		Columns(userColumns...).
+		Values(
+			user.GetID(), user.GetUsername(), user.GetLogin(), user.GetHashedPassword(), string(user.GetRole()),
+			nullableEmail(user), user.IsEmailVerified(),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		if pgdb.IsUniqueViolationOf(err, usersEmailUnique) {
+			return ErrDuplicateEmail
+		}
+		if pgdb.IsUniqueViolation(err) {
+			return ErrDuplicateLogin
+		}
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	return nil
+}
+
+func (r *pgUserRep) Update(ctx context.Context, user *models.User) error {
+	query, args, err := pgdb.Psql.Update(usersTable).
+		Set("username", user.GetUsername()).
+		Set("login", user.GetLogin()).
+		Set("hashed_password", user.GetHashedPassword()).
+		Set("role", string(user.GetRole())).
+		Set("email", nullableEmail(user)).
+		Set("email_verified", user.IsEmailVerified()).
+		Where(sq.Eq{"id": user.GetID()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		if pgdb.IsUniqueViolationOf(err, usersEmailUnique) {
+			return ErrDuplicateEmail
+		}
+		if pgdb.IsUniqueViolation(err) {
+			return ErrDuplicateLogin
+		}
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *pgUserRep) ReplacePasswordHash(ctx context.Context, userID uuid.UUID, oldHash string, newHash string) error {
+	query, args, err := pgdb.Psql.Update(usersTable).
+		Set("hashed_password", newHash).
+		Where(sq.Eq{"id": userID, "hashed_password": oldHash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPasswordHashChanged
+	}
+	return nil
+}
+
+func (r *pgUserRep) Delete(ctx context.Context, userID uuid.UUID) error {
+	query, args, err := pgdb.Psql.Delete(usersTable).
+		Where(sq.Eq{"id": userID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *pgUserRep) getOne(ctx context.Context, where sq.Sqlizer) (*models.User, error) {
+	query, args, err := pgdb.Psql.Select(userColumns...).
+		From(usersTable).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+
+	var (
+		id                                    uuid.UUID
+		username, login, hashedPassword, role string
+		email                                 *string
+		emailVerified                         bool
+	)
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&id, &username, &login, &hashedPassword, &role, &email, &emailVerified)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	user, err := models.NewUser(id, username, login, hashedPassword, models.Role(role), deref(email), emailVerified)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserRep, err)
+	}
+	return &user, nil
+}
+
+// nullableEmail - у пользователей без почты в столбце NULL, чтобы не мешать уникальности
+func nullableEmail(user *models.User) *string {
+	if user.GetEmail() == "" {
+		return nil
+	}
+	email := user.GetEmail()
+	return &email
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
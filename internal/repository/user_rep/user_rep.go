@@ -0,0 +1,31 @@
+package userrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+type UserRep interface {
+	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	GetByLogin(ctx context.Context, login string) (*models.User, error)
+	// GetByEmail ищет по адресу без учета регистра
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Add(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	// ReplacePasswordHash меняет хеш пароля, только если он все еще равен oldHash,
+	// иначе ErrPasswordHashChanged: пароль успели сменить
+	ReplacePasswordHash(ctx context.Context, userID uuid.UUID, oldHash string, newHash string) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+var (
+	ErrUserRep        = errors.New("UserRep")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrDuplicateLogin = errors.New("user with this login already exists")
+	ErrDuplicateEmail = errors.New("user with this email already exists")
+	// ErrPasswordHashChanged - хеш пароля изменился с момента чтения пользователя
+	ErrPasswordHashChanged = errors.New("user password hash has changed")
+)
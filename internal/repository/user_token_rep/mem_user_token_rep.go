@@ -0,0 +1,88 @@
+package usertokenrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	"github.com/google/uuid"
+)
+
+func NewMemUserTokenRep(db *memdb.DB) UserTokenRep {
+	return &memUserTokenRep{
+		db: db,
+	}
+}
+
+type memUserTokenRep struct {
+	db *memdb.DB
+}
+
+func (r *memUserTokenRep) Add(ctx context.Context, token *models.UserToken) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		if _, ok := t.Users[token.GetUserID()]; !ok {
+			return ErrUserNotFound
+		}
+		for id, stored := range t.UserTokens {
+			if id == token.GetID() || stored.GetTokenHash() == token.GetTokenHash() {
+				return ErrUserTokenRep
+			}
+		}
+		for id, stored := range t.UserTokens {
+			if stored.GetUserID() == token.GetUserID() && stored.GetPurpose() == token.GetPurpose() && !stored.IsUsed() {
+				used, err := markUsed(stored)
+				if err != nil {
+					return err
+				}
+				t.UserTokens[id] = used
+			}
+		}
+		t.UserTokens[token.GetID()] = token
+		return nil
+	})
+}
+
+func (r *memUserTokenRep) GetByHash(ctx context.Context, tokenHash string) (*models.UserToken, error) {
+	var res *models.UserToken
+	err := r.db.Read(func(t *memdb.Tables) error {
+		for _, token := range t.UserTokens {
+			if token.GetTokenHash() == tokenHash {
+				res = token
+				return nil
+			}
+		}
+		return ErrUserTokenNotFound
+	})
+	return res, err
+}
+
+func (r *memUserTokenRep) Use(ctx context.Context, tokenID uuid.UUID) error {
+	return r.db.Write(func(t *memdb.Tables) error {
+		token, ok := t.UserTokens[tokenID]
+		if !ok {
+			return ErrUserTokenNotFound
+		}
+		if token.IsUsed() {
+			return ErrAlreadyUsed
+		}
+		used, err := markUsed(token)
+		if err != nil {
+			return err
+		}
+		t.UserTokens[tokenID] = used
+		return nil
+	})
+}
+
+// markUsed - копия токена, помеченная использованной, модели неизменяемы
+func markUsed(token *models.UserToken) (*models.UserToken, error) {
+	return models.NewUserToken(
+		token.GetID(),
+		token.GetUserID(),
+		token.GetPurpose(),
+		token.GetTokenHash(),
+		token.GetEmail(),
+		token.GetExpiresAt(),
+		true,
+	)
+}
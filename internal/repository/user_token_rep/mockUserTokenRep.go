@@ -0,0 +1,31 @@
+package usertokenrep
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserTokenRep struct {
+	mock.Mock
+}
+
+func (m *MockUserTokenRep) Add(ctx context.Context, token *models.UserToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRep) GetByHash(ctx context.Context, tokenHash string) (*models.UserToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserToken), args.Error(1)
+}
+
+func (m *MockUserTokenRep) Use(ctx context.Context, tokenID uuid.UUID) error {
+	args := m.Called(ctx, tokenID)
+	return args.Error(0)
+}
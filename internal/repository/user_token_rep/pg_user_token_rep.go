@@ -0,0 +1,112 @@
+package usertokenrep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	pgdb "github.com/CakeForKit/CraftPlace.git/internal/repository/pg_db"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const userTokensTable = "user_tokens"
+
+func NewPgUserTokenRep(pool *pgxpool.Pool) UserTokenRep {
+	return &pgUserTokenRep{
+		pool: pool,
+	}
+}
+
+type pgUserTokenRep struct {
+	pool *pgxpool.Pool
+}
+
+func (r *pgUserTokenRep) Add(ctx context.Context, token *models.UserToken) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query, args, err := pgdb.Psql.Update(userTokensTable).
+			Set("used_at", sq.Expr("now()")).
+			Where(sq.Eq{"user_id": token.GetUserID(), "purpose": string(token.GetPurpose()), "used_at": nil}).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+
+		query, args, err = pgdb.Psql.Insert(userTokensTable).
+			Columns("id", "user_id", "purpose", "token_hash", "email", "expires_at").
+			Values(
+				token.GetID(), token.GetUserID(), string(token.GetPurpose()),
+				token.GetTokenHash(), token.GetEmail(), token.GetExpiresAt(),
+			).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		if pgdb.IsForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+	return nil
+}
+
+func (r *pgUserTokenRep) GetByHash(ctx context.Context, tokenHash string) (*models.UserToken, error) {
+	query, args, err := pgdb.Psql.Select(
+		"id", "user_id", "purpose", "token_hash", "email", "expires_at", "used_at IS NOT NULL",
+	).
+		From(userTokensTable).
+		Where(sq.Eq{"token_hash": tokenHash}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+
+	var (
+		id, userID           uuid.UUID
+		purpose, hash, email string
+		expiresAt            time.Time
+		used                 bool
+	)
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&id, &userID, &purpose, &hash, &email, &expiresAt, &used)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserTokenNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+	token, err := models.NewUserToken(id, userID, models.TokenPurpose(purpose), hash, email, expiresAt.UTC(), used)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+	return token, nil
+}
+
+func (r *pgUserTokenRep) Use(ctx context.Context, tokenID uuid.UUID) error {
+	// условие в WHERE не дает двум параллельным запросам использовать один токен
+	query, args, err := pgdb.Psql.Update(userTokensTable).
+		Set("used_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": tokenID, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserTokenRep, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAlreadyUsed
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package usertokenrep
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+// UserTokenRep - одноразовые токены из писем: подтверждение почты и сброс пароля
+type UserTokenRep interface {
+	// Add сохраняет токен. Неиспользованные токены пользователя с той же целью перестают действовать:
+	// работает только ссылка из последнего письма.
+	Add(ctx context.Context, token *models.UserToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*models.UserToken, error)
+	// Use атомарно помечает токен использованным.
+	// ErrAlreadyUsed - если токен уже использован (в т.ч. параллельным запросом).
+	Use(ctx context.Context, tokenID uuid.UUID) error
+}
+
+var (
+	ErrUserTokenRep      = errors.New("UserTokenRep")
+	ErrUserTokenNotFound = errors.New("user token not found")
+	ErrAlreadyUsed       = errors.New("user token already used")
+	ErrUserNotFound      = errors.New("owner of the user token not found")
+)
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
 	"github.com/google/uuid"
@@ -19,9 +20,47 @@ var (
 	ErrHasNoRights = errors.New("has no rights")
 )
 
+// Action и Resource описывают действие над записями, которые не принадлежат пользователю
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type Resource string
+
+const (
+	ResourceCategory Resource = "category"
+	ResourceShop     Resource = "shop"
+	ResourceProduct  Resource = "product"
+	ResourcePost     Resource = "post"
+)
+
+// permissions - минимальная роль для действия. Свои магазины, товары и посты пользователь
+// меняет без этой таблицы (см. OwnerPolicy), чужие - только при наличии права здесь.
+var permissions = map[Resource]map[Action]tokenmaker.RoleAuth{
+	ResourceCategory: {
+		ActionCreate: tokenmaker.AdminRole,
+		ActionUpdate: tokenmaker.AdminRole,
+		ActionDelete: tokenmaker.AdminRole,
+	},
+	ResourceShop:    {ActionDelete: tokenmaker.ModeratorRole},
+	ResourceProduct: {ActionDelete: tokenmaker.ModeratorRole},
+	ResourcePost:    {ActionDelete: tokenmaker.ModeratorRole},
+}
+
 type AuthZ interface {
 	Authorize(ctx context.Context, payload tokenmaker.Payload) context.Context
+	// UserIDFromContext возвращает пользователя запроса с любой ролью
 	UserIDFromContext(ctx context.Context) (uuid.UUID, error)
+	// PayloadFromContext возвращает полезную нагрузку токена текущего запроса
+	PayloadFromContext(ctx context.Context) (tokenmaker.Payload, error)
+	// RequireRole - ErrNotAuthZ для анонимного запроса, ErrHasNoRights если роль не включает role
+	RequireRole(ctx context.Context, role tokenmaker.RoleAuth) error
+	// Can - разрешено ли пользователю запроса действие над чужой записью
+	Can(ctx context.Context, action Action, resource Resource) bool
 }
 
 func NewAuthZ() (AuthZ, error) {
@@ -40,8 +79,35 @@ func (a *authZ) UserIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	if !ok {
 		return uuid.Nil, ErrNotAuthZ
 	}
-	if payload.Role != tokenmaker.UserRole {
+	if !payload.Role.Includes(tokenmaker.UserRole) {
 		return uuid.Nil, ErrHasNoRights
 	}
 	return payload.PersonID, nil
 }
+
+func (a *authZ) PayloadFromContext(ctx context.Context) (tokenmaker.Payload, error) {
+	payload, ok := ctx.Value(AuthZContextKey).(tokenmaker.Payload)
+	if !ok {
+		return tokenmaker.Payload{}, ErrNotAuthZ
+	}
+	return payload, nil
+}
+
+func (a *authZ) RequireRole(ctx context.Context, role tokenmaker.RoleAuth) error {
+	payload, err := a.PayloadFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !payload.Role.Includes(role) {
+		return fmt.Errorf("%w: %s required", ErrHasNoRights, role)
+	}
+	return nil
+}
+
+func (a *authZ) Can(ctx context.Context, action Action, resource Resource) bool {
+	required, ok := permissions[resource][action]
+	if !ok {
+		return false
+	}
+	return a.RequireRole(ctx, required) == nil
+}
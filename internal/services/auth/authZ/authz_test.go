@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
 	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
 	"github.com/google/uuid"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
@@ -44,3 +45,71 @@ func (s *AuthZServiceSuite) TestAuthZ_Authz_GetUserID(t provider.T) {
 		assert.ErrorIs(t, err, auth.ErrNotAuthZ)
 	})
 }
+
+func (s *AuthZServiceSuite) TestAuthZ_Authz_GetPayload(t provider.T) {
+	payloadMother := testobj.NewPayloadMother()
+	authzServ, err := auth.NewAuthZ()
+	t.Require().NoError(err, "Failed to create authzServ")
+
+	t.WithNewStep("success", func(sCtx provider.StepCtx) {
+		payload := payloadMother.UserPayload(uuid.New())
+		ctx := authzServ.Authorize(context.Background(), payload)
+
+		resPayload, err := authzServ.PayloadFromContext(ctx)
+		sCtx.Require().NoError(err)
+		assert.Equal(t, payload.GetID(), resPayload.GetID())
+	})
+	t.WithNewStep("not authorized", func(sCtx provider.StepCtx) {
+		_, err := authzServ.PayloadFromContext(context.Background())
+
+		assert.ErrorIs(t, err, auth.ErrNotAuthZ)
+	})
+}
+
+func (s *AuthZServiceSuite) TestAuthZ_RequireRole(t provider.T) {
+	payloadMother := testobj.NewPayloadMother()
+	authzServ, err := auth.NewAuthZ()
+	t.Require().NoError(err, "Failed to create authzServ")
+
+	t.WithNewStep("admin includes moderator", func(sCtx provider.StepCtx) {
+		ctx := authzServ.Authorize(context.Background(), payloadMother.RolePayload(uuid.New(), tokenmaker.AdminRole))
+
+		sCtx.Require().NoError(authzServ.RequireRole(ctx, tokenmaker.ModeratorRole))
+	})
+	t.WithNewStep("user is not admin", func(sCtx provider.StepCtx) {
+		ctx := authzServ.Authorize(context.Background(), payloadMother.UserPayload(uuid.New()))
+
+		err := authzServ.RequireRole(ctx, tokenmaker.AdminRole)
+
+		assert.ErrorIs(t, err, auth.ErrHasNoRights)
+	})
+	t.WithNewStep("not authorized", func(sCtx provider.StepCtx) {
+		err := authzServ.RequireRole(context.Background(), tokenmaker.UserRole)
+
+		assert.ErrorIs(t, err, auth.ErrNotAuthZ)
+	})
+}
+
+func (s *AuthZServiceSuite) TestAuthZ_Can(t provider.T) {
+	payloadMother := testobj.NewPayloadMother()
+	authzServ, err := auth.NewAuthZ()
+	t.Require().NoError(err, "Failed to create authzServ")
+
+	userCtx := authzServ.Authorize(context.Background(), payloadMother.UserPayload(uuid.New()))
+	moderatorCtx := authzServ.Authorize(context.Background(), payloadMother.RolePayload(uuid.New(), tokenmaker.ModeratorRole))
+	adminCtx := authzServ.Authorize(context.Background(), payloadMother.RolePayload(uuid.New(), tokenmaker.AdminRole))
+
+	t.WithNewStep("categories are managed by admin only", func(sCtx provider.StepCtx) {
+		sCtx.Assert().True(authzServ.Can(adminCtx, auth.ActionCreate, auth.ResourceCategory))
+		sCtx.Assert().False(authzServ.Can(moderatorCtx, auth.ActionCreate, auth.ResourceCategory))
+		sCtx.Assert().False(authzServ.Can(userCtx, auth.ActionCreate, auth.ResourceCategory))
+	})
+	t.WithNewStep("moderator deletes but does not update foreign products", func(sCtx provider.StepCtx) {
+		sCtx.Assert().True(authzServ.Can(moderatorCtx, auth.ActionDelete, auth.ResourceProduct))
+		sCtx.Assert().False(authzServ.Can(moderatorCtx, auth.ActionUpdate, auth.ResourceProduct))
+		sCtx.Assert().False(authzServ.Can(userCtx, auth.ActionDelete, auth.ResourceProduct))
+	})
+	t.WithNewStep("anonymous request", func(sCtx provider.StepCtx) {
+		sCtx.Assert().False(authzServ.Can(context.Background(), auth.ActionDelete, auth.ResourcePost))
+	})
+}
@@ -22,3 +22,18 @@ func (m *MockAuthZ) UserIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
+
+func (m *MockAuthZ) PayloadFromContext(ctx context.Context) (tokenmaker.Payload, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(tokenmaker.Payload), args.Error(1)
+}
+
+func (m *MockAuthZ) RequireRole(ctx context.Context, role tokenmaker.RoleAuth) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockAuthZ) Can(ctx context.Context, action Action, resource Resource) bool {
+	args := m.Called(ctx, action, resource)
+	return args.Bool(0)
+}
@@ -0,0 +1,179 @@
+package authclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api/authpb"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// ServiceTokenMetadata - токен сервиса, "Bearer <AUTH_SERVICE_TOKEN>"
+	ServiceTokenMetadata = "authorization"
+	// ClientIPMetadata - адрес клиента API для ограничения попыток входа на стороне сервиса авторизации
+	ClientIPMetadata = "x-client-ip"
+)
+
+// NewAuthClient - authuser.AuthUser поверх gRPC сервиса авторизации (cmd/auth). Каждый вызов
+// подписывается serviceToken. Попытки входа ограничивает сам сервис, адрес клиента передается
+// из контекста (loginthrottle.WithClientIP). Logout, LogoutAll и RequestEmailVerification передают
+// токен доступа запроса (authuser.WithAccessToken), сервис проверяет его сам.
+// MFAUser нужен только для ограничения попыток входа и удаленно не поддерживается.
+func NewAuthClient(conn grpc.ClientConnInterface, timeout time.Duration, serviceToken string) authuser.AuthUser {
+	return &authClient{
+		client:       authpb.NewAuthServiceClient(conn),
+		timeout:      timeout,
+		serviceToken: serviceToken,
+	}
+}
+
+// Dial - соединение с сервисом авторизации во внутренней сети, без TLS
+func Dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+type authClient struct {
+	client       authpb.AuthServiceClient
+	timeout      time.Duration
+	serviceToken string
+}
+
+func (c *authClient) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = metadata.AppendToOutgoingContext(ctx, ServiceTokenMetadata, "Bearer "+c.serviceToken)
+	if ip := loginthrottle.ClientIPFromContext(ctx); ip != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ClientIPMetadata, ip)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *authClient) LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (authuser.Tokens, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	tokens, err := c.client.Login(ctx, &authpb.LoginRequest{Login: lur.Login, Password: lur.Password})
+	if err != nil {
+		return authuser.Tokens{}, FromStatus(err)
+	}
+	return TokensFromProto(tokens), nil
+}
+
+func (c *authClient) RegisterUser(ctx context.Context, rur reqresp.RegisterUserRequest) error {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err := c.client.Register(ctx, &authpb.RegisterRequest{
+		Username: rur.Username,
+		Login:    rur.Login,
+		Email:    rur.Email,
+		Password: rur.Password,
+	})
+	return FromStatus(err)
+}
+
+func (c *authClient) VerifyByToken(ctx context.Context, token string) (*tokenmaker.Payload, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	payload, err := c.client.Introspect(ctx, &authpb.IntrospectRequest{AccessToken: token})
+	if err != nil {
+		return nil, FromStatus(err)
+	}
+	return PayloadFromProto(payload)
+}
+
+func (c *authClient) Refresh(ctx context.Context, refreshToken string) (authuser.Tokens, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	tokens, err := c.client.Refresh(ctx, &authpb.RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return authuser.Tokens{}, FromStatus(err)
+	}
+	return TokensFromProto(tokens), nil
+}
+
+// Logout - сервис сам проверяет токен доступа запроса, payload нужен только интерфейсу
+func (c *authClient) Logout(ctx context.Context, payload tokenmaker.Payload, refreshToken string) error {
+	accessToken := authuser.AccessTokenFromContext(ctx)
+	if accessToken == "" {
+		return ErrNoAccessToken
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err := c.client.Logout(ctx, &authpb.LogoutRequest{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+	return FromStatus(err)
+}
+
+func (c *authClient) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	req, err := userRequest(ctx, userID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err = c.client.LogoutAll(ctx, req)
+	return FromStatus(err)
+}
+
+func (c *authClient) VerifyEmail(ctx context.Context, token string) error {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err := c.client.VerifyEmail(ctx, &authpb.TokenRequest{Token: token})
+	return FromStatus(err)
+}
+
+func (c *authClient) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	req, err := userRequest(ctx, userID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err = c.client.RequestEmailVerification(ctx, req)
+	return FromStatus(err)
+}
+
+func (c *authClient) ForgotPassword(ctx context.Context, email string) error {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err := c.client.ForgotPassword(ctx, &authpb.ForgotPasswordRequest{Email: email})
+	return FromStatus(err)
+}
+
+func (c *authClient) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	_, err := c.client.ResetPassword(ctx, &authpb.ResetPasswordRequest{Token: token, Password: newPassword})
+	return FromStatus(err)
+}
+
+func (c *authClient) CompleteMFA(ctx context.Context, mfaToken string, code string) (authuser.Tokens, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	tokens, err := c.client.CompleteMFA(ctx, &authpb.CompleteMFARequest{MfaToken: mfaToken, Code: code})
+	if err != nil {
+		return authuser.Tokens{}, FromStatus(err)
+	}
+	return TokensFromProto(tokens), nil
+}
+
+func (c *authClient) MFAUser(ctx context.Context, mfaToken string) (*models.User, error) {
+	return nil, ErrNotSupported
+}
+
+// userRequest - действие над userID от имени владельца токена доступа запроса
+func userRequest(ctx context.Context, userID uuid.UUID) (*authpb.UserRequest, error) {
+	accessToken := authuser.AccessTokenFromContext(ctx)
+	if accessToken == "" {
+		return nil, ErrNoAccessToken
+	}
+	return &authpb.UserRequest{UserId: userID.String(), AccessToken: accessToken}, nil
+}
@@ -0,0 +1,233 @@
+package authclient_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api"
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	authclient "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_client"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type AuthClientSuite struct {
+	suite.Suite
+}
+
+func TestAuthClient(t *testing.T) {
+	suite.RunSuite(t, new(AuthClientSuite))
+}
+
+const serviceToken = "service-token-0123456789abcdef0123"
+
+var registerReq = reqresp.RegisterUserRequest{
+	Username: "uname",
+	Login:    "ulogin",
+	Email:    "master@example.com",
+	Password: "Password123",
+}
+
+// newRemoteAuthUser - клиент с токеном сервиса clientToken, подключенный к gRPC серверу
+// над настоящим AuthUser в памяти. После одной неудачной попытки вход задерживается на минуту
+func newRemoteAuthUser(t provider.StepCtx, clientToken string) (authuser.AuthUser, func()) {
+	appCnfg := testobj.NewAppConfigMother().Default()
+	passwordCnfg := testobj.NewPasswordConfigMother().Default()
+	tokenMaker, err := tokenmaker.NewTokenMaker(appCnfg)
+	t.Require().NoError(err)
+	hash, err := hasher.NewHasher(passwordCnfg)
+	t.Require().NoError(err)
+	mockNotifier := new(notifier.MockNotifier)
+	mockNotifier.On("UserRegistered", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	authUserServ, err := authuser.NewAuthUser(
+		appCnfg,
+		userRep,
+		refreshtokenrep.NewMemRefreshTokenRep(db),
+		revokedtokenrep.NewMemRevokedTokenRep(db),
+		usertokenrep.NewMemUserTokenRep(db),
+		tokenMaker,
+		hash,
+		passwordpolicy.NewPasswordPolicy(passwordCnfg),
+		mockNotifier,
+		mfa.NewMFA(appCnfg, mfarep.NewMemMFARep(db), userRep),
+	)
+	t.Require().NoError(err)
+	policy := cnfg.ThrottlePolicy{
+		FreeAttempts:    1,
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 3,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+	throttle := loginthrottle.NewLoginThrottle(
+		cnfg.ThrottleConfig{Login: policy, IP: policy},
+		loginattemptrep.NewMemLoginAttemptRep(db),
+	)
+
+	listener := bufconn.Listen(1 << 20)
+	server, err := api.NewAuthGRPCServer(authuser.NewThrottledAuthUser(authUserServ, throttle), serviceToken)
+	t.Require().NoError(err)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	t.Require().NoError(err)
+	return authclient.NewAuthClient(conn, time.Second, clientToken), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func (s *AuthClientSuite) TestAuthClient_Session(t provider.T) {
+	t.WithNewStep("register, login, refresh and logout", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		client, stop := newRemoteAuthUser(sCtx, serviceToken)
+		defer stop()
+
+		sCtx.Require().NoError(client.RegisterUser(ctx, registerReq))
+		tokens, err := client.LoginUser(ctx, reqresp.LoginUserRequest{Login: registerReq.Login, Password: registerReq.Password})
+		sCtx.Require().NoError(err)
+		sCtx.Require().NotEmpty(tokens.AccessToken)
+		sCtx.Require().NotEmpty(tokens.RefreshToken)
+
+		payload, err := client.VerifyByToken(ctx, tokens.AccessToken)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(tokenmaker.UserRole, payload.GetRole())
+
+		refreshed, err := client.Refresh(ctx, tokens.RefreshToken)
+		sCtx.Require().NoError(err)
+		_, err = client.Refresh(ctx, tokens.RefreshToken)
+		sCtx.Require().ErrorIs(err, authuser.ErrRefreshTokenReused)
+
+		payload, err = client.VerifyByToken(ctx, refreshed.AccessToken)
+		sCtx.Require().NoError(err)
+		sCtx.Require().NoError(client.LogoutAll(authuser.WithAccessToken(ctx, refreshed.AccessToken), payload.GetPersonID()))
+		_, err = client.VerifyByToken(ctx, refreshed.AccessToken)
+		sCtx.Require().ErrorIs(err, authuser.ErrRevokedToken)
+		sCtx.Assert().Equal(authuser.ErrRevokedToken.Error(), err.Error())
+	})
+	t.WithNewStep("logout revokes the access token of the request", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		client, stop := newRemoteAuthUser(sCtx, serviceToken)
+		defer stop()
+		sCtx.Require().NoError(client.RegisterUser(ctx, registerReq))
+		tokens, err := client.LoginUser(ctx, reqresp.LoginUserRequest{Login: registerReq.Login, Password: registerReq.Password})
+		sCtx.Require().NoError(err)
+		payload, err := client.VerifyByToken(ctx, tokens.AccessToken)
+		sCtx.Require().NoError(err)
+
+		err = client.Logout(ctx, *payload, "")
+		sCtx.Require().ErrorIs(err, authclient.ErrNoAccessToken)
+
+		sCtx.Require().NoError(client.Logout(authuser.WithAccessToken(ctx, tokens.AccessToken), *payload, tokens.RefreshToken))
+		_, err = client.VerifyByToken(ctx, tokens.AccessToken)
+		sCtx.Require().ErrorIs(err, authuser.ErrRevokedToken)
+		_, err = client.Refresh(ctx, tokens.RefreshToken)
+		sCtx.Require().Error(err)
+	})
+}
+
+func (s *AuthClientSuite) TestAuthClient_ServiceAuth(t provider.T) {
+	t.WithNewStep("calls without the service token are rejected", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		for _, token := range []string{"", "wrong-service-token-0123456789abcdef"} {
+			client, stop := newRemoteAuthUser(sCtx, token)
+
+			err := client.RegisterUser(ctx, registerReq)
+			sCtx.Require().ErrorIs(err, authclient.ErrAuthService)
+			sCtx.Assert().Contains(err.Error(), "Unauthenticated")
+			stop()
+		}
+	})
+	t.WithNewStep("actions over another user are rejected", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		client, stop := newRemoteAuthUser(sCtx, serviceToken)
+		defer stop()
+		sCtx.Require().NoError(client.RegisterUser(ctx, registerReq))
+		tokens, err := client.LoginUser(ctx, reqresp.LoginUserRequest{Login: registerReq.Login, Password: registerReq.Password})
+		sCtx.Require().NoError(err)
+		ctx = authuser.WithAccessToken(ctx, tokens.AccessToken)
+
+		err = client.LogoutAll(ctx, uuid.New())
+		sCtx.Assert().ErrorIs(err, authclient.ErrSessionMismatch)
+		err = client.RequestEmailVerification(ctx, uuid.New())
+		sCtx.Assert().ErrorIs(err, authclient.ErrSessionMismatch)
+		err = client.LogoutAll(authuser.WithAccessToken(context.Background(), "forged.jwt.token"), uuid.New())
+		sCtx.Assert().ErrorIs(err, tokenmaker.ErrInvalidToken)
+	})
+}
+
+func (s *AuthClientSuite) TestAuthClient_Errors(t provider.T) {
+	t.WithNewStep("service errors keep their identity", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		client, stop := newRemoteAuthUser(sCtx, serviceToken)
+		defer stop()
+		sCtx.Require().NoError(client.RegisterUser(ctx, registerReq))
+
+		err := client.RegisterUser(ctx, registerReq)
+		sCtx.Assert().ErrorIs(err, authuser.ErrDuplicateLoginUser)
+		weak := registerReq
+		weak.Login, weak.Email, weak.Password = "other", "other@example.com", "short"
+		err = client.RegisterUser(ctx, weak)
+		sCtx.Assert().ErrorIs(err, authuser.ErrWeakPassword)
+
+		_, err = client.LoginUser(ctx, reqresp.LoginUserRequest{Login: "nobody", Password: "Password123"})
+		sCtx.Assert().ErrorIs(err, authuser.ErrUserNotFound)
+		_, err = client.VerifyByToken(ctx, "malformed.jwt.token")
+		sCtx.Assert().ErrorIs(err, tokenmaker.ErrInvalidToken)
+		_, err = client.CompleteMFA(ctx, "malformed.jwt.token", "123456")
+		sCtx.Assert().ErrorIs(err, authuser.ErrInvalidMFAToken)
+		err = client.VerifyEmail(ctx, "unknown")
+		sCtx.Assert().ErrorIs(err, authuser.ErrInvalidUserToken)
+		_, err = client.MFAUser(ctx, "token")
+		sCtx.Assert().ErrorIs(err, authclient.ErrNotSupported)
+	})
+	t.WithNewStep("login throttling with the API client address", func(sCtx provider.StepCtx) {
+		client, stop := newRemoteAuthUser(sCtx, serviceToken)
+		defer stop()
+		sCtx.Require().NoError(client.RegisterUser(context.Background(), registerReq))
+		ctx := loginthrottle.WithClientIP(context.Background(), "10.0.0.1")
+		wrong := reqresp.LoginUserRequest{Login: registerReq.Login, Password: "wrong-password"}
+
+		for range 2 {
+			_, err := client.LoginUser(ctx, wrong)
+			sCtx.Require().ErrorIs(err, hasher.ErrPassword)
+		}
+		_, err := client.LoginUser(ctx, reqresp.LoginUserRequest{Login: registerReq.Login, Password: registerReq.Password})
+		var throttled *loginthrottle.ThrottledError
+		sCtx.Require().ErrorAs(err, &throttled)
+		sCtx.Assert().Greater(throttled.RetryAfter, time.Duration(0))
+	})
+}
@@ -0,0 +1,57 @@
+package authclient
+
+import (
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/api/authpb"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TokensToProto(tokens authuser.Tokens) *authpb.Tokens {
+	return &authpb.Tokens{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		MfaToken:     tokens.MFAToken,
+	}
+}
+
+func TokensFromProto(tokens *authpb.Tokens) authuser.Tokens {
+	return authuser.Tokens{
+		AccessToken:  tokens.GetAccessToken(),
+		RefreshToken: tokens.GetRefreshToken(),
+		MFAToken:     tokens.GetMfaToken(),
+	}
+}
+
+func PayloadToProto(payload tokenmaker.Payload) *authpb.Payload {
+	return &authpb.Payload{
+		TokenId:   payload.GetID().String(),
+		PersonId:  payload.GetPersonID().String(),
+		Role:      string(payload.GetRole()),
+		IssuedAt:  timestamppb.New(payload.GetIssuedAt()),
+		NotBefore: timestamppb.New(payload.GetNotBefore()),
+		ExpiredAt: timestamppb.New(payload.GetExpiredAt()),
+	}
+}
+
+func PayloadFromProto(payload *authpb.Payload) (*tokenmaker.Payload, error) {
+	id, err := uuid.Parse(payload.GetTokenId())
+	if err != nil {
+		return nil, fmt.Errorf("payload token_id: %w", err)
+	}
+	personID, err := uuid.Parse(payload.GetPersonId())
+	if err != nil {
+		return nil, fmt.Errorf("payload person_id: %w", err)
+	}
+	return &tokenmaker.Payload{
+		ID:        id,
+		PersonID:  personID,
+		Role:      tokenmaker.RoleAuth(payload.GetRole()),
+		IssuedAt:  payload.GetIssuedAt().AsTime(),
+		NotBefore: payload.GetNotBefore().AsTime(),
+		ExpiredAt: payload.GetExpiredAt().AsTime(),
+	}, nil
+}
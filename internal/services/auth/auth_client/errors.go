@@ -0,0 +1,134 @@
+package authclient
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ошибки сервиса авторизации передаются статусом gRPC с google.rpc.ErrorInfo: reason однозначно
+// определяет ошибку, поэтому на стороне клиента errors.Is и errors.As работают как при вызове в процессе
+
+const (
+	ErrorDomain = "craftplace.auth"
+
+	reasonTooManyAttempts = "TOO_MANY_ATTEMPTS"
+	metadataRetryAfter    = "retry_after" // time.Duration.String()
+)
+
+var (
+	ErrAuthService  = errors.New("auth service")
+	ErrNotSupported = errors.New("not supported by remote auth")
+	// ErrNoAccessToken - действие от имени пользователя вызвано вне запроса с его токеном доступа
+	ErrNoAccessToken = errors.New("access token is not in the request context")
+	// ErrSessionMismatch - токен доступа принадлежит не тому пользователю, над которым действие
+	ErrSessionMismatch = errors.New("access token belongs to another user")
+)
+
+type authError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// authErrors проверяются по порядку: ошибки, оборачивающие другие, идут раньше
+var authErrors = []authError{
+	{ErrSessionMismatch, codes.PermissionDenied, "SESSION_MISMATCH"},
+	{authuser.ErrInvalidMFAToken, codes.Unauthenticated, "INVALID_MFA_TOKEN"},
+	{authuser.ErrInvalidMFACode, codes.Unauthenticated, "INVALID_MFA_CODE"},
+	{authuser.ErrDuplicateLoginUser, codes.AlreadyExists, "DUPLICATE_LOGIN"},
+	{authuser.ErrDuplicateEmailUser, codes.AlreadyExists, "DUPLICATE_EMAIL"},
+	{authuser.ErrUserNotFound, codes.NotFound, "USER_NOT_FOUND"},
+	{authuser.ErrWeakPassword, codes.InvalidArgument, "WEAK_PASSWORD"},
+	{authuser.ErrInvalidRefreshToken, codes.Unauthenticated, "INVALID_REFRESH_TOKEN"},
+	{authuser.ErrRefreshTokenReused, codes.Unauthenticated, "REFRESH_TOKEN_REUSED"},
+	{authuser.ErrRevokedToken, codes.Unauthenticated, "TOKEN_REVOKED"},
+	{authuser.ErrInvalidUserToken, codes.InvalidArgument, "INVALID_USER_TOKEN"},
+	{authuser.ErrNoEmail, codes.FailedPrecondition, "NO_EMAIL"},
+	{authuser.ErrEmailAlreadyVerified, codes.FailedPrecondition, "EMAIL_ALREADY_VERIFIED"},
+	{hasher.ErrPassword, codes.Unauthenticated, "INVALID_PASSWORD"},
+	{tokenmaker.ErrExpiredToken, codes.Unauthenticated, "TOKEN_EXPIRED"},
+	{tokenmaker.ErrInvalidToken, codes.Unauthenticated, "INVALID_TOKEN"},
+	{tokenmaker.ErrIncorrectRole, codes.PermissionDenied, "INCORRECT_ROLE"},
+	{models.ErrUserValidate, codes.InvalidArgument, "INVALID_USER"},
+}
+
+// remoteError - ошибка сервиса авторизации с исходным текстом
+type remoteError struct {
+	err     error
+	message string
+}
+
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.err
+}
+
+// ToStatus - ошибка AuthUser в статус gRPC. Неизвестные ошибки становятся codes.Internal
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var throttled *loginthrottle.ThrottledError
+	if errors.As(err, &throttled) {
+		return withErrorInfo(codes.ResourceExhausted, err.Error(), &errdetails.ErrorInfo{
+			Reason:   reasonTooManyAttempts,
+			Domain:   ErrorDomain,
+			Metadata: map[string]string{metadataRetryAfter: throttled.RetryAfter.String()},
+		})
+	}
+	for _, ae := range authErrors {
+		if errors.Is(err, ae.err) {
+			return withErrorInfo(ae.code, err.Error(), &errdetails.ErrorInfo{Reason: ae.reason, Domain: ErrorDomain})
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func withErrorInfo(code codes.Code, message string, info *errdetails.ErrorInfo) error {
+	st, err := status.New(code, message).WithDetails(info)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// FromStatus - статус gRPC в ошибку AuthUser. Ошибки без ErrorInfo (недоступность сервиса,
+// истекший срок вызова) оборачиваются в ErrAuthService
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrAuthService, err)
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorDomain {
+			continue
+		}
+		if info.GetReason() == reasonTooManyAttempts {
+			retryAfter, _ := time.ParseDuration(info.GetMetadata()[metadataRetryAfter])
+			return &loginthrottle.ThrottledError{RetryAfter: retryAfter}
+		}
+		for _, ae := range authErrors {
+			if ae.reason == info.GetReason() {
+				return &remoteError{err: ae.err, message: st.Message()}
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s: %s", ErrAuthService, st.Code(), st.Message())
+}
@@ -0,0 +1,17 @@
+package authuser
+
+import "context"
+
+type accessTokenContextKey struct{}
+
+// WithAccessToken кладет проверенный токен доступа запроса в контекст: удаленный AuthUser
+// передает его сервису авторизации, чтобы тот сам проверил, от чьего имени выполняется действие
+func WithAccessToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, accessTokenContextKey{}, token)
+}
+
+// AccessTokenFromContext - токен доступа запроса, пусто если запрос без токена
+func AccessTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(accessTokenContextKey{}).(string)
+	return token
+}
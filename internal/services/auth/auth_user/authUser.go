@@ -3,86 +3,217 @@ package authuser
 import (
 	"context"
 	"errors"
-	"time"
+	"log"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
 	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
 	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
 	"github.com/google/uuid"
 )
 
 type AuthUser interface {
-	LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (string, error)
+	LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (Tokens, error)
 	RegisterUser(ctx context.Context, rur reqresp.RegisterUserRequest) error
-	VerifyByToken(token string) (*tokenmaker.Payload, error)
+	// VerifyByToken проверяет токен доступа и то, что он не отозван
+	VerifyByToken(ctx context.Context, token string) (*tokenmaker.Payload, error)
+	// Refresh обменивает refresh-токен на новую пару токенов, старый refresh-токен больше не действует
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+	// Logout завершает текущую сессию: отзывает токен доступа и, если передан, refresh-токен
+	Logout(ctx context.Context, payload tokenmaker.Payload, refreshToken string) error
+	// LogoutAll завершает все сессии пользователя
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	// VerifyEmail подтверждает почту по токену из письма
+	VerifyEmail(ctx context.Context, token string) error
+	// RequestEmailVerification отправляет новое письмо для подтверждения почты,
+	// ссылки из прежних писем перестают действовать
+	RequestEmailVerification(ctx context.Context, userID uuid.UUID) error
+	// ForgotPassword отправляет письмо со ссылкой для сброса пароля. Для незарегистрированного адреса
+	// ничего не делает и не возвращает ошибку, чтобы по ответу нельзя было узнать, есть ли такой пользователь.
+	ForgotPassword(ctx context.Context, email string) error
+	// ResetPassword задает новый пароль по токену из письма и завершает все сессии.
+	// Слабый пароль (ErrWeakPassword) не расходует токен.
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+	// CompleteMFA - второй шаг входа: обменивает токен из LoginUser и код второго фактора
+	// на пару токенов. Токен второго шага действует один раз.
+	CompleteMFA(ctx context.Context, mfaToken string, code string) (Tokens, error)
+	// MFAUser - пользователь, которому выдан токен второго шага
+	MFAUser(ctx context.Context, mfaToken string) (*models.User, error)
+}
+
+// Tokens - короткоживущий токен доступа и долгоживущий refresh-токен. Если у пользователя
+// включен второй фактор, LoginUser возвращает только MFAToken для CompleteMFA.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string
 }
 
 var (
-	ErrDuplicateLoginUser = errors.New("err Duplicate Login User")
-	ErrUserNotFound       = errors.New("err User Not Found")
+	ErrDuplicateLoginUser  = userrep.ErrDuplicateLogin
+	ErrDuplicateEmailUser  = userrep.ErrDuplicateEmail
+	ErrUserNotFound        = userrep.ErrUserNotFound
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, all sessions of the login are revoked")
+	ErrRevokedToken        = errors.New("token has been revoked")
+	ErrInvalidMFAToken     = errors.New("mfa token is invalid")
+	ErrInvalidMFACode      = mfa.ErrInvalidCode
+	ErrWeakPassword        = passwordpolicy.ErrWeakPassword
 )
 
 type authUser struct {
 	tokenMaker tokenmaker.TokenMaker
 	hasher     hasher.Hasher
-	// config     cnfg.AppConfig
-	// userrep    userrep.UserRep
-
+	policy     passwordpolicy.PasswordPolicy
+	config     cnfg.AppConfig
+	userrep    userrep.UserRep
+	refreshRep refreshtokenrep.RefreshTokenRep
+	revokedRep revokedtokenrep.RevokedTokenRep
+	tokenRep   usertokenrep.UserTokenRep
+	notifier   notifier.Notifier
+	mfa        mfa.MFA
 }
 
-// config cnfg.AppConfig, urep userrep.UserRep,
-func NewAuthUser(tokenMaker tokenmaker.TokenMaker, hasher hasher.Hasher) AuthUser {
+func NewAuthUser(
+	config cnfg.AppConfig,
+	urep userrep.UserRep,
+	refreshRep refreshtokenrep.RefreshTokenRep,
+	revokedRep revokedtokenrep.RevokedTokenRep,
+	tokenRep usertokenrep.UserTokenRep,
+	tokenMaker tokenmaker.TokenMaker,
+	hasher hasher.Hasher,
+	policy passwordpolicy.PasswordPolicy,
+	notifier notifier.Notifier,
+	mfa mfa.MFA,
+) (AuthUser, error) {
 	server := &authUser{
 		tokenMaker: tokenMaker,
 		hasher:     hasher,
-		// config:     config,
-		// userrep:    urep,
+		policy:     policy,
+		config:     config,
+		userrep:    urep,
+		refreshRep: refreshRep,
+		revokedRep: revokedRep,
+		tokenRep:   tokenRep,
+		notifier:   notifier,
+		mfa:        mfa,
 	}
-	return server
+	return server, nil
 }
 
-func (s *authUser) LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (string, error) {
-	// user, err := s.userrep.GetByLogin(ctx, lur.Login)
-	// if err != nil {
-	// 	return "", err
-	// }
+func (s *authUser) LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (Tokens, error) {
+	user, err := s.userrep.GetByLogin(ctx, lur.Login)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	err = s.hasher.CheckPassword(lur.Password, user.GetHashedPassword())
+	if err != nil {
+		return Tokens{}, err
+	}
+	if s.hasher.NeedsRehash(user.GetHashedPassword()) {
+		s.rehashPassword(ctx, user, lur.Password)
+	}
 
-	// err = s.hasher.CheckPassword(lur.Password, user.GetHashedPassword())
-	// if err != nil {
-	// 	return "", err
-	// }
-	userID := uuid.New()
-	s_config_AccessTokenDuration := time.Hour
+	mfaEnabled, err := s.mfa.Enabled(ctx, user.GetID())
+	if err != nil {
+		return Tokens{}, err
+	}
+	if mfaEnabled {
+		// токен второго шага не принимается нигде, кроме CompleteMFA
+		mfaToken, err := s.tokenMaker.CreateToken(user.GetID(), tokenmaker.MFAPendingRole, s.config.MFATokenDuration)
+		if err != nil {
+			return Tokens{}, err
+		}
+		return Tokens{MFAToken: mfaToken}, nil
+	}
+	return s.issueTokens(ctx, user)
+}
+
+// issueTokens - вход выполнен: токен доступа и refresh-токен нового семейства
+func (s *authUser) issueTokens(ctx context.Context, user *models.User) (Tokens, error) {
 	accessToken, err := s.tokenMaker.CreateToken(
-		userID,
-		tokenmaker.UserRole,
-		s_config_AccessTokenDuration,
+		user.GetID(),
+		tokenmaker.RoleOf(user.GetRole()),
+		s.config.AccessTokenDuration,
 	)
 	if err != nil {
-		return "", err
+		return Tokens{}, err
+	}
+	// каждый вход начинает новое семейство refresh-токенов
+	refreshToken, stored, err := s.newRefreshToken(uuid.New(), user.GetID())
+	if err != nil {
+		return Tokens{}, err
+	}
+	if err := s.refreshRep.Add(ctx, stored); err != nil {
+		return Tokens{}, err
+	}
+	return Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// rehashPassword пересчитывает устаревший хеш, пока известен пароль. Ошибка не мешает входу:
+// хеш пересчитается при следующем входе.
+func (s *authUser) rehashPassword(ctx context.Context, user *models.User, password string) {
+	hashedPassword, err := s.hasher.HashPassword(password)
+	if err == nil {
+		err = s.userrep.ReplacePasswordHash(ctx, user.GetID(), user.GetHashedPassword(), hashedPassword)
+	}
+	// пароль сменили параллельно - новый хеш уже актуален
+	if err != nil && !errors.Is(err, userrep.ErrPasswordHashChanged) {
+		log.Printf("rehash password of user %s: %v", user.GetID(), err)
 	}
-	return accessToken, nil
 }
 
 func (s *authUser) RegisterUser(ctx context.Context, rur reqresp.RegisterUserRequest) error {
+	if err := s.policy.Validate(rur.Password); err != nil {
+		return err
+	}
 	hashedPassword, err := s.hasher.HashPassword(rur.Password)
 	if err != nil {
 		return err
 	}
 	user, err := models.NewUser(
 		uuid.New(),
+		rur.Username,
 		rur.Login,
 		hashedPassword,
+		models.RoleUser,
+		rur.Email,
+		false,
 	)
 	if err != nil {
-		return nil
+		return err
+	}
+	if err := s.userrep.Add(ctx, &user); err != nil {
+		return err
 	}
-	_ = user
-	// err = s.userrep.Add(ctx, &user)
+	token, err := s.issueToken(ctx, &user, models.PurposeVerifyEmail, s.config.EmailVerifyTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.notifier.UserRegistered(ctx, &user, token)
 	return nil
 }
 
-func (s *authUser) VerifyByToken(tokenStr string) (*tokenmaker.Payload, error) {
-	return s.tokenMaker.VerifyToken(tokenStr, tokenmaker.UserRole)
+func (s *authUser) VerifyByToken(ctx context.Context, tokenStr string) (*tokenmaker.Payload, error) {
+	payload, err := s.tokenMaker.VerifyToken(tokenStr, tokenmaker.UserRole)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := s.revokedRep.IsRevoked(ctx, payload.GetID(), payload.GetPersonID(), payload.GetIssuedAt())
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrRevokedToken
+	}
+	return payload, nil
 }
@@ -1,6 +1,32 @@
 package authuser_test
 
-/*
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+var testPolicy = passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default())
+
 type AuthUserServiceSuite struct {
 	suite.Suite
 }
@@ -15,19 +41,18 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 	appConfigCreator := testobj.NewAppConfigMother()
 	appCnfg := appConfigCreator.Default()
 
-	tokenMaker, err := token.NewTokenMaker(appCnfg.TokenSymmetricKey)
+	tokenMaker, err := token.NewTokenMaker(appCnfg)
 	t.Require().NoError(err, "Failed to create token maker")
 
 	userCreator := testobj.NewUserMother()
 	hashedPassword := "$2a$10$hashedpassword123"
 	user := userCreator.UserWithPswdHash(uuid.New(), hashedPassword)
 	passwordUser := "password123"
-	registerReq := auth.RegisterUserRequest{
-		Username:       user.GetUsername(),
-		Login:          user.GetLogin(),
-		Password:       passwordUser,
-		Email:          user.GetEmail(),
-		SubscribeEmail: user.IsSubscribedToMail(),
+	registerReq := reqresp.RegisterUserRequest{
+		Username: user.GetUsername(),
+		Login:    user.GetLogin(),
+		Email:    "Master@Example.com",
+		Password: passwordUser,
 	}
 
 	t.WithNewStep("success", func(sCtx provider.StepCtx) {
@@ -40,12 +65,18 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 		mockUserRep.On("Add", ctx, mock.MatchedBy(func(u *models.User) bool {
 			return user.GetUsername() == u.GetUsername() &&
 				user.GetLogin() == u.GetLogin() &&
-				user.GetHashedPassword() == hashedPassword &&
-				user.GetEmail() == u.GetEmail() &&
-				user.IsSubscribedToMail() == u.IsSubscribedToMail()
+				u.GetHashedPassword() == hashedPassword &&
+				u.GetEmail() == "master@example.com" &&
+				!u.IsEmailVerified()
 		})).Return(nil)
+		mockTokenRep := new(usertokenrep.MockUserTokenRep)
+		mockTokenRep.On("Add", ctx, mock.MatchedBy(func(t *models.UserToken) bool {
+			return t.GetPurpose() == models.PurposeVerifyEmail && t.GetEmail() == "master@example.com"
+		})).Return(nil)
+		mockNotifier := new(notifier.MockNotifier)
+		mockNotifier.On("UserRegistered", ctx, mock.AnythingOfType("*models.User"), mock.AnythingOfType("string")).Return()
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, tokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), mockTokenRep, tokenMaker, mockHasher, testPolicy, mockNotifier, new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 		// act
 		err = authUserServ.RegisterUser(ctx, registerReq)
@@ -53,6 +84,27 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 		sCtx.Require().NoError(err)
 		mockHasher.AssertCalled(t, "HashPassword", passwordUser)
 		mockUserRep.AssertCalled(t, "Add", ctx, mock.AnythingOfType("*models.User"))
+		mockTokenRep.AssertCalled(t, "Add", ctx, mock.AnythingOfType("*models.UserToken"))
+		mockNotifier.AssertCalled(t, "UserRegistered", ctx, mock.AnythingOfType("*models.User"), mock.AnythingOfType("string"))
+	})
+	t.WithNewStep("weak password", func(sCtx provider.StepCtx) {
+		// ARRANGE
+		ctx := context.Background()
+		mockHasher := new(hasher.MockHasher)
+		mockUserRep := new(userrep.MockUserRep)
+		weakReq := registerReq
+		weakReq.Password = "1234"
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), tokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
+		sCtx.Require().NoError(err)
+
+		// ACT
+		err = authUserServ.RegisterUser(ctx, weakReq)
+
+		// ASSERT
+		sCtx.Require().ErrorIs(err, auth.ErrWeakPassword)
+		mockHasher.AssertNotCalled(t, "HashPassword", mock.Anything)
+		mockUserRep.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
 	})
 	t.WithNewStep("hasher error", func(sCtx provider.StepCtx) {
 		// ARRANGE
@@ -63,7 +115,7 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 
 		mockUserRep := new(userrep.MockUserRep)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, tokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), tokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
@@ -86,7 +138,7 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 		expectedErr := errors.New("database error")
 		mockUserRep.On("Add", ctx, mock.AnythingOfType("*models.User")).Return(expectedErr)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, tokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), tokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
@@ -98,6 +150,25 @@ func (s *AuthUserServiceSuite) TestAuthUser_RegisterUser(t provider.T) {
 		mockHasher.AssertCalled(t, "HashPassword", passwordUser)
 		mockUserRep.AssertCalled(t, "Add", ctx, mock.AnythingOfType("*models.User"))
 	})
+
+	t.WithNewStep("duplicate login", func(sCtx provider.StepCtx) {
+		// ARRANGE
+		ctx := context.Background()
+		mockHasher := new(hasher.MockHasher)
+		mockHasher.On("HashPassword", passwordUser).Return(hashedPassword, nil)
+
+		mockUserRep := new(userrep.MockUserRep)
+		mockUserRep.On("Add", ctx, mock.AnythingOfType("*models.User")).Return(userrep.ErrDuplicateLogin)
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), tokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
+		sCtx.Require().NoError(err)
+
+		// ACT
+		err = authUserServ.RegisterUser(ctx, registerReq)
+
+		// ASSERT
+		sCtx.Require().ErrorIs(err, auth.ErrDuplicateLoginUser)
+	})
 }
 
 func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
@@ -109,7 +180,7 @@ func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
 	user := userCreator.UserWithPswdHash(uuid.New(), hashedPassword)
 	passwordUser := "password123"
 
-	loginReq := auth.LoginUserRequest{
+	loginReq := reqresp.LoginUserRequest{
 		Login:    user.GetLogin(),
 		Password: passwordUser,
 	}
@@ -124,25 +195,96 @@ func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
 		// Настройка моков
 		mockUserRep.On("GetByLogin", ctx, user.GetLogin()).Return(&user, nil)
 		mockHasher.On("CheckPassword", passwordUser, hashedPassword).Return(nil)
+		mockHasher.On("NeedsRehash", hashedPassword).Return(false)
 
 		expectedToken := "access-token-123"
 		mockTokenMaker.On("CreateToken", user.GetID(), token.UserRole, appCnfg.AccessTokenDuration).
 			Return(expectedToken, nil)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		mockRefreshRep := new(refreshtokenrep.MockRefreshTokenRep)
+		mockRefreshRep.On("Add", ctx, mock.MatchedBy(func(rt *models.RefreshToken) bool {
+			return rt.GetUserID() == user.GetID() && !rt.IsRotated() && !rt.IsRevoked()
+		})).Return(nil)
+		mockMFA := new(mfa.MockMFA)
+		mockMFA.On("Enabled", ctx, user.GetID()).Return(false, nil)
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockRefreshRep, new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), mockMFA)
 		sCtx.Require().NoError(err)
 
 		// ACT
-		tokenStr, err := authUserServ.LoginUser(ctx, loginReq)
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
 
 		// ASSERT
 		sCtx.Require().NoError(err)
-		sCtx.Assert().Equal(expectedToken, tokenStr)
+		sCtx.Assert().Equal(expectedToken, tokens.AccessToken)
+		sCtx.Assert().NotEmpty(tokens.RefreshToken)
+		mockRefreshRep.AssertCalled(t, "Add", ctx, mock.AnythingOfType("*models.RefreshToken"))
 		mockUserRep.AssertCalled(t, "GetByLogin", ctx, user.GetLogin())
 		mockHasher.AssertCalled(t, "CheckPassword", passwordUser, hashedPassword)
 		mockTokenMaker.AssertCalled(t, "CreateToken", user.GetID(), token.UserRole, appCnfg.AccessTokenDuration)
 	})
 
+	t.WithNewStep("mfa enabled", func(sCtx provider.StepCtx) {
+		// ARRANGE
+		ctx := context.Background()
+		mockHasher := new(hasher.MockHasher)
+		mockUserRep := new(userrep.MockUserRep)
+		mockTokenMaker := new(token.MockTokenMaker)
+
+		mockUserRep.On("GetByLogin", ctx, user.GetLogin()).Return(&user, nil)
+		mockHasher.On("CheckPassword", passwordUser, hashedPassword).Return(nil)
+		mockHasher.On("NeedsRehash", hashedPassword).Return(false)
+		mockMFA := new(mfa.MockMFA)
+		mockMFA.On("Enabled", ctx, user.GetID()).Return(true, nil)
+		mockTokenMaker.On("CreateToken", user.GetID(), token.MFAPendingRole, appCnfg.MFATokenDuration).
+			Return("mfa-token-123", nil)
+		mockRefreshRep := new(refreshtokenrep.MockRefreshTokenRep)
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockRefreshRep, new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), mockMFA)
+		sCtx.Require().NoError(err)
+
+		// ACT
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
+
+		// ASSERT
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(auth.Tokens{MFAToken: "mfa-token-123"}, tokens)
+		mockTokenMaker.AssertNotCalled(t, "CreateToken", user.GetID(), token.UserRole, mock.Anything)
+		mockRefreshRep.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	})
+
+	t.WithNewStep("outdated hash is replaced", func(sCtx provider.StepCtx) {
+		// ARRANGE
+		ctx := context.Background()
+		mockHasher := new(hasher.MockHasher)
+		mockUserRep := new(userrep.MockUserRep)
+		mockTokenMaker := new(token.MockTokenMaker)
+
+		mockUserRep.On("GetByLogin", ctx, user.GetLogin()).Return(&user, nil)
+		mockHasher.On("CheckPassword", passwordUser, hashedPassword).Return(nil)
+		mockHasher.On("NeedsRehash", hashedPassword).Return(true)
+		mockHasher.On("HashPassword", passwordUser).Return("$argon2id$new-hash", nil)
+		mockUserRep.On("ReplacePasswordHash", ctx, user.GetID(), hashedPassword, "$argon2id$new-hash").
+			Return(userrep.ErrPasswordHashChanged)
+		mockMFA := new(mfa.MockMFA)
+		mockMFA.On("Enabled", ctx, user.GetID()).Return(false, nil)
+		mockTokenMaker.On("CreateToken", user.GetID(), token.UserRole, appCnfg.AccessTokenDuration).
+			Return("access-token-123", nil)
+		mockRefreshRep := new(refreshtokenrep.MockRefreshTokenRep)
+		mockRefreshRep.On("Add", ctx, mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockRefreshRep, new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), mockMFA)
+		sCtx.Require().NoError(err)
+
+		// ACT
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
+
+		// ASSERT
+		sCtx.Require().NoError(err, "password changed concurrently, login still succeeds")
+		sCtx.Assert().Equal("access-token-123", tokens.AccessToken)
+		mockUserRep.AssertCalled(t, "ReplacePasswordHash", ctx, user.GetID(), hashedPassword, "$argon2id$new-hash")
+	})
+
 	t.WithNewStep("error user not found", func(sCtx provider.StepCtx) {
 		// ARRANGE
 		ctx := context.Background()
@@ -153,15 +295,15 @@ func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
 		expectedErr := errors.New("user not found")
 		mockUserRep.On("GetByLogin", ctx, user.GetLogin()).Return(nil, expectedErr)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
-		tokenStr, err := authUserServ.LoginUser(ctx, loginReq)
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
 
 		// ASSERT
 		sCtx.Require().Error(err)
-		sCtx.Assert().Empty(tokenStr)
+		sCtx.Assert().Empty(tokens)
 		sCtx.Assert().Equal(expectedErr, err)
 		mockUserRep.AssertCalled(t, "GetByLogin", ctx, user.GetLogin())
 		mockHasher.AssertNotCalled(t, "CheckPassword", mock.Anything, mock.Anything)
@@ -179,15 +321,15 @@ func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
 		expectedErr := errors.New("wrong password")
 		mockHasher.On("CheckPassword", passwordUser, hashedPassword).Return(expectedErr)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
-		tokenStr, err := authUserServ.LoginUser(ctx, loginReq)
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
 
 		// ASSERT
 		sCtx.Require().Error(err)
-		sCtx.Assert().Empty(tokenStr)
+		sCtx.Assert().Empty(tokens)
 		sCtx.Assert().Equal(expectedErr, err)
 		mockUserRep.AssertCalled(t, "GetByLogin", ctx, user.GetLogin())
 		mockHasher.AssertCalled(t, "CheckPassword", passwordUser, hashedPassword)
@@ -202,20 +344,23 @@ func (s *AuthUserServiceSuite) TestAuthUser_LoginUser(t provider.T) {
 
 		mockUserRep.On("GetByLogin", ctx, user.GetLogin()).Return(&user, nil)
 		mockHasher.On("CheckPassword", passwordUser, hashedPassword).Return(nil)
+		mockHasher.On("NeedsRehash", hashedPassword).Return(false)
 
 		expectedErr := errors.New("token creation failed")
 		mockTokenMaker.On("CreateToken", user.GetID(), token.UserRole, appCnfg.AccessTokenDuration).
 			Return("", expectedErr)
+		mockMFA := new(mfa.MockMFA)
+		mockMFA.On("Enabled", ctx, user.GetID()).Return(false, nil)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), mockMFA)
 		sCtx.Require().NoError(err)
 
 		// ACT
-		tokenStr, err := authUserServ.LoginUser(ctx, loginReq)
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
 
 		// ASSERT
 		sCtx.Require().Error(err)
-		sCtx.Assert().Empty(tokenStr)
+		sCtx.Assert().Empty(tokens)
 		sCtx.Assert().Equal(expectedErr, err)
 		mockUserRep.AssertCalled(t, "GetByLogin", ctx, user.GetLogin())
 		mockHasher.AssertCalled(t, "CheckPassword", passwordUser, hashedPassword)
@@ -229,29 +374,64 @@ func (s *AuthUserServiceSuite) TestAuthUser_VerifyByToken(t provider.T) {
 
 	t.WithNewStep("success", func(sCtx provider.StepCtx) {
 		// ARRANGE
+		ctx := context.Background()
 		mockHasher := new(hasher.MockHasher)
 		mockUserRep := new(userrep.MockUserRep)
 		mockTokenMaker := new(token.MockTokenMaker)
 
 		expectedPayload := &token.Payload{
+			ID:        uuid.New(),
 			PersonID:  uuid.New(),
 			Role:      token.UserRole,
+			IssuedAt:  time.Now(),
 			ExpiredAt: time.Now().Add(time.Hour),
 		}
 
 		tokenString := "valid-token-123"
 		mockTokenMaker.On("VerifyToken", tokenString, token.UserRole).Return(expectedPayload, nil)
+		mockRevokedRep := new(revokedtokenrep.MockRevokedTokenRep)
+		mockRevokedRep.On("IsRevoked", ctx, expectedPayload.ID, expectedPayload.PersonID, expectedPayload.IssuedAt).
+			Return(false, nil)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), mockRevokedRep, new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
-		payload, err := authUserServ.VerifyByToken(tokenString)
+		payload, err := authUserServ.VerifyByToken(ctx, tokenString)
 
 		// ASSERT
 		sCtx.Require().NoError(err)
 		sCtx.Assert().Equal(expectedPayload, payload)
 		mockTokenMaker.AssertCalled(t, "VerifyToken", tokenString, token.UserRole)
+		mockRevokedRep.AssertCalled(t, "IsRevoked", ctx, expectedPayload.ID, expectedPayload.PersonID, expectedPayload.IssuedAt)
+	})
+
+	t.WithNewStep("revoked token", func(sCtx provider.StepCtx) {
+		// ARRANGE
+		ctx := context.Background()
+		mockTokenMaker := new(token.MockTokenMaker)
+		expectedPayload := &token.Payload{
+			ID:        uuid.New(),
+			PersonID:  uuid.New(),
+			Role:      token.UserRole,
+			IssuedAt:  time.Now(),
+			ExpiredAt: time.Now().Add(time.Hour),
+		}
+		tokenString := "revoked-token-123"
+		mockTokenMaker.On("VerifyToken", tokenString, token.UserRole).Return(expectedPayload, nil)
+		mockRevokedRep := new(revokedtokenrep.MockRevokedTokenRep)
+		mockRevokedRep.On("IsRevoked", ctx, expectedPayload.ID, expectedPayload.PersonID, expectedPayload.IssuedAt).
+			Return(true, nil)
+
+		authUserServ, err := auth.NewAuthUser(appCnfg, new(userrep.MockUserRep), new(refreshtokenrep.MockRefreshTokenRep), mockRevokedRep, new(usertokenrep.MockUserTokenRep), mockTokenMaker, new(hasher.MockHasher), testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
+		sCtx.Require().NoError(err)
+
+		// ACT
+		payload, err := authUserServ.VerifyByToken(ctx, tokenString)
+
+		// ASSERT
+		sCtx.Require().ErrorIs(err, auth.ErrRevokedToken)
+		sCtx.Assert().Nil(payload)
 	})
 
 	t.WithNewStep("error", func(sCtx provider.StepCtx) {
@@ -264,11 +444,11 @@ func (s *AuthUserServiceSuite) TestAuthUser_VerifyByToken(t provider.T) {
 		expectedErr := errors.New("invalid token")
 		mockTokenMaker.On("VerifyToken", tokenString, token.UserRole).Return(nil, expectedErr)
 
-		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, mockTokenMaker, mockHasher)
+		authUserServ, err := auth.NewAuthUser(appCnfg, mockUserRep, new(refreshtokenrep.MockRefreshTokenRep), new(revokedtokenrep.MockRevokedTokenRep), new(usertokenrep.MockUserTokenRep), mockTokenMaker, mockHasher, testPolicy, new(notifier.MockNotifier), new(mfa.MockMFA))
 		sCtx.Require().NoError(err)
 
 		// ACT
-		payload, err := authUserServ.VerifyByToken(tokenString)
+		payload, err := authUserServ.VerifyByToken(context.Background(), tokenString)
 
 		// ASSERT
 		sCtx.Require().Error(err)
@@ -277,4 +457,3 @@ func (s *AuthUserServiceSuite) TestAuthUser_VerifyByToken(t provider.T) {
 		mockTokenMaker.AssertCalled(t, "VerifyToken", tokenString, token.UserRole)
 	})
 }
-*/
@@ -0,0 +1,159 @@
+package authuser
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidUserToken     = errors.New("token is invalid, expired or already used")
+	ErrNoEmail              = errors.New("user has no email")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+)
+
+func (s *authUser) VerifyEmail(ctx context.Context, token string) error {
+	user, err := s.useToken(ctx, token, models.PurposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+	verified, err := withPassword(user, user.GetHashedPassword(), true)
+	if err != nil {
+		return err
+	}
+	return s.userrep.Update(ctx, &verified)
+}
+
+func (s *authUser) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userrep.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.GetEmail() == "" {
+		return ErrNoEmail
+	}
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+	token, err := s.issueToken(ctx, user, models.PurposeVerifyEmail, s.config.EmailVerifyTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.notifier.EmailVerification(ctx, user, token)
+	return nil
+}
+
+func (s *authUser) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userrep.GetByEmail(ctx, email)
+	if errors.Is(err, userrep.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	token, err := s.issueToken(ctx, user, models.PurposeResetPassword, s.config.PasswordResetTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.notifier.PasswordReset(ctx, user, token)
+	return nil
+}
+
+func (s *authUser) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	// пароль проверяется и хешируется до использования токена, чтобы ошибка не сжигала ссылку
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+	hashedPassword, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user, err := s.useToken(ctx, token, models.PurposeResetPassword)
+	if err != nil {
+		return err
+	}
+	// переход по ссылке из письма подтверждает и саму почту
+	updated, err := withPassword(user, hashedPassword, true)
+	if err != nil {
+		return err
+	}
+	if err := s.userrep.Update(ctx, &updated); err != nil {
+		return err
+	}
+	if err := s.LogoutAll(ctx, user.GetID()); err != nil {
+		return err
+	}
+	s.notifier.PasswordChanged(ctx, &updated)
+	return nil
+}
+
+// issueToken сохраняет хеш нового токена для адреса пользователя и возвращает сам токен для письма
+func (s *authUser) issueToken(ctx context.Context, user *models.User, purpose models.TokenPurpose, ttl time.Duration) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	stored, err := models.NewUserToken(
+		uuid.New(),
+		user.GetID(),
+		purpose,
+		hashToken(token),
+		user.GetEmail(),
+		time.Now().UTC().Add(ttl),
+		false,
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := s.tokenRep.Add(ctx, stored); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// useToken проверяет токен из письма и помечает его использованным.
+// Токен недействителен, если после отправки письма пользователь сменил почту.
+func (s *authUser) useToken(ctx context.Context, token string, purpose models.TokenPurpose) (*models.User, error) {
+	stored, err := s.tokenRep.GetByHash(ctx, hashToken(token))
+	if errors.Is(err, usertokenrep.ErrUserTokenNotFound) {
+		return nil, ErrInvalidUserToken
+	} else if err != nil {
+		return nil, err
+	}
+	if stored.GetPurpose() != purpose || stored.IsUsed() || time.Now().After(stored.GetExpiresAt()) {
+		return nil, ErrInvalidUserToken
+	}
+	user, err := s.userrep.GetByID(ctx, stored.GetUserID())
+	if errors.Is(err, userrep.ErrUserNotFound) {
+		return nil, ErrInvalidUserToken
+	} else if err != nil {
+		return nil, err
+	}
+	if user.GetEmail() != stored.GetEmail() {
+		return nil, ErrInvalidUserToken
+	}
+	err = s.tokenRep.Use(ctx, stored.GetID())
+	if errors.Is(err, usertokenrep.ErrAlreadyUsed) {
+		return nil, ErrInvalidUserToken
+	} else if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// withPassword - копия пользователя с новым паролем и отметкой о подтверждении почты, модели неизменяемы
+func withPassword(user *models.User, hashedPassword string, emailVerified bool) (models.User, error) {
+	return models.NewUser(
+		user.GetID(),
+		user.GetUsername(),
+		user.GetLogin(),
+		hashedPassword,
+		user.GetRole(),
+		user.GetEmail(),
+		emailVerified,
+	)
+}
@@ -0,0 +1,188 @@
+package authuser_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmailSuite struct {
+	suite.Suite
+}
+
+func TestEmail(t *testing.T) {
+	suite.RunSuite(t, new(EmailSuite))
+}
+
+var emailTestRegister = reqresp.RegisterUserRequest{
+	Username: "anna",
+	Login:    "anna-login",
+	Email:    "Anna@Example.com",
+	Password: "password123",
+}
+
+// newEmailAuthUser - сервис поверх in-memory хранилища, письма попадают в мок уведомлений
+func newEmailAuthUser(t provider.StepCtx) (auth.AuthUser, userrep.UserRep, *notifier.MockNotifier) {
+	appCnfg := testobj.NewAppConfigMother().Default()
+	tokenMaker, err := token.NewTokenMaker(appCnfg)
+	t.Require().NoError(err)
+	hash, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Default())
+	t.Require().NoError(err)
+
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	mockNotifier := new(notifier.MockNotifier)
+	mockNotifier.On("UserRegistered", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockNotifier.On("EmailVerification", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockNotifier.On("PasswordReset", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockNotifier.On("PasswordChanged", mock.Anything, mock.Anything).Return()
+
+	authUserServ, err := auth.NewAuthUser(
+		appCnfg,
+		userRep,
+		refreshtokenrep.NewMemRefreshTokenRep(db),
+		revokedtokenrep.NewMemRevokedTokenRep(db),
+		usertokenrep.NewMemUserTokenRep(db),
+		tokenMaker,
+		hash,
+		passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default()),
+		mockNotifier,
+		mfa.NewMFA(appCnfg, mfarep.NewMemMFARep(db), userRep),
+	)
+	t.Require().NoError(err)
+	t.Require().NoError(authUserServ.RegisterUser(context.Background(), emailTestRegister))
+	return authUserServ, userRep, mockNotifier
+}
+
+// sentToken - токен из последнего письма method
+func sentToken(n *notifier.MockNotifier, method string) string {
+	var res string
+	for _, call := range n.Calls {
+		if call.Method == method {
+			res = call.Arguments.String(2)
+		}
+	}
+	return res
+}
+
+func registeredUser(t provider.StepCtx, userRep userrep.UserRep) *models.User {
+	user, err := userRep.GetByLogin(context.Background(), emailTestRegister.Login)
+	t.Require().NoError(err)
+	return user
+}
+
+func (s *EmailSuite) TestAuthUser_VerifyEmail(t provider.T) {
+	t.WithNewStep("link from welcome letter verifies email once", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, userRep, mockNotifier := newEmailAuthUser(sCtx)
+		user := registeredUser(sCtx, userRep)
+		sCtx.Require().Equal("anna@example.com", user.GetEmail())
+		sCtx.Require().False(user.IsEmailVerified())
+
+		verifyToken := sentToken(mockNotifier, "UserRegistered")
+		sCtx.Require().NoError(authUserServ.VerifyEmail(ctx, verifyToken))
+
+		sCtx.Assert().True(registeredUser(sCtx, userRep).IsEmailVerified())
+		sCtx.Assert().ErrorIs(authUserServ.VerifyEmail(ctx, verifyToken), auth.ErrInvalidUserToken)
+	})
+	t.WithNewStep("resend invalidates the previous link", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, userRep, mockNotifier := newEmailAuthUser(sCtx)
+		first := sentToken(mockNotifier, "UserRegistered")
+
+		sCtx.Require().NoError(authUserServ.RequestEmailVerification(ctx, registeredUser(sCtx, userRep).GetID()))
+
+		sCtx.Assert().ErrorIs(authUserServ.VerifyEmail(ctx, first), auth.ErrInvalidUserToken)
+		sCtx.Assert().NoError(authUserServ.VerifyEmail(ctx, sentToken(mockNotifier, "EmailVerification")))
+		err := authUserServ.RequestEmailVerification(ctx, registeredUser(sCtx, userRep).GetID())
+		sCtx.Assert().ErrorIs(err, auth.ErrEmailAlreadyVerified)
+	})
+	t.WithNewStep("link is bound to the address it was sent to", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, userRep, mockNotifier := newEmailAuthUser(sCtx)
+		user := registeredUser(sCtx, userRep)
+		changed, err := models.NewUser(user.GetID(), user.GetUsername(), user.GetLogin(),
+			user.GetHashedPassword(), user.GetRole(), "other@example.com", false)
+		sCtx.Require().NoError(err)
+		sCtx.Require().NoError(userRep.Update(ctx, &changed))
+
+		err = authUserServ.VerifyEmail(ctx, sentToken(mockNotifier, "UserRegistered"))
+
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidUserToken)
+	})
+	t.WithNewStep("unknown token", func(sCtx provider.StepCtx) {
+		authUserServ, _, _ := newEmailAuthUser(sCtx)
+
+		err := authUserServ.VerifyEmail(context.Background(), "unknown")
+
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidUserToken)
+	})
+}
+
+func (s *EmailSuite) TestAuthUser_ResetPassword(t provider.T) {
+	t.WithNewStep("new password works and sessions are revoked", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, userRep, mockNotifier := newEmailAuthUser(sCtx)
+		loginReq := reqresp.LoginUserRequest{Login: emailTestRegister.Login, Password: emailTestRegister.Password}
+		session, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		sCtx.Require().NoError(authUserServ.ForgotPassword(ctx, "ANNA@example.com"))
+		resetToken := sentToken(mockNotifier, "PasswordReset")
+		sCtx.Require().NoError(authUserServ.ResetPassword(ctx, resetToken, "new-password"))
+
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Assert().ErrorIs(err, hasher.ErrPassword)
+		_, err = authUserServ.LoginUser(ctx, reqresp.LoginUserRequest{Login: loginReq.Login, Password: "new-password"})
+		sCtx.Assert().NoError(err)
+		_, err = authUserServ.Refresh(ctx, session.RefreshToken)
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidRefreshToken)
+		sCtx.Assert().True(registeredUser(sCtx, userRep).IsEmailVerified())
+		mockNotifier.AssertCalled(t, "PasswordChanged", ctx, mock.Anything)
+
+		err = authUserServ.ResetPassword(ctx, resetToken, "third-password")
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidUserToken)
+	})
+	t.WithNewStep("weak password keeps the link", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, _, mockNotifier := newEmailAuthUser(sCtx)
+		sCtx.Require().NoError(authUserServ.ForgotPassword(ctx, emailTestRegister.Email))
+		resetToken := sentToken(mockNotifier, "PasswordReset")
+
+		sCtx.Assert().ErrorIs(authUserServ.ResetPassword(ctx, resetToken, "short"), auth.ErrWeakPassword)
+		sCtx.Assert().NoError(authUserServ.ResetPassword(ctx, resetToken, "new-password"))
+	})
+	t.WithNewStep("verify token cannot reset password", func(sCtx provider.StepCtx) {
+		authUserServ, _, mockNotifier := newEmailAuthUser(sCtx)
+
+		err := authUserServ.ResetPassword(context.Background(), sentToken(mockNotifier, "UserRegistered"), "new-password")
+
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidUserToken)
+	})
+	t.WithNewStep("unknown email is not revealed", func(sCtx provider.StepCtx) {
+		authUserServ, _, mockNotifier := newEmailAuthUser(sCtx)
+
+		err := authUserServ.ForgotPassword(context.Background(), "nobody@example.com")
+
+		sCtx.Assert().NoError(err)
+		mockNotifier.AssertNotCalled(t, "PasswordReset", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,37 @@
+package authuser
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/google/uuid"
+)
+
+func (s *authUser) Logout(ctx context.Context, payload tokenmaker.Payload, refreshToken string) error {
+	if refreshToken != "" {
+		stored, err := s.refreshRep.GetByHash(ctx, hashToken(refreshToken))
+		if err != nil && !errors.Is(err, refreshtokenrep.ErrRefreshTokenNotFound) {
+			return err
+		}
+		// неизвестный токен не мешает выходу, а чужой - признак ошибки клиента
+		if err == nil {
+			if stored.GetUserID() != payload.GetPersonID() {
+				return ErrInvalidRefreshToken
+			}
+			if err := s.refreshRep.RevokeFamily(ctx, stored.GetFamilyID()); err != nil {
+				return err
+			}
+		}
+	}
+	return s.revokedRep.Revoke(ctx, payload.GetID(), payload.GetExpiredAt())
+}
+
+func (s *authUser) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	if err := s.refreshRep.RevokeUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.revokedRep.RevokeUser(ctx, userID, time.Now().UTC())
+}
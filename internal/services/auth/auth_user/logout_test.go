@@ -0,0 +1,86 @@
+package authuser_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type LogoutSuite struct {
+	suite.Suite
+}
+
+func TestLogout(t *testing.T) {
+	suite.RunSuite(t, new(LogoutSuite))
+}
+
+func (s *LogoutSuite) TestAuthUser_Logout(t provider.T) {
+	t.WithNewStep("current session only", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		current, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		other, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		payload, err := authUserServ.VerifyByToken(ctx, current.AccessToken)
+		sCtx.Require().NoError(err)
+
+		err = authUserServ.Logout(ctx, *payload, current.RefreshToken)
+
+		sCtx.Require().NoError(err)
+		_, err = authUserServ.VerifyByToken(ctx, current.AccessToken)
+		sCtx.Assert().ErrorIs(err, auth.ErrRevokedToken)
+		_, err = authUserServ.Refresh(ctx, current.RefreshToken)
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidRefreshToken)
+		_, err = authUserServ.VerifyByToken(ctx, other.AccessToken)
+		sCtx.Assert().NoError(err)
+		_, err = authUserServ.Refresh(ctx, other.RefreshToken)
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("unknown refresh token is ignored", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		current, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		payload, err := authUserServ.VerifyByToken(ctx, current.AccessToken)
+		sCtx.Require().NoError(err)
+
+		err = authUserServ.Logout(ctx, *payload, "unknown")
+
+		sCtx.Require().NoError(err)
+		_, err = authUserServ.VerifyByToken(ctx, current.AccessToken)
+		sCtx.Assert().ErrorIs(err, auth.ErrRevokedToken)
+	})
+}
+
+func (s *LogoutSuite) TestAuthUser_LogoutAll(t provider.T) {
+	t.WithNewStep("all sessions are revoked, new login works", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		first, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		second, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		payload, err := authUserServ.VerifyByToken(ctx, first.AccessToken)
+		sCtx.Require().NoError(err)
+
+		err = authUserServ.LogoutAll(ctx, payload.GetPersonID())
+
+		sCtx.Require().NoError(err)
+		for _, tokens := range []auth.Tokens{first, second} {
+			_, err = authUserServ.VerifyByToken(ctx, tokens.AccessToken)
+			sCtx.Assert().ErrorIs(err, auth.ErrRevokedToken)
+			_, err = authUserServ.Refresh(ctx, tokens.RefreshToken)
+			sCtx.Assert().ErrorIs(err, auth.ErrInvalidRefreshToken)
+		}
+
+		fresh, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		_, err = authUserServ.VerifyByToken(ctx, fresh.AccessToken)
+		sCtx.Assert().NoError(err)
+	})
+}
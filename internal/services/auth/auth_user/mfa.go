@@ -0,0 +1,56 @@
+package authuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+)
+
+func (s *authUser) CompleteMFA(ctx context.Context, mfaToken string, code string) (Tokens, error) {
+	payload, err := s.verifyMFAToken(ctx, mfaToken)
+	if err != nil {
+		return Tokens{}, err
+	}
+	user, err := s.userrep.GetByID(ctx, payload.GetPersonID())
+	if err != nil {
+		return Tokens{}, err
+	}
+	err = s.mfa.Verify(ctx, user.GetID(), code)
+	if errors.Is(err, mfa.ErrMFANotEnabled) {
+		// второй фактор выключили после первого шага, нужно войти заново
+		return Tokens{}, fmt.Errorf("%w: %w", ErrInvalidMFAToken, err)
+	} else if err != nil {
+		return Tokens{}, err
+	}
+	if err := s.revokedRep.Revoke(ctx, payload.GetID(), payload.GetExpiredAt()); err != nil {
+		return Tokens{}, err
+	}
+	return s.issueTokens(ctx, user)
+}
+
+func (s *authUser) MFAUser(ctx context.Context, mfaToken string) (*models.User, error) {
+	payload, err := s.verifyMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.userrep.GetByID(ctx, payload.GetPersonID())
+}
+
+func (s *authUser) verifyMFAToken(ctx context.Context, mfaToken string) (*tokenmaker.Payload, error) {
+	payload, err := s.tokenMaker.VerifyToken(mfaToken, tokenmaker.MFAPendingRole)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMFAToken, err)
+	}
+	revoked, err := s.revokedRep.IsRevoked(ctx, payload.GetID(), payload.GetPersonID(), payload.GetIssuedAt())
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMFAToken, ErrRevokedToken)
+	}
+	return payload, nil
+}
@@ -0,0 +1,137 @@
+package authuser_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MFASuite struct {
+	suite.Suite
+}
+
+func TestMFA(t *testing.T) {
+	suite.RunSuite(t, new(MFASuite))
+}
+
+// newMFAAuthUser - сервис с одним пользователем, у которого включен второй фактор.
+// Возвращает секрет, чтобы тест мог получить код приложения.
+func newMFAAuthUser(t provider.StepCtx) (auth.AuthUser, reqresp.LoginUserRequest, string, []string) {
+	ctx := context.Background()
+	appCnfg := testobj.NewAppConfigMother().Default()
+	tokenMaker, err := token.NewTokenMaker(appCnfg)
+	t.Require().NoError(err)
+	hash, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Default())
+	t.Require().NoError(err)
+	hashedPassword, err := hash.HashPassword(refreshTestPassword)
+	t.Require().NoError(err)
+
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	user := testobj.NewUserMother().UserWithPswdHash(uuid.New(), hashedPassword)
+	t.Require().NoError(userRep.Add(ctx, &user))
+	mfaServ := mfa.NewMFA(appCnfg, mfarep.NewMemMFARep(db), userRep)
+	enrollment, err := mfaServ.Enroll(ctx, user.GetID())
+	t.Require().NoError(err)
+	confirmation, err := mfa.TOTPCode(enrollment.Secret, time.Now())
+	t.Require().NoError(err)
+	t.Require().NoError(mfaServ.Confirm(ctx, user.GetID(), confirmation))
+
+	authUserServ, err := auth.NewAuthUser(
+		appCnfg,
+		userRep,
+		refreshtokenrep.NewMemRefreshTokenRep(db),
+		revokedtokenrep.NewMemRevokedTokenRep(db),
+		usertokenrep.NewMemUserTokenRep(db),
+		tokenMaker,
+		hash,
+		passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default()),
+		new(notifier.MockNotifier),
+		mfaServ,
+	)
+	t.Require().NoError(err)
+	loginReq := reqresp.LoginUserRequest{Login: user.GetLogin(), Password: refreshTestPassword}
+	return authUserServ, loginReq, enrollment.Secret, enrollment.RecoveryCodes
+}
+
+// nextCode - код следующего шага, текущий уже использован при подтверждении
+func nextCode(t provider.StepCtx, secret string) string {
+	code, err := mfa.TOTPCode(secret, time.Now().Add(30*time.Second))
+	t.Require().NoError(err)
+	return code
+}
+
+func (s *MFASuite) TestAuthUser_CompleteMFA(t provider.T) {
+	t.WithNewStep("password alone gives only the mfa token", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq, secret, _ := newMFAAuthUser(sCtx)
+
+		first, err := authUserServ.LoginUser(ctx, loginReq)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(first.AccessToken)
+		sCtx.Assert().Empty(first.RefreshToken)
+		_, err = authUserServ.VerifyByToken(ctx, first.MFAToken)
+		sCtx.Assert().Error(err, "mfa token is not an access token")
+
+		tokens, err := authUserServ.CompleteMFA(ctx, first.MFAToken, nextCode(sCtx, secret))
+		sCtx.Require().NoError(err)
+		_, err = authUserServ.VerifyByToken(ctx, tokens.AccessToken)
+		sCtx.Assert().NoError(err)
+		_, err = authUserServ.Refresh(ctx, tokens.RefreshToken)
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("mfa token is single use", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq, _, recoveryCodes := newMFAAuthUser(sCtx)
+		first, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.CompleteMFA(ctx, first.MFAToken, recoveryCodes[0])
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.CompleteMFA(ctx, first.MFAToken, recoveryCodes[1])
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidMFAToken)
+	})
+	t.WithNewStep("wrong code keeps the mfa token", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq, secret, _ := newMFAAuthUser(sCtx)
+		first, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.CompleteMFA(ctx, first.MFAToken, "ABCDE-FGHIJ")
+		sCtx.Require().ErrorIs(err, auth.ErrInvalidMFACode)
+
+		_, err = authUserServ.CompleteMFA(ctx, first.MFAToken, nextCode(sCtx, secret))
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("access token cannot complete mfa", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		tokens, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		sCtx.Require().Empty(tokens.MFAToken)
+
+		_, err = authUserServ.CompleteMFA(ctx, tokens.AccessToken, "123456")
+
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidMFAToken)
+	})
+}
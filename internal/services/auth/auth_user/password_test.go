@@ -0,0 +1,76 @@
+package authuser_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type PasswordSuite struct {
+	suite.Suite
+}
+
+func TestPassword(t *testing.T) {
+	suite.RunSuite(t, new(PasswordSuite))
+}
+
+func (s *PasswordSuite) TestAuthUser_RehashOnLogin(t provider.T) {
+	t.WithNewStep("bcrypt hash is upgraded to argon2id", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		appCnfg := testobj.NewAppConfigMother().Default()
+		tokenMaker, err := token.NewTokenMaker(appCnfg)
+		sCtx.Require().NoError(err)
+		oldHasher, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Bcrypt())
+		sCtx.Require().NoError(err)
+		newHasher, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Default())
+		sCtx.Require().NoError(err)
+		bcryptHash, err := oldHasher.HashPassword(refreshTestPassword)
+		sCtx.Require().NoError(err)
+
+		db := memdb.NewDB()
+		userRep := userrep.NewMemUserRep(db)
+		user := testobj.NewUserMother().UserWithPswdHash(uuid.New(), bcryptHash)
+		sCtx.Require().NoError(userRep.Add(ctx, &user))
+		authUserServ, err := auth.NewAuthUser(
+			appCnfg,
+			userRep,
+			refreshtokenrep.NewMemRefreshTokenRep(db),
+			revokedtokenrep.NewMemRevokedTokenRep(db),
+			usertokenrep.NewMemUserTokenRep(db),
+			tokenMaker,
+			newHasher,
+			testPolicy,
+			new(notifier.MockNotifier),
+			mfa.NewMFA(appCnfg, mfarep.NewMemMFARep(db), userRep),
+		)
+		sCtx.Require().NoError(err)
+		loginReq := reqresp.LoginUserRequest{Login: user.GetLogin(), Password: refreshTestPassword}
+
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		stored, err := userRep.GetByID(ctx, user.GetID())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(strings.HasPrefix(stored.GetHashedPassword(), "$argon2id$"), stored.GetHashedPassword())
+		sCtx.Assert().False(newHasher.NeedsRehash(stored.GetHashedPassword()))
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Assert().NoError(err)
+	})
+}
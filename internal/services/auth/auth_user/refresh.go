@@ -0,0 +1,106 @@
+package authuser
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	tokenmaker "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/google/uuid"
+)
+
+// tokenBytes - размер случайных refresh-токенов и токенов из писем
+const tokenBytes = 32
+
+// Refresh - ротация refresh-токена. Повторное предъявление уже обмененного токена
+// означает, что он украден: отзывается все семейство, выданное при том же входе.
+func (s *authUser) Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+	current, err := s.refreshRep.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, refreshtokenrep.ErrRefreshTokenNotFound) {
+		return Tokens{}, ErrInvalidRefreshToken
+	} else if err != nil {
+		return Tokens{}, err
+	}
+	if current.IsRevoked() {
+		return Tokens{}, ErrInvalidRefreshToken
+	}
+	if current.IsRotated() {
+		return Tokens{}, s.revokeReused(ctx, current)
+	}
+	if time.Now().After(current.GetExpiresAt()) {
+		return Tokens{}, fmt.Errorf("%w: %w", ErrInvalidRefreshToken, tokenmaker.ErrExpiredToken)
+	}
+	// пользователь мог быть удален или сменить роль после выдачи токена
+	user, err := s.userrep.GetByID(ctx, current.GetUserID())
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	nextToken, next, err := s.newRefreshToken(current.GetFamilyID(), current.GetUserID())
+	if err != nil {
+		return Tokens{}, err
+	}
+	err = s.refreshRep.Rotate(ctx, current.GetID(), next)
+	if errors.Is(err, refreshtokenrep.ErrAlreadyRotated) {
+		// токен успели обменять параллельным запросом
+		return Tokens{}, s.revokeReused(ctx, current)
+	} else if err != nil {
+		return Tokens{}, err
+	}
+
+	accessToken, err := s.tokenMaker.CreateToken(user.GetID(), tokenmaker.RoleOf(user.GetRole()), s.config.AccessTokenDuration)
+	if err != nil {
+		return Tokens{}, err
+	}
+	return Tokens{AccessToken: accessToken, RefreshToken: nextToken}, nil
+}
+
+func (s *authUser) revokeReused(ctx context.Context, token *models.RefreshToken) error {
+	if err := s.refreshRep.RevokeFamily(ctx, token.GetFamilyID()); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// newRefreshToken возвращает случайный токен для клиента и запись с его хешем для хранилища
+func (s *authUser) newRefreshToken(familyID uuid.UUID, userID uuid.UUID) (string, *models.RefreshToken, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", nil, err
+	}
+	stored, err := models.NewRefreshToken(
+		uuid.New(),
+		familyID,
+		userID,
+		hashToken(token),
+		time.Now().UTC().Add(s.config.RefreshTokenDuration),
+		false,
+		false,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, stored, nil
+}
+
+// randomToken - случайный токен для клиента, 256 бит в base64url
+func randomToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken - у токена 256 бит энтропии, поэтому достаточно SHA-256 без соли
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,125 @@
+package authuser_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	refreshtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/refresh_token_rep"
+	revokedtokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/revoked_token_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	usertokenrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_token_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type RefreshSuite struct {
+	suite.Suite
+}
+
+func TestRefresh(t *testing.T) {
+	suite.RunSuite(t, new(RefreshSuite))
+}
+
+const refreshTestPassword = "password123"
+
+// newMemAuthUser - сервис поверх in-memory хранилища с одним зарегистрированным пользователем
+func newMemAuthUser(t provider.StepCtx, refreshDuration time.Duration) (auth.AuthUser, reqresp.LoginUserRequest) {
+	ctx := context.Background()
+	appCnfg := testobj.NewAppConfigMother().Default()
+	appCnfg.RefreshTokenDuration = refreshDuration
+
+	tokenMaker, err := token.NewTokenMaker(appCnfg)
+	t.Require().NoError(err)
+	hash, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Default())
+	t.Require().NoError(err)
+	hashedPassword, err := hash.HashPassword(refreshTestPassword)
+	t.Require().NoError(err)
+
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	user := testobj.NewUserMother().UserWithPswdHash(uuid.New(), hashedPassword)
+	t.Require().NoError(userRep.Add(ctx, &user))
+
+	authUserServ, err := auth.NewAuthUser(
+		appCnfg,
+		userRep,
+		refreshtokenrep.NewMemRefreshTokenRep(db),
+		revokedtokenrep.NewMemRevokedTokenRep(db),
+		usertokenrep.NewMemUserTokenRep(db),
+		tokenMaker,
+		hash,
+		passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default()),
+		new(notifier.MockNotifier),
+		mfa.NewMFA(appCnfg, mfarep.NewMemMFARep(db), userRep),
+	)
+	t.Require().NoError(err)
+	return authUserServ, reqresp.LoginUserRequest{Login: user.GetLogin(), Password: refreshTestPassword}
+}
+
+func (s *RefreshSuite) TestAuthUser_Refresh(t provider.T) {
+	t.WithNewStep("rotation issues a new pair", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		login, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		refreshed, err := authUserServ.Refresh(ctx, login.RefreshToken)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().NotEmpty(refreshed.AccessToken)
+		sCtx.Assert().NotEqual(login.RefreshToken, refreshed.RefreshToken)
+		_, err = authUserServ.VerifyByToken(ctx, refreshed.AccessToken)
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("reuse of rotated token revokes the family", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, time.Hour)
+		login, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		other, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+		refreshed, err := authUserServ.Refresh(ctx, login.RefreshToken)
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.Refresh(ctx, login.RefreshToken)
+		sCtx.Require().ErrorIs(err, auth.ErrRefreshTokenReused)
+
+		// легитимный токен того же семейства тоже отозван
+		_, err = authUserServ.Refresh(ctx, refreshed.RefreshToken)
+		sCtx.Assert().ErrorIs(err, auth.ErrInvalidRefreshToken)
+		// другие входы не затронуты
+		_, err = authUserServ.Refresh(ctx, other.RefreshToken)
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("expired token", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newMemAuthUser(sCtx, -time.Minute)
+		login, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.Refresh(ctx, login.RefreshToken)
+
+		sCtx.Require().ErrorIs(err, auth.ErrInvalidRefreshToken)
+		sCtx.Assert().ErrorIs(err, token.ErrExpiredToken)
+	})
+	t.WithNewStep("unknown token", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, _ := newMemAuthUser(sCtx, time.Hour)
+
+		_, err := authUserServ.Refresh(ctx, "unknown")
+
+		sCtx.Require().ErrorIs(err, auth.ErrInvalidRefreshToken)
+	})
+}
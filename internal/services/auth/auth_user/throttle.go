@@ -0,0 +1,75 @@
+package authuser
+
+import (
+	"context"
+	"errors"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+)
+
+// NewThrottledAuthUser ограничивает попытки входа в authu. Неудачей считается неверный пароль
+// и неизвестный логин, чтобы по ответам нельзя было отличить одно от другого. Адрес клиента
+// берется из контекста (loginthrottle.WithClientIP). Пока попытки запрещены, пароль не проверяется
+// и LoginUser возвращает *loginthrottle.ThrottledError. Неверный код второго фактора в CompleteMFA
+// считается неудачей того же логина, поэтому перебор кодов ограничен так же, как перебор паролей.
+func NewThrottledAuthUser(authu AuthUser, throttle loginthrottle.LoginThrottle) AuthUser {
+	return &throttledAuthUser{
+		AuthUser: authu,
+		throttle: throttle,
+	}
+}
+
+type throttledAuthUser struct {
+	AuthUser
+	throttle loginthrottle.LoginThrottle
+}
+
+func (s *throttledAuthUser) LoginUser(ctx context.Context, lur reqresp.LoginUserRequest) (Tokens, error) {
+	ip := loginthrottle.ClientIPFromContext(ctx)
+	if err := s.throttle.Check(ctx, lur.Login, ip); err != nil {
+		return Tokens{}, err
+	}
+	tokens, err := s.AuthUser.LoginUser(ctx, lur)
+	if errors.Is(err, hasher.ErrPassword) || errors.Is(err, ErrUserNotFound) {
+		if throttleErr := s.throttle.Failure(ctx, lur.Login, ip); throttleErr != nil {
+			return Tokens{}, throttleErr
+		}
+		return Tokens{}, err
+	} else if err != nil {
+		return Tokens{}, err
+	}
+	// пароль верный, но вход завершится только после второго фактора
+	if tokens.MFAToken != "" {
+		return tokens, nil
+	}
+	if err := s.throttle.Success(ctx, lur.Login); err != nil {
+		return Tokens{}, err
+	}
+	return tokens, nil
+}
+
+func (s *throttledAuthUser) CompleteMFA(ctx context.Context, mfaToken string, code string) (Tokens, error) {
+	user, err := s.AuthUser.MFAUser(ctx, mfaToken)
+	if err != nil {
+		return Tokens{}, err
+	}
+	ip := loginthrottle.ClientIPFromContext(ctx)
+	if err := s.throttle.Check(ctx, user.GetLogin(), ip); err != nil {
+		return Tokens{}, err
+	}
+	tokens, err := s.AuthUser.CompleteMFA(ctx, mfaToken, code)
+	if errors.Is(err, ErrInvalidMFACode) {
+		if throttleErr := s.throttle.Failure(ctx, user.GetLogin(), ip); throttleErr != nil {
+			return Tokens{}, throttleErr
+		}
+		return Tokens{}, err
+	} else if err != nil {
+		return Tokens{}, err
+	}
+	if err := s.throttle.Success(ctx, user.GetLogin()); err != nil {
+		return Tokens{}, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,111 @@
+package authuser_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type ThrottleSuite struct {
+	suite.Suite
+}
+
+func TestThrottle(t *testing.T) {
+	suite.RunSuite(t, new(ThrottleSuite))
+}
+
+// newThrottledAuthUser - сервис с одним пользователем, вход по логину блокируется после трех неудач
+func newThrottledAuthUser(t provider.StepCtx) (auth.AuthUser, reqresp.LoginUserRequest) {
+	authUserServ, loginReq := newMemAuthUser(t, time.Hour)
+	return auth.NewThrottledAuthUser(authUserServ, newTestThrottle()), loginReq
+}
+
+func newTestThrottle() loginthrottle.LoginThrottle {
+	policy := cnfg.ThrottlePolicy{
+		FreeAttempts:    1,
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 3,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+	return loginthrottle.NewLoginThrottle(
+		cnfg.ThrottleConfig{Login: policy, IP: policy},
+		loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()),
+	)
+}
+
+func (s *ThrottleSuite) TestAuthUser_ThrottledLogin(t provider.T) {
+	t.WithNewStep("correct password is rejected while delayed", func(sCtx provider.StepCtx) {
+		ctx := loginthrottle.WithClientIP(context.Background(), "10.0.0.1")
+		authUserServ, loginReq := newThrottledAuthUser(sCtx)
+		wrong := reqresp.LoginUserRequest{Login: loginReq.Login, Password: "wrong-password"}
+
+		_, err := authUserServ.LoginUser(ctx, wrong)
+		sCtx.Require().ErrorIs(err, hasher.ErrPassword)
+		_, err = authUserServ.LoginUser(ctx, wrong)
+		sCtx.Require().ErrorIs(err, hasher.ErrPassword)
+
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Assert().ErrorIs(err, loginthrottle.ErrTooManyAttempts)
+	})
+	t.WithNewStep("unknown logins count against the address", func(sCtx provider.StepCtx) {
+		ctx := loginthrottle.WithClientIP(context.Background(), "10.0.0.1")
+		authUserServ, loginReq := newThrottledAuthUser(sCtx)
+
+		for _, login := range []string{"nobody-1", "nobody-2"} {
+			_, err := authUserServ.LoginUser(ctx, reqresp.LoginUserRequest{Login: login, Password: "password"})
+			sCtx.Require().ErrorIs(err, auth.ErrUserNotFound)
+		}
+
+		_, err := authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Assert().ErrorIs(err, loginthrottle.ErrTooManyAttempts)
+		_, err = authUserServ.LoginUser(loginthrottle.WithClientIP(context.Background(), "10.0.0.2"), loginReq)
+		sCtx.Assert().NoError(err)
+	})
+	t.WithNewStep("successful login resets the login counter", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq := newThrottledAuthUser(sCtx)
+		wrong := reqresp.LoginUserRequest{Login: loginReq.Login, Password: "wrong-password"}
+
+		_, err := authUserServ.LoginUser(ctx, wrong)
+		sCtx.Require().ErrorIs(err, hasher.ErrPassword)
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		_, err = authUserServ.LoginUser(ctx, wrong)
+		sCtx.Require().ErrorIs(err, hasher.ErrPassword)
+		_, err = authUserServ.LoginUser(ctx, loginReq)
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *ThrottleSuite) TestAuthUser_ThrottledMFA(t provider.T) {
+	t.WithNewStep("wrong codes lock the login", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		authUserServ, loginReq, secret, _ := newMFAAuthUser(sCtx)
+		throttled := auth.NewThrottledAuthUser(authUserServ, newTestThrottle())
+		first, err := throttled.LoginUser(ctx, loginReq)
+		sCtx.Require().NoError(err)
+
+		for range 2 {
+			_, err = throttled.CompleteMFA(ctx, first.MFAToken, "ABCDE-FGHIJ")
+			sCtx.Require().ErrorIs(err, auth.ErrInvalidMFACode)
+		}
+
+		_, err = throttled.CompleteMFA(ctx, first.MFAToken, nextCode(sCtx, secret))
+		sCtx.Assert().ErrorIs(err, loginthrottle.ErrTooManyAttempts)
+		_, err = throttled.LoginUser(ctx, loginReq)
+		sCtx.Assert().ErrorIs(err, loginthrottle.ErrTooManyAttempts)
+	})
+}
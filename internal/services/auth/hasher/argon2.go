@@ -0,0 +1,101 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2Prefix  = "$argon2id$"
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// argon2Hasher - Argon2id (RFC 9106). Параметры записываются в хеш в формате PHC:
+// $argon2id$v=19$m=65536,t=3,p=2$<соль>$<ключ>, поэтому старые хеши проверяются
+// и после смены настроек.
+type argon2Hasher struct {
+	memory  uint32 // КиБ
+	time    uint32
+	threads uint8
+}
+
+// argon2Hash - разобранный хеш Argon2id
+type argon2Hash struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func (h *argon2Hasher) hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", ErrHash
+	}
+	key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, argon2KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix, argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2Hasher) check(password string, hashedPassword string) error {
+	parsed, err := parseArgon2(hashedPassword)
+	if err != nil {
+		return ErrPassword
+	}
+	key := argon2.IDKey([]byte(password), parsed.salt, parsed.time, parsed.memory, parsed.threads, uint32(len(parsed.key)))
+	if subtle.ConstantTimeCompare(key, parsed.key) != 1 {
+		return ErrPassword
+	}
+	return nil
+}
+
+func (h *argon2Hasher) recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, argon2Prefix)
+}
+
+func (h *argon2Hasher) upToDate(hashedPassword string) bool {
+	parsed, err := parseArgon2(hashedPassword)
+	return err == nil &&
+		parsed.memory == h.memory &&
+		parsed.time == h.time &&
+		parsed.threads == h.threads &&
+		len(parsed.salt) == argon2SaltLen &&
+		len(parsed.key) == argon2KeyLen
+}
+
+func parseArgon2(hashedPassword string) (argon2Hash, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", соль, ключ
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2Hash{}, fmt.Errorf("%w: not an argon2id hash", ErrPassword)
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2Hash{}, fmt.Errorf("%w: unsupported argon2 version", ErrPassword)
+	}
+	var res argon2Hash
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &res.memory, &res.time, &res.threads); err != nil {
+		return argon2Hash{}, fmt.Errorf("%w: argon2 parameters: %w", ErrPassword, err)
+	}
+	if res.time == 0 || res.threads == 0 || res.memory < 8*uint32(res.threads) {
+		return argon2Hash{}, fmt.Errorf("%w: argon2 parameters out of range", ErrPassword)
+	}
+	var err error
+	if res.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2Hash{}, fmt.Errorf("%w: argon2 salt: %w", ErrPassword, err)
+	}
+	if res.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(res.key) == 0 {
+		return argon2Hash{}, fmt.Errorf("%w: argon2 key", ErrPassword)
+	}
+	return res, nil
+}
@@ -2,45 +2,119 @@ package hasher
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Hasher interface {
-	HashPassword(password string) (string, error) // HashPassword возвращает bcrypt хэш пароля
+	HashPassword(password string) (string, error) // HashPassword возвращает хэш пароля алгоритмом из настроек
+	// CheckPassword проверяет пароль по хешу любого поддерживаемого алгоритма
 	CheckPassword(password string, hashedPassword string) error
+	// NeedsRehash - хеш получен другим алгоритмом или с другими параметрами, чем в настройках,
+	// и его стоит пересчитать, пока известен пароль
+	NeedsRehash(hashedPassword string) bool
 }
 
 var (
 	ErrHash          = errors.New("failed to hash password")
 	ErrPassword      = errors.New("error CompareHashAndPassword")
 	ErrEmptyPassword = errors.New("error the password cannot be empty")
+	ErrHasherConfig  = errors.New("hasher config")
 )
 
-func NewHasher() (Hasher, error) {
-	return &bcryptHasher{}, nil
+func NewHasher(config cnfg.PasswordConfig) (Hasher, error) {
+	argon := &argon2Hasher{
+		memory:  config.Argon2Memory,
+		time:    config.Argon2Time,
+		threads: config.Argon2Threads,
+	}
+	bcr := &bcryptHasher{
+		cost: config.BcryptCost,
+	}
+	var primary scheme
+	switch config.HashAlgorithm {
+	case cnfg.HashArgon2id:
+		primary = argon
+	case cnfg.HashBcrypt:
+		primary = bcr
+	default:
+		return nil, fmt.Errorf("%w: unknown algorithm %q", ErrHasherConfig, config.HashAlgorithm)
+	}
+	return &hasher{
+		primary: primary,
+		schemes: []scheme{argon, bcr},
+	}, nil
 }
 
-// ----------------
+// scheme - один алгоритм хеширования, формат хеша определяется по префиксу
+type scheme interface {
+	hash(password string) (string, error)
+	check(password string, hashedPassword string) error
+	recognizes(hashedPassword string) bool
+	// upToDate - хеш получен с текущими параметрами
+	upToDate(hashedPassword string) bool
+}
 
-type bcryptHasher struct {
+type hasher struct {
+	primary scheme
+	schemes []scheme
 }
 
-func (h *bcryptHasher) HashPassword(password string) (string, error) {
+func (h *hasher) HashPassword(password string) (string, error) {
 	if len(password) == 0 {
 		return "", ErrEmptyPassword
 	}
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return h.primary.hash(password)
+}
+
+func (h *hasher) CheckPassword(password string, hashedPassword string) error {
+	for _, s := range h.schemes {
+		if s.recognizes(hashedPassword) {
+			return s.check(password, hashedPassword)
+		}
+	}
+	return ErrPassword
+}
+
+func (h *hasher) NeedsRehash(hashedPassword string) bool {
+	return !h.primary.recognizes(hashedPassword) || !h.primary.upToDate(hashedPassword)
+}
+
+// ----------------
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (h *bcryptHasher) hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	if err != nil {
 		return "", ErrHash
 	}
 	return string(hashedPassword), nil
 }
 
-func (h *bcryptHasher) CheckPassword(password string, hashedPassword string) error {
+func (h *bcryptHasher) check(password string, hashedPassword string) error {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	if err != nil {
 		err = ErrPassword
 	}
 	return err
 }
+
+func (h *bcryptHasher) recognizes(hashedPassword string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *bcryptHasher) upToDate(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err == nil && cost == h.cost
+}
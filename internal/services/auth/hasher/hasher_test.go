@@ -1,9 +1,12 @@
 package hasher_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
 	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/stretchr/testify/require"
@@ -22,7 +25,7 @@ func (s *HasherSuite) BeforeEach(t provider.T) {
 	t.Epic("Password")
 	t.Feature("Hasher Service")
 
-	hasherServ, err := hasher.NewHasher()
+	hasherServ, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Default())
 	require.NoError(t, err)
 	s.hasherServ = hasherServ
 }
@@ -154,3 +157,69 @@ func (s *HasherSuite) TestHasher_SpecialCharactersPassword(t provider.T) {
 		}
 	})
 }
+
+func (s *HasherSuite) TestHasher_Argon2idFormat(t provider.T) {
+	t.WithNewStep("Параметры записаны в хеш", func(sCtx provider.StepCtx) {
+		hashedPassword, err := s.hasherServ.HashPassword("secure_password_123")
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=1024,t=1,p=1$"), hashedPassword)
+		sCtx.Assert().False(s.hasherServ.NeedsRehash(hashedPassword))
+	})
+	t.WithNewStep("Пароли длиннее 72 байт различаются", func(sCtx provider.StepCtx) {
+		prefix := strings.Repeat("a", 72)
+		hashedPassword, err := s.hasherServ.HashPassword(prefix + "1")
+		sCtx.Require().NoError(err)
+
+		err = s.hasherServ.CheckPassword(prefix+"2", hashedPassword)
+
+		sCtx.Assert().ErrorIs(err, hasher.ErrPassword)
+	})
+	t.WithNewStep("Испорченный хеш", func(sCtx provider.StepCtx) {
+		err := s.hasherServ.CheckPassword("password", "$argon2id$v=19$m=1024,t=1,p=1$bad")
+
+		sCtx.Assert().ErrorIs(err, hasher.ErrPassword)
+	})
+}
+
+func (s *HasherSuite) TestHasher_NeedsRehash(t provider.T) {
+	t.WithNewStep("Хеш bcrypt проверяется и требует пересчета", func(sCtx provider.StepCtx) {
+		bcryptHasher, err := hasher.NewHasher(testobj.NewPasswordConfigMother().Bcrypt())
+		sCtx.Require().NoError(err)
+		old, err := bcryptHasher.HashPassword("secure_password_123")
+		sCtx.Require().NoError(err)
+		sCtx.Require().False(bcryptHasher.NeedsRehash(old))
+
+		sCtx.Assert().NoError(s.hasherServ.CheckPassword("secure_password_123", old))
+		sCtx.Assert().ErrorIs(s.hasherServ.CheckPassword("wrong_password", old), hasher.ErrPassword)
+		sCtx.Assert().True(s.hasherServ.NeedsRehash(old))
+	})
+	t.WithNewStep("Изменились параметры Argon2id", func(sCtx provider.StepCtx) {
+		old, err := s.hasherServ.HashPassword("secure_password_123")
+		sCtx.Require().NoError(err)
+		config := testobj.NewPasswordConfigMother().Default()
+		config.Argon2Memory = 2048
+		stronger, err := hasher.NewHasher(config)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().True(stronger.NeedsRehash(old))
+		sCtx.Assert().NoError(stronger.CheckPassword("secure_password_123", old))
+	})
+	t.WithNewStep("Изменилась стоимость bcrypt", func(sCtx provider.StepCtx) {
+		config := testobj.NewPasswordConfigMother().Bcrypt()
+		cheap, err := hasher.NewHasher(config)
+		sCtx.Require().NoError(err)
+		old, err := cheap.HashPassword("secure_password_123")
+		sCtx.Require().NoError(err)
+		config.BcryptCost = 5
+		costly, err := hasher.NewHasher(config)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().True(costly.NeedsRehash(old))
+	})
+	t.WithNewStep("Неизвестный алгоритм в настройках", func(sCtx provider.StepCtx) {
+		_, err := hasher.NewHasher(cnfg.PasswordConfig{HashAlgorithm: "md5"})
+
+		sCtx.Assert().ErrorIs(err, hasher.ErrHasherConfig)
+	})
+}
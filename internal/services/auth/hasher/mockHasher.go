@@ -15,3 +15,8 @@ func (m *MockHasher) CheckPassword(password string, hashedPassword string) error
 	args := m.Called(password, hashedPassword)
 	return args.Error(0)
 }
+
+func (m *MockHasher) NeedsRehash(hashedPassword string) bool {
+	args := m.Called(hashedPassword)
+	return args.Bool(0)
+}
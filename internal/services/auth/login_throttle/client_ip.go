@@ -0,0 +1,16 @@
+package loginthrottle
+
+import "context"
+
+type clientIPContextKey struct{}
+
+// WithClientIP кладет адрес клиента в контекст запроса, по нему считаются попытки входа
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ClientIPFromContext - адрес клиента запроса, пусто если он не известен
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
@@ -0,0 +1,161 @@
+package loginthrottle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	"github.com/google/uuid"
+)
+
+// LoginThrottle ограничивает неудачные попытки входа отдельно по логину и по IP-адресу:
+// после нескольких неудач подряд следующая попытка откладывается с удвоением задержки,
+// после большего числа неудач вход блокируется, блокировка записывается в журнал
+type LoginThrottle interface {
+	// Check - *ThrottledError, если сейчас попытки входа под логином или с адреса запрещены
+	Check(ctx context.Context, login string, ip string) error
+	// Failure учитывает неудачную попытку и назначает задержку или блокировку
+	Failure(ctx context.Context, login string, ip string) error
+	// Success забывает неудачи логина. Неудачи адреса остаются, чтобы вход в свой аккаунт
+	// не позволял перебирать пароли к чужим.
+	Success(ctx context.Context, login string) error
+	// Lockouts - последние limit записей журнала блокировок, 0 - DefaultLockoutsLimit
+	Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error)
+}
+
+const (
+	DefaultLockoutsLimit = 50
+	MaxLockoutsLimit     = 500
+)
+
+var (
+	ErrLoginThrottle   = errors.New("LoginThrottle")
+	ErrTooManyAttempts = errors.New("too many login attempts")
+)
+
+// ThrottledError - попытки входа запрещены еще RetryAfter
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+func NewLoginThrottle(config cnfg.ThrottleConfig, attemptRep loginattemptrep.LoginAttemptRep) LoginThrottle {
+	return &loginThrottle{
+		policies: map[models.LoginScope]cnfg.ThrottlePolicy{
+			models.LoginScopeLogin: config.Login,
+			models.LoginScopeIP:    config.IP,
+		},
+		attemptRep: attemptRep,
+	}
+}
+
+type loginThrottle struct {
+	policies   map[models.LoginScope]cnfg.ThrottlePolicy
+	attemptRep loginattemptrep.LoginAttemptRep
+}
+
+// subject - логин или адрес, по которому считаются попытки
+type subject struct {
+	scope models.LoginScope
+	value string
+}
+
+func subjects(login string, ip string) []subject {
+	res := []subject{{scope: models.LoginScopeLogin, value: login}}
+	// адреса может не быть, если вход вызван не из HTTP-запроса
+	if ip != "" {
+		res = append(res, subject{scope: models.LoginScopeIP, value: ip})
+	}
+	return res
+}
+
+func (s *loginThrottle) Check(ctx context.Context, login string, ip string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, sub := range subjects(login, ip) {
+		counter, err := s.attemptRep.Get(ctx, sub.scope, sub.value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+		}
+		wait = max(wait, counter.BlockedUntil.Sub(now))
+	}
+	if wait > 0 {
+		return &ThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func (s *loginThrottle) Failure(ctx context.Context, login string, ip string) error {
+	now := time.Now()
+	for _, sub := range subjects(login, ip) {
+		policy := s.policies[sub.scope]
+		counter, err := s.attemptRep.AddFailure(ctx, sub.scope, sub.value, now, policy.Window)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+		}
+		if counter.Failures <= policy.FreeAttempts {
+			continue
+		}
+		until := now.Add(backoff(policy, counter.Failures))
+		if counter.Failures >= policy.LockoutAttempts {
+			until = now.Add(policy.LockoutDuration)
+			if err := s.lockout(ctx, sub, counter.Failures, until, now); err != nil {
+				return err
+			}
+		}
+		if err := s.attemptRep.Block(ctx, sub.scope, sub.value, until); err != nil {
+			return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+		}
+	}
+	return nil
+}
+
+func (s *loginThrottle) Success(ctx context.Context, login string) error {
+	if err := s.attemptRep.Reset(ctx, models.LoginScopeLogin, login); err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+	}
+	return nil
+}
+
+func (s *loginThrottle) Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error) {
+	if limit <= 0 {
+		limit = DefaultLockoutsLimit
+	}
+	res, err := s.attemptRep.Lockouts(ctx, min(limit, MaxLockoutsLimit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+	}
+	return res, nil
+}
+
+func (s *loginThrottle) lockout(ctx context.Context, sub subject, failures int, until time.Time, now time.Time) error {
+	lockout, err := models.NewLoginLockout(uuid.New(), sub.scope, sub.value, failures, until, now.UTC())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+	}
+	if err := s.attemptRep.AddLockout(ctx, lockout); err != nil {
+		return fmt.Errorf("%w: %w", ErrLoginThrottle, err)
+	}
+	return nil
+}
+
+// backoff - задержка после failures неудач подряд: BaseDelay после первой неудачи сверх бесплатных,
+// дальше вдвое больше за каждую, но не больше MaxDelay
+func backoff(policy cnfg.ThrottlePolicy, failures int) time.Duration {
+	delay := policy.BaseDelay
+	for i := policy.FreeAttempts + 1; i < failures && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, policy.MaxDelay)
+}
@@ -0,0 +1,133 @@
+package loginthrottle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	loginattemptrep "github.com/CakeForKit/CraftPlace.git/internal/repository/login_attempt_rep"
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	loginthrottle "github.com/CakeForKit/CraftPlace.git/internal/services/auth/login_throttle"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type LoginThrottleSuite struct {
+	suite.Suite
+}
+
+func TestLoginThrottle(t *testing.T) {
+	suite.RunSuite(t, new(LoginThrottleSuite))
+}
+
+func throttleConfig() cnfg.ThrottleConfig {
+	return cnfg.ThrottleConfig{
+		Login: cnfg.ThrottlePolicy{
+			FreeAttempts:    2,
+			BaseDelay:       time.Minute,
+			MaxDelay:        3 * time.Minute,
+			LockoutAttempts: 6,
+			LockoutDuration: time.Hour,
+			Window:          24 * time.Hour,
+		},
+		IP: cnfg.ThrottlePolicy{
+			FreeAttempts:    4,
+			BaseDelay:       time.Minute,
+			MaxDelay:        3 * time.Minute,
+			LockoutAttempts: 10,
+			LockoutDuration: 2 * time.Hour,
+			Window:          24 * time.Hour,
+		},
+	}
+}
+
+// failures делает n неудачных попыток и возвращает ошибку проверки после них
+func failures(t provider.StepCtx, throttle loginthrottle.LoginThrottle, login string, ip string, n int) error {
+	ctx := context.Background()
+	for range n {
+		t.Require().NoError(throttle.Failure(ctx, login, ip))
+	}
+	return throttle.Check(ctx, login, ip)
+}
+
+// retryAfter - срок из ошибки Check, 0 - попытки разрешены
+func retryAfter(t provider.StepCtx, err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+	var throttled *loginthrottle.ThrottledError
+	t.Require().True(errors.As(err, &throttled), err)
+	t.Require().ErrorIs(err, loginthrottle.ErrTooManyAttempts)
+	return throttled.RetryAfter
+}
+
+func (s *LoginThrottleSuite) TestLoginThrottle_Backoff(t provider.T) {
+	t.WithNewStep("free attempts, then doubling delay up to the maximum", func(sCtx provider.StepCtx) {
+		throttle := loginthrottle.NewLoginThrottle(throttleConfig(), loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()))
+
+		sCtx.Assert().Zero(retryAfter(sCtx, failures(sCtx, throttle, "anna", "10.0.0.1", 2)))
+		sCtx.Assert().InDelta(time.Minute, retryAfter(sCtx, failures(sCtx, throttle, "anna", "10.0.0.1", 1)), float64(time.Second))
+		sCtx.Assert().InDelta(2*time.Minute, retryAfter(sCtx, failures(sCtx, throttle, "anna", "10.0.0.1", 1)), float64(time.Second))
+		sCtx.Assert().InDelta(3*time.Minute, retryAfter(sCtx, failures(sCtx, throttle, "anna", "10.0.0.1", 1)), float64(time.Second))
+		// другой логин с другого адреса не затронут
+		sCtx.Assert().NoError(throttle.Check(context.Background(), "ivan", "10.0.0.2"))
+	})
+	t.WithNewStep("old failures are forgotten", func(sCtx provider.StepCtx) {
+		config := throttleConfig()
+		config.Login.Window = time.Nanosecond
+		config.IP.Window = time.Nanosecond
+		throttle := loginthrottle.NewLoginThrottle(config, loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()))
+
+		sCtx.Assert().NoError(failures(sCtx, throttle, "anna", "10.0.0.1", 5))
+	})
+}
+
+func (s *LoginThrottleSuite) TestLoginThrottle_Lockout(t provider.T) {
+	t.WithNewStep("login is locked and the lockout is recorded", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		throttle := loginthrottle.NewLoginThrottle(throttleConfig(), loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()))
+
+		err := failures(sCtx, throttle, "anna", "", 6)
+
+		sCtx.Assert().InDelta(time.Hour, retryAfter(sCtx, err), float64(time.Second))
+		lockouts, err := throttle.Lockouts(ctx, 0)
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(lockouts, 1)
+		sCtx.Assert().Equal(models.LoginScopeLogin, lockouts[0].GetScope())
+		sCtx.Assert().Equal("anna", lockouts[0].GetSubject())
+		sCtx.Assert().Equal(6, lockouts[0].GetFailures())
+	})
+	t.WithNewStep("address is locked across logins", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		throttle := loginthrottle.NewLoginThrottle(throttleConfig(), loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()))
+		for i := range 10 {
+			sCtx.Require().NoError(throttle.Failure(ctx, string(rune('a'+i))+"-login", "10.0.0.1"))
+		}
+
+		err := throttle.Check(ctx, "new-login", "10.0.0.1")
+
+		sCtx.Assert().InDelta(2*time.Hour, retryAfter(sCtx, err), float64(time.Second))
+		sCtx.Assert().NoError(throttle.Check(ctx, "new-login", "10.0.0.2"))
+		lockouts, err := throttle.Lockouts(ctx, 0)
+		sCtx.Require().NoError(err)
+		sCtx.Require().Len(lockouts, 1)
+		sCtx.Assert().Equal(models.LoginScopeIP, lockouts[0].GetScope())
+	})
+}
+
+func (s *LoginThrottleSuite) TestLoginThrottle_Success(t provider.T) {
+	t.WithNewStep("success forgets login failures but not address failures", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		throttle := loginthrottle.NewLoginThrottle(throttleConfig(), loginattemptrep.NewMemLoginAttemptRep(memdb.NewDB()))
+		sCtx.Require().NoError(failures(sCtx, throttle, "anna", "10.0.0.1", 2))
+
+		sCtx.Require().NoError(throttle.Success(ctx, "anna"))
+
+		// у логина снова две бесплатные попытки, а неудачи адреса продолжают копиться
+		sCtx.Assert().NoError(failures(sCtx, throttle, "anna", "10.0.0.1", 2))
+		sCtx.Assert().Error(failures(sCtx, throttle, "ivan", "10.0.0.1", 1))
+	})
+}
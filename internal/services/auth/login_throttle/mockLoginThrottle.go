@@ -0,0 +1,35 @@
+package loginthrottle
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginThrottle struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottle) Check(ctx context.Context, login string, ip string) error {
+	args := m.Called(ctx, login, ip)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottle) Failure(ctx context.Context, login string, ip string) error {
+	args := m.Called(ctx, login, ip)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottle) Success(ctx context.Context, login string) error {
+	args := m.Called(ctx, login)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottle) Lockouts(ctx context.Context, limit int) ([]*models.LoginLockout, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LoginLockout), args.Error(1)
+}
@@ -0,0 +1,196 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	"github.com/google/uuid"
+)
+
+// MFA - второй фактор входа: TOTP из приложения-аутентификатора (RFC 6238)
+// или одноразовый код восстановления на случай потери телефона
+type MFA interface {
+	// Enroll начинает настройку: новый секрет и коды восстановления. Прежняя неподтвержденная
+	// настройка заменяется. Второй фактор начнет действовать после Confirm.
+	Enroll(ctx context.Context, userID uuid.UUID) (Enrollment, error)
+	// Confirm включает второй фактор, если code - текущий код приложения
+	Confirm(ctx context.Context, userID uuid.UUID, code string) error
+	// Disable выключает второй фактор по коду приложения или коду восстановления
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	Enabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// Verify проверяет код при входе. Каждый код принимается один раз.
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+// Enrollment - данные для приложения-аутентификатора. ProvisioningURI кодируется клиентом в QR-код,
+// Secret - для ручного ввода. Коды восстановления показываются один раз, сервер хранит только хеши.
+type Enrollment struct {
+	Secret          string
+	ProvisioningURI string
+	RecoveryCodes   []string
+}
+
+var (
+	ErrMFA               = errors.New("MFA")
+	ErrInvalidCode       = errors.New("invalid mfa code")
+	ErrMFANotEnrolled    = errors.New("mfa enrollment not started")
+	ErrMFANotEnabled     = errors.New("mfa is not enabled")
+	ErrMFAAlreadyEnabled = errors.New("mfa is already enabled")
+)
+
+func NewMFA(config cnfg.AppConfig, mfaRep mfarep.MFARep, userRep userrep.UserRep) MFA {
+	return &mfa{
+		issuer:  config.MFAIssuer,
+		mfaRep:  mfaRep,
+		userRep: userRep,
+	}
+}
+
+type mfa struct {
+	issuer  string
+	mfaRep  mfarep.MFARep
+	userRep userrep.UserRep
+}
+
+func (s *mfa) Enroll(ctx context.Context, userID uuid.UUID) (Enrollment, error) {
+	current, err := s.get(ctx, userID)
+	if err != nil && !errors.Is(err, ErrMFANotEnrolled) {
+		return Enrollment{}, err
+	}
+	if current != nil && current.IsEnabled() {
+		return Enrollment{}, ErrMFAAlreadyEnabled
+	}
+	user, err := s.userRep.GetByID(ctx, userID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("%w: %w", ErrMFA, err)
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("%w: %w", ErrMFA, err)
+	}
+	enrolled, err := models.NewUserMFA(userID, secret, false, 0, hashes)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("%w: %w", ErrMFA, err)
+	}
+	if err := s.mfaRep.Save(ctx, enrolled); err != nil {
+		return Enrollment{}, err
+	}
+	return Enrollment{
+		Secret:          secret,
+		ProvisioningURI: s.provisioningURI(user.GetLogin(), secret),
+		RecoveryCodes:   codes,
+	}, nil
+}
+
+func (s *mfa) Confirm(ctx context.Context, userID uuid.UUID, code string) error {
+	current, err := s.get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if current.IsEnabled() {
+		return ErrMFAAlreadyEnabled
+	}
+	key, err := decodeSecret(current.GetSecret())
+	if err != nil {
+		return err
+	}
+	step, ok := matchStep(key, code, time.Now())
+	if !ok {
+		return ErrInvalidCode
+	}
+	// код подтверждения тоже считается использованным
+	enabled, err := models.NewUserMFA(userID, current.GetSecret(), true, step, current.GetRecoveryHashes())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMFA, err)
+	}
+	return s.mfaRep.Save(ctx, enabled)
+}
+
+func (s *mfa) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.mfaRep.Delete(ctx, userID)
+}
+
+func (s *mfa) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	current, err := s.get(ctx, userID)
+	if errors.Is(err, ErrMFANotEnrolled) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return current.IsEnabled(), nil
+}
+
+func (s *mfa) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	current, err := s.get(ctx, userID)
+	if errors.Is(err, ErrMFANotEnrolled) {
+		return ErrMFANotEnabled
+	} else if err != nil {
+		return err
+	}
+	if !current.IsEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	if !isTOTPCode(code) {
+		err := s.mfaRep.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+		if errors.Is(err, mfarep.ErrRecoveryCodeNotFound) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+	key, err := decodeSecret(current.GetSecret())
+	if err != nil {
+		return err
+	}
+	step, ok := matchStep(key, code, time.Now())
+	if !ok {
+		return ErrInvalidCode
+	}
+	// шаг фиксируется атомарно, поэтому перехваченный код нельзя повторить даже параллельным запросом
+	err = s.mfaRep.UseStep(ctx, userID, step)
+	if errors.Is(err, mfarep.ErrStepUsed) {
+		return ErrInvalidCode
+	}
+	return err
+}
+
+func (s *mfa) get(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	current, err := s.mfaRep.Get(ctx, userID)
+	if errors.Is(err, mfarep.ErrMFANotFound) {
+		return nil, ErrMFANotEnrolled
+	}
+	return current, err
+}
+
+// provisioningURI - формат Key URI, который понимают Google Authenticator и совместимые приложения
+func (s *mfa) provisioningURI(login string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", s.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(totpDigits))
+	query.Set("period", strconv.Itoa(int(totpPeriod/time.Second)))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + s.issuer + ":" + login,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
@@ -0,0 +1,165 @@
+package mfa_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	memdb "github.com/CakeForKit/CraftPlace.git/internal/repository/mem_db"
+	mfarep "github.com/CakeForKit/CraftPlace.git/internal/repository/mfa_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/mfa"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type MFASuite struct {
+	suite.Suite
+}
+
+func TestMFA(t *testing.T) {
+	suite.RunSuite(t, new(MFASuite))
+}
+
+// newMemMFA - сервис поверх in-memory хранилища с одним пользователем
+func newMemMFA(t provider.StepCtx) (mfa.MFA, uuid.UUID) {
+	db := memdb.NewDB()
+	userRep := userrep.NewMemUserRep(db)
+	user := testobj.NewUserMother().DefaultUserP(uuid.New())
+	t.Require().NoError(userRep.Add(context.Background(), user))
+	return mfa.NewMFA(testobj.NewAppConfigMother().Default(), mfarep.NewMemMFARep(db), userRep), user.GetID()
+}
+
+// enabledMFA - включенный второй фактор. Код подтверждения уже использован,
+// поэтому для входа код берется со следующего шага.
+func enabledMFA(t provider.StepCtx) (mfa.MFA, uuid.UUID, mfa.Enrollment) {
+	ctx := context.Background()
+	mfaServ, userID := newMemMFA(t)
+	enrollment, err := mfaServ.Enroll(ctx, userID)
+	t.Require().NoError(err)
+	t.Require().NoError(mfaServ.Confirm(ctx, userID, code(t, enrollment.Secret, time.Now())))
+	return mfaServ, userID, enrollment
+}
+
+func code(t provider.StepCtx, secret string, at time.Time) string {
+	res, err := mfa.TOTPCode(secret, at)
+	t.Require().NoError(err)
+	return res
+}
+
+func (s *MFASuite) TestMFA_TOTPCode(t provider.T) {
+	// тестовые векторы RFC 6238 для SHA-1, ключ - ASCII "12345678901234567890"
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range vectors {
+		t.WithNewStep(expected, func(sCtx provider.StepCtx) {
+			sCtx.Assert().Equal(expected, code(sCtx, secret, time.Unix(unix, 0)))
+		})
+	}
+	t.WithNewStep("secret is case insensitive", func(sCtx provider.StepCtx) {
+		sCtx.Assert().Equal("287082", code(sCtx, strings.ToLower(secret), time.Unix(59, 0)))
+	})
+}
+
+func (s *MFASuite) TestMFA_Enroll(t provider.T) {
+	t.WithNewStep("uri for authenticator app", func(sCtx provider.StepCtx) {
+		mfaServ, userID := newMemMFA(sCtx)
+
+		enrollment, err := mfaServ.Enroll(context.Background(), userID)
+
+		sCtx.Require().NoError(err)
+		uri, err := url.Parse(enrollment.ProvisioningURI)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("otpauth", uri.Scheme)
+		sCtx.Assert().Equal("totp", uri.Host)
+		sCtx.Assert().Equal(enrollment.Secret, uri.Query().Get("secret"))
+		sCtx.Assert().Equal("6", uri.Query().Get("digits"))
+		sCtx.Assert().Len(enrollment.RecoveryCodes, 10)
+	})
+	t.WithNewStep("not enabled until confirmed", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mfaServ, userID := newMemMFA(sCtx)
+		enrollment, err := mfaServ.Enroll(ctx, userID)
+		sCtx.Require().NoError(err)
+
+		enabled, err := mfaServ.Enabled(ctx, userID)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().False(enabled)
+		err = mfaServ.Verify(ctx, userID, code(sCtx, enrollment.Secret, time.Now()))
+		sCtx.Assert().ErrorIs(err, mfa.ErrMFANotEnabled)
+
+		sCtx.Assert().ErrorIs(mfaServ.Confirm(ctx, userID, "000000"), mfa.ErrInvalidCode)
+		sCtx.Require().NoError(mfaServ.Confirm(ctx, userID, code(sCtx, enrollment.Secret, time.Now())))
+		enabled, err = mfaServ.Enabled(ctx, userID)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(enabled)
+	})
+	t.WithNewStep("enabled mfa is not replaced", func(sCtx provider.StepCtx) {
+		mfaServ, userID, _ := enabledMFA(sCtx)
+
+		_, err := mfaServ.Enroll(context.Background(), userID)
+
+		sCtx.Assert().ErrorIs(err, mfa.ErrMFAAlreadyEnabled)
+	})
+}
+
+func (s *MFASuite) TestMFA_Verify(t provider.T) {
+	t.WithNewStep("code is accepted once", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mfaServ, userID, enrollment := enabledMFA(sCtx)
+		next := code(sCtx, enrollment.Secret, time.Now().Add(30*time.Second))
+
+		sCtx.Require().NoError(mfaServ.Verify(ctx, userID, next))
+		sCtx.Assert().ErrorIs(mfaServ.Verify(ctx, userID, next), mfa.ErrInvalidCode)
+	})
+	t.WithNewStep("confirmation code cannot be replayed", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mfaServ, userID := newMemMFA(sCtx)
+		enrollment, err := mfaServ.Enroll(ctx, userID)
+		sCtx.Require().NoError(err)
+		confirmation := code(sCtx, enrollment.Secret, time.Now())
+		sCtx.Require().NoError(mfaServ.Confirm(ctx, userID, confirmation))
+
+		err = mfaServ.Verify(ctx, userID, confirmation)
+
+		sCtx.Assert().ErrorIs(err, mfa.ErrInvalidCode)
+	})
+	t.WithNewStep("code outside the skew window", func(sCtx provider.StepCtx) {
+		mfaServ, userID, enrollment := enabledMFA(sCtx)
+
+		err := mfaServ.Verify(context.Background(), userID, code(sCtx, enrollment.Secret, time.Now().Add(5*time.Minute)))
+
+		sCtx.Assert().ErrorIs(err, mfa.ErrInvalidCode)
+	})
+	t.WithNewStep("recovery code is accepted once in any case", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mfaServ, userID, enrollment := enabledMFA(sCtx)
+		recovery := enrollment.RecoveryCodes[3]
+
+		sCtx.Require().NoError(mfaServ.Verify(ctx, userID, strings.ToLower(strings.ReplaceAll(recovery, "-", ""))))
+		sCtx.Assert().ErrorIs(mfaServ.Verify(ctx, userID, recovery), mfa.ErrInvalidCode)
+		sCtx.Assert().NoError(mfaServ.Verify(ctx, userID, enrollment.RecoveryCodes[4]))
+	})
+	t.WithNewStep("disable requires a valid code", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mfaServ, userID, enrollment := enabledMFA(sCtx)
+
+		sCtx.Assert().ErrorIs(mfaServ.Disable(ctx, userID, "AAAAA-AAAAA"), mfa.ErrInvalidCode)
+		sCtx.Require().NoError(mfaServ.Disable(ctx, userID, enrollment.RecoveryCodes[0]))
+
+		enabled, err := mfaServ.Enabled(ctx, userID)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().False(enabled)
+	})
+}
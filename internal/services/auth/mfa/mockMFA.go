@@ -0,0 +1,37 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMFA struct {
+	mock.Mock
+}
+
+func (m *MockMFA) Enroll(ctx context.Context, userID uuid.UUID) (Enrollment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(Enrollment), args.Error(1)
+}
+
+func (m *MockMFA) Confirm(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockMFA) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockMFA) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFA) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
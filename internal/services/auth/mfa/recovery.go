@@ -0,0 +1,39 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	recoveryCodesCount = 10
+	// recoveryCodeLen - символов base32 в коде, 50 бит
+	recoveryCodeLen = 10
+	recoveryBytes   = 7
+)
+
+// newRecoveryCodes - коды для пользователя в виде XXXXX-XXXXX и их хеши для хранилища
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for range recoveryCodesCount {
+		buf := make([]byte, recoveryBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := secretEncoding.EncodeToString(buf)[:recoveryCodeLen]
+		codes = append(codes, raw[:recoveryCodeLen/2]+"-"+raw[recoveryCodeLen/2:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode - у кода 50 случайных бит и он одноразовый, поэтому SHA-256 без соли достаточно.
+// Регистр, пробелы и дефисы не важны, чтобы код можно было ввести как удобно.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,38 @@
+package ownerpolicy
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOwnerPolicy struct {
+	mock.Mock
+}
+
+func (m *MockOwnerPolicy) AuthorizeShop(ctx context.Context, shopID uuid.UUID, action auth.Action) (*models.Shop, error) {
+	args := m.Called(ctx, shopID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Shop), args.Error(1)
+}
+
+func (m *MockOwnerPolicy) AuthorizeProduct(ctx context.Context, productID uuid.UUID, action auth.Action) (*models.Product, error) {
+	args := m.Called(ctx, productID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockOwnerPolicy) AuthorizePost(ctx context.Context, postID uuid.UUID, action auth.Action) (*models.Post, error) {
+	args := m.Called(ctx, postID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
@@ -0,0 +1,107 @@
+package ownerpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	"github.com/google/uuid"
+)
+
+// OwnerPolicy - изменять магазин, его товары и посты может владелец магазина,
+// а чужие записи - только роль, которой действие разрешено в AuthZ.Can (например, модератор удаляет).
+// Методы возвращают запись, если действие action разрешено пользователю из контекста,
+// иначе auth.ErrNotAuthZ / auth.ErrHasNoRights или ошибку "не найдено" репозитория.
+// Добавление товара или поста в магазин - это auth.ActionUpdate магазина.
+type OwnerPolicy interface {
+	AuthorizeShop(ctx context.Context, shopID uuid.UUID, action auth.Action) (*models.Shop, error)
+	AuthorizeProduct(ctx context.Context, productID uuid.UUID, action auth.Action) (*models.Product, error)
+	AuthorizePost(ctx context.Context, postID uuid.UUID, action auth.Action) (*models.Post, error)
+}
+
+func NewOwnerPolicy(
+	authz auth.AuthZ,
+	shopRep shoprep.ShopRep,
+	productRep productrep.ProductRep,
+	postRep postrep.PostRep,
+) OwnerPolicy {
+	return &ownerPolicy{
+		authz:      authz,
+		shopRep:    shopRep,
+		productRep: productRep,
+		postRep:    postRep,
+	}
+}
+
+type ownerPolicy struct {
+	authz      auth.AuthZ
+	shopRep    shoprep.ShopRep
+	productRep productrep.ProductRep
+	postRep    postrep.PostRep
+}
+
+func (p *ownerPolicy) AuthorizeShop(ctx context.Context, shopID uuid.UUID, action auth.Action) (*models.Shop, error) {
+	if _, err := p.authz.UserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+	shop, err := p.shopRep.GetByID(ctx, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkOwner(ctx, shop, action, auth.ResourceShop); err != nil {
+		return nil, err
+	}
+	return shop, nil
+}
+
+func (p *ownerPolicy) AuthorizeProduct(ctx context.Context, productID uuid.UUID, action auth.Action) (*models.Product, error) {
+	// пользователь проверяется до обращения к товару, чтобы анонимный запрос не узнал о его существовании
+	if _, err := p.authz.UserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+	product, err := p.productRep.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkShopOwner(ctx, product.GetShopID(), action, auth.ResourceProduct); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (p *ownerPolicy) AuthorizePost(ctx context.Context, postID uuid.UUID, action auth.Action) (*models.Post, error) {
+	if _, err := p.authz.UserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+	post, err := p.postRep.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkShopOwner(ctx, post.GetShopID(), action, auth.ResourcePost); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (p *ownerPolicy) checkShopOwner(ctx context.Context, shopID uuid.UUID, action auth.Action, resource auth.Resource) error {
+	shop, err := p.shopRep.GetByID(ctx, shopID)
+	if err != nil {
+		return err
+	}
+	return p.checkOwner(ctx, shop, action, resource)
+}
+
+func (p *ownerPolicy) checkOwner(ctx context.Context, shop *models.Shop, action auth.Action, resource auth.Resource) error {
+	userID, err := p.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if shop.GetUserID() == userID || p.authz.Can(ctx, action, resource) {
+		return nil
+	}
+	return fmt.Errorf("%w: shop %s belongs to another user", auth.ErrHasNoRights, shop.GetID())
+}
@@ -0,0 +1,148 @@
+package ownerpolicy_test
+
+import (
+	"context"
+	"testing"
+
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type OwnerPolicySuite struct {
+	suite.Suite
+}
+
+func TestOwnerPolicy(t *testing.T) {
+	suite.RunSuite(t, new(OwnerPolicySuite))
+}
+
+func (s *OwnerPolicySuite) TestOwnerPolicy_AuthorizeShop(t provider.T) {
+	shopCreator := testobj.NewShopMother()
+
+	t.WithNewStep("owner", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		userID := uuid.New()
+		shop := shopCreator.ShopOfUserP(userID)
+
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shop.GetID()).Return(shop, nil)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, new(productrep.MockProductRep), new(postrep.MockPostRep))
+
+		res, err := policy.AuthorizeShop(ctx, shop.GetID(), auth.ActionUpdate)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(shop, res)
+	})
+	t.WithNewStep("shop of another user", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		shop := shopCreator.ShopOfUserP(uuid.New())
+
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionUpdate, auth.ResourceShop).Return(false)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shop.GetID()).Return(shop, nil)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, new(productrep.MockProductRep), new(postrep.MockPostRep))
+
+		_, err := policy.AuthorizeShop(ctx, shop.GetID(), auth.ActionUpdate)
+
+		sCtx.Require().ErrorIs(err, auth.ErrHasNoRights)
+	})
+	t.WithNewStep("not authorized", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.Nil, auth.ErrNotAuthZ)
+		mockShopRep := new(shoprep.MockShopRep)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, new(productrep.MockProductRep), new(postrep.MockPostRep))
+
+		_, err := policy.AuthorizeShop(ctx, uuid.New(), auth.ActionUpdate)
+
+		sCtx.Require().ErrorIs(err, auth.ErrNotAuthZ)
+		mockShopRep.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("shop not found", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		shopID := uuid.New()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shopID).Return(nil, shoprep.ErrShopNotFound)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, new(productrep.MockProductRep), new(postrep.MockPostRep))
+
+		_, err := policy.AuthorizeShop(ctx, shopID, auth.ActionUpdate)
+
+		sCtx.Require().ErrorIs(err, shoprep.ErrShopNotFound)
+	})
+}
+
+func (s *OwnerPolicySuite) TestOwnerPolicy_AuthorizeProduct(t provider.T) {
+	shopCreator := testobj.NewShopMother()
+	productCreator := testobj.NewProductMother()
+
+	t.WithNewStep("product of own shop", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		userID := uuid.New()
+		shop := shopCreator.ShopOfUserP(userID)
+		product := productCreator.ProductOfShopP(shop.GetID())
+
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shop.GetID()).Return(shop, nil)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetByID", ctx, product.GetID()).Return(product, nil)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, mockProductRep, new(postrep.MockPostRep))
+
+		res, err := policy.AuthorizeProduct(ctx, product.GetID(), auth.ActionUpdate)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(product, res)
+	})
+	t.WithNewStep("product of another user's shop", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		shop := shopCreator.ShopOfUserP(uuid.New())
+		product := productCreator.ProductOfShopP(shop.GetID())
+
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionUpdate, auth.ResourceProduct).Return(false)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shop.GetID()).Return(shop, nil)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetByID", ctx, product.GetID()).Return(product, nil)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, mockProductRep, new(postrep.MockPostRep))
+
+		_, err := policy.AuthorizeProduct(ctx, product.GetID(), auth.ActionUpdate)
+
+		sCtx.Require().ErrorIs(err, auth.ErrHasNoRights)
+	})
+	t.WithNewStep("moderator deletes product of another user's shop", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		shop := shopCreator.ShopOfUserP(uuid.New())
+		product := productCreator.ProductOfShopP(shop.GetID())
+
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionDelete, auth.ResourceProduct).Return(true)
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", ctx, shop.GetID()).Return(shop, nil)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetByID", ctx, product.GetID()).Return(product, nil)
+		policy := ownerpolicy.NewOwnerPolicy(mockAuthZ, mockShopRep, mockProductRep, new(postrep.MockPostRep))
+
+		res, err := policy.AuthorizeProduct(ctx, product.GetID(), auth.ActionDelete)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(product, res)
+	})
+}
@@ -0,0 +1,76 @@
+package passwordpolicy
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+// PasswordPolicy - требования к новому паролю при регистрации, смене и сбросе.
+// Пароли, заданные до ужесточения требований, при входе не проверяются.
+type PasswordPolicy interface {
+	// Validate - ErrWeakPassword с описанием первого нарушенного требования
+	Validate(password string) error
+}
+
+var (
+	ErrWeakPassword = errors.New("password does not meet the requirements")
+)
+
+func NewPasswordPolicy(config cnfg.PasswordConfig) PasswordPolicy {
+	return &passwordPolicy{
+		minLength:  config.MinLength,
+		maxLength:  config.MaxLength,
+		minClasses: config.MinClasses,
+	}
+}
+
+type passwordPolicy struct {
+	minLength  int // символов
+	maxLength  int // байт
+	minClasses int
+}
+
+func (p *passwordPolicy) Validate(password string) error {
+	if !utf8.ValidString(password) {
+		return fmt.Errorf("%w: invalid utf-8", ErrWeakPassword)
+	}
+	if utf8.RuneCountInString(password) < p.minLength {
+		return fmt.Errorf("%w: at least %d characters required", ErrWeakPassword, p.minLength)
+	}
+	if len(password) > p.maxLength {
+		return fmt.Errorf("%w: at most %d bytes allowed", ErrWeakPassword, p.maxLength)
+	}
+	if classes := charClasses(password); classes < p.minClasses {
+		return fmt.Errorf("%w: at least %d of lowercase letters, uppercase letters, digits and other characters required",
+			ErrWeakPassword, p.minClasses)
+	}
+	return nil
+}
+
+// charClasses - сколько видов символов есть в пароле: строчные, заглавные, цифры, остальные
+func charClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	res := 0
+	for _, has := range []bool{lower, upper, digit, other} {
+		if has {
+			res++
+		}
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package passwordpolicy_test
+
+import (
+	"strings"
+	"testing"
+
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type PasswordPolicySuite struct {
+	suite.Suite
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	suite.RunSuite(t, new(PasswordPolicySuite))
+}
+
+func (s *PasswordPolicySuite) TestPasswordPolicy_Validate(t provider.T) {
+	policy := passwordpolicy.NewPasswordPolicy(testobj.NewPasswordConfigMother().Default())
+
+	valid := []string{"password123", "Password!", "пароль-звезда", "ПарольЗвезда"}
+	for _, password := range valid {
+		t.WithNewStep("valid "+password, func(sCtx provider.StepCtx) {
+			sCtx.Assert().NoError(policy.Validate(password))
+		})
+	}
+
+	weak := map[string]string{
+		"too short":            "pass12",
+		"one class":            "passwordpassword",
+		"digits only":          "1234567890",
+		"too long":             strings.Repeat("a1", 65),
+		"short in characters":  "пар1",
+		"invalid utf-8 string": "password\xff1",
+	}
+	for name, password := range weak {
+		t.WithNewStep(name, func(sCtx provider.StepCtx) {
+			sCtx.Assert().ErrorIs(policy.Validate(password), passwordpolicy.ErrWeakPassword)
+		})
+	}
+}
+
+func (s *PasswordPolicySuite) TestPasswordPolicy_Config(t provider.T) {
+	t.WithNewStep("no class requirement", func(sCtx provider.StepCtx) {
+		config := testobj.NewPasswordConfigMother().Default()
+		config.MinClasses = 0
+		config.MinLength = 4
+
+		sCtx.Assert().NoError(passwordpolicy.NewPasswordPolicy(config).Validate("1234"))
+	})
+}
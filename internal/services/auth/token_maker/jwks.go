@@ -0,0 +1,109 @@
+package tokenmaker
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// JWK и JWKS (RFC 7517, RFC 8037) - открытые ключи, по которым другие сервисы проверяют токены без общего секрета
+
+const (
+	ktyOKP = "OKP"
+	ktyEC  = "EC"
+
+	crvEd25519 = "Ed25519"
+	crvP256    = "P-256"
+
+	p256CoordSize = 32
+)
+
+type JWK struct {
+	Kty string `json:"kty" example:"OKP"`
+	Crv string `json:"crv" example:"Ed25519"`
+	X   string `json:"x" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+	Y   string `json:"y,omitempty"` // только для EC
+	Kid string `json:"kid" example:"2026-10"`
+	Alg string `json:"alg" example:"EdDSA"`
+	Use string `json:"use" example:"sig"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet - реализации TokenMaker, токены которых проверяются открытыми ключами
+type KeySet interface {
+	JWKS() JWKSet
+}
+
+// JWK - открытая часть ключа
+func (k *SigningKey) JWK() JWK {
+	jwk := k.jwk()
+	jwk.Kid = k.id
+	return jwk
+}
+
+func (k *SigningKey) jwk() JWK {
+	jwk := JWK{Alg: k.alg, Use: "sig"}
+	switch pub := k.public.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv = ktyOKP, crvEd25519
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *ecdsa.PublicKey:
+		// несжатая точка: 0x04 || X || Y
+		point, _ := pub.Bytes()
+		jwk.Kty, jwk.Crv = ktyEC, crvP256
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+p256CoordSize])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[1+p256CoordSize:])
+	}
+	return jwk
+}
+
+// Thumbprint - отпечаток ключа по RFC 7638: SHA-256 от обязательных полей в лексикографическом порядке
+func (j JWK) Thumbprint() string {
+	var canonical string
+	if j.Kty == ktyEC {
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, j.Crv, j.Kty, j.X, j.Y)
+	} else {
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, j.Crv, j.Kty, j.X)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// SigningKey - ключ только для проверки подписей, полученный из JWKS
+func (j JWK) SigningKey() (*SigningKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(j.X)
+	if err != nil {
+		return nil, fmt.Errorf("%w: jwk x: %w", ErrSigningKey, err)
+	}
+	var key any
+	switch {
+	case j.Kty == ktyOKP && j.Crv == crvEd25519 && j.Alg == AlgEdDSA:
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: jwk x: invalid size", ErrSigningKey)
+		}
+		key = ed25519.PublicKey(x)
+	case j.Kty == ktyEC && j.Crv == crvP256 && j.Alg == AlgES256:
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("%w: jwk y: %w", ErrSigningKey, err)
+		}
+		point := append(append([]byte{4}, x...), y...)
+		key, err = ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSigningKey, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported jwk %s/%s/%s", ErrSigningKey, j.Kty, j.Crv, j.Alg)
+	}
+	if j.Kid == "" {
+		return nil, fmt.Errorf("%w: jwk without kid", ErrSigningKey)
+	}
+	return NewSigningKey(j.Kid, key, time.Time{}, time.Time{})
+}
@@ -0,0 +1,145 @@
+package tokenmaker
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+)
+
+// JWT с асимметричной подписью ключом из связки. Id ключа пишется в заголовок kid,
+// поэтому другие сервисы проверяют токены по открытым ключам из JWKS без общего секрета
+
+const jwtHeaderKeyID = "kid"
+
+type JWTKeyringMaker struct {
+	keyring Keyring
+}
+
+func NewJWTKeyringMaker(keyring Keyring) TokenMaker {
+	return &JWTKeyringMaker{keyring: keyring}
+}
+
+// NewJWTEdDSAMaker - privateKeySeed это hex-строка 32-байтного seed ключа Ed25519
+func NewJWTEdDSAMaker(privateKeySeed string) (TokenMaker, error) {
+	return newSingleKeyJWTMaker(AlgEdDSA, privateKeySeed)
+}
+
+// NewJWTES256Maker - privateKey это hex-строка 32-байтного скаляра ключа P-256
+func NewJWTES256Maker(privateKey string) (TokenMaker, error) {
+	return newSingleKeyJWTMaker(AlgES256, privateKey)
+}
+
+func newSingleKeyJWTMaker(alg string, hexKey string) (TokenMaker, error) {
+	key, err := ParseSigningKeyHex(alg, hexKey)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := NewKeyring(alg, []*SigningKey{key})
+	if err != nil {
+		return nil, err
+	}
+	return NewJWTKeyringMaker(keyring), nil
+}
+
+func (maker *JWTKeyringMaker) CreateToken(userID uuid.UUID, role RoleAuth, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, role, duration)
+	if err != nil {
+		return "", err
+	}
+	key, err := maker.keyring.SigningKey(payload.IssuedAt)
+	if err != nil {
+		return "", err
+	}
+
+	jwtToken := jwt.NewWithClaims(jwtSigningMethod(key.alg), payload)
+	jwtToken.Header[jwtHeaderKeyID] = key.id
+	return jwtToken.SignedString(key.private)
+}
+
+func (maker *JWTKeyringMaker) VerifyToken(token string, role RoleAuth) (*Payload, error) {
+	// ключ выбирается по kid, а алгоритм из заголовка должен совпасть с алгоритмом ключа
+	keyFunc := func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header[jwtHeaderKeyID].(string)
+		key, err := maker.keyring.VerificationKey(kid, time.Now())
+		if err != nil || t.Method.Alg() != key.alg {
+			return nil, ErrInvalidToken
+		}
+		return key.public, nil
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if !payload.Role.Includes(role) {
+		return nil, ErrIncorrectRole
+	}
+	return payload, nil
+}
+
+func (maker *JWTKeyringMaker) JWKS() JWKSet {
+	keys := maker.keyring.PublicKeys(time.Now())
+	set := JWKSet{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, key.JWK())
+	}
+	return set
+}
+
+func jwtSigningMethod(alg string) jwt.SigningMethod {
+	if alg == AlgEdDSA {
+		return SigningMethodEdDSA
+	}
+	return jwt.SigningMethodES256
+}
+
+// jwt-go v3 не знает EdDSA (RFC 8037), поэтому метод подписи регистрируется здесь
+
+type signingMethodEdDSA struct{}
+
+var SigningMethodEdDSA jwt.SigningMethod = &signingMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(AlgEdDSA, func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return AlgEdDSA
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+func (m *signingMethodEdDSA) Verify(signingString string, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
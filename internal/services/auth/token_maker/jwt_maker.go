@@ -57,7 +57,7 @@ func (maker *JWTMaker) VerifyToken(token string, role RoleAuth) (*Payload, error
 	if !ok {
 		return nil, ErrInvalidToken
 	}
-	if payload.Role != role {
+	if !payload.Role.Includes(role) {
 		return nil, ErrIncorrectRole
 	}
 	return payload, nil
@@ -0,0 +1,117 @@
+package tokenmaker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Keyring - связка ключей подписи. Новые токены подписывает один ключ, принимаются подписи всех не истекших.
+// Ротация по расписанию: в связку заранее кладется ключ с будущим Not-Before, а старому ставится Not-After
+// не раньше, чем истекут подписанные им токены
+type Keyring interface {
+	// SigningKey - ключ для новых токенов в момент now: из вступивших в силу ключей алгоритма связки
+	// с закрытой частью - с самым поздним Not-Before
+	SigningKey(now time.Time) (*SigningKey, error)
+	// VerificationKey - ключ kid, если его подписи принимаются в момент now
+	VerificationKey(kid string, now time.Time) (*SigningKey, error)
+	// PublicKeys - ключи для JWKS: не истекшие к моменту now, в том числе запланированные,
+	// чтобы другие сервисы получили их до первого подписанного ими токена
+	PublicKeys(now time.Time) []*SigningKey
+}
+
+var (
+	ErrKeyring      = errors.New("invalid keyring")
+	ErrNoSigningKey = errors.New("no active signing key")
+	ErrUnknownKey   = errors.New("unknown or expired signing key")
+)
+
+const pemKeyExt = ".pem"
+
+type keyring struct {
+	alg  string
+	keys []*SigningKey // по возрастанию Not-Before
+	byID map[string]*SigningKey
+}
+
+// NewKeyring - alg это алгоритм новых подписей; ключи других алгоритмов только проверяют подписи.
+// Связка без закрытых ключей годится только для проверки токенов, например по JWKS другого сервиса
+func NewKeyring(alg string, keys []*SigningKey) (Keyring, error) {
+	if alg != AlgEdDSA && alg != AlgES256 {
+		return nil, fmt.Errorf("%w: unknown algorithm %q", ErrKeyring, alg)
+	}
+	kr := &keyring{
+		alg:  alg,
+		keys: append([]*SigningKey(nil), keys...),
+		byID: make(map[string]*SigningKey, len(keys)),
+	}
+	for _, key := range kr.keys {
+		if _, ok := kr.byID[key.id]; ok {
+			return nil, fmt.Errorf("%w: duplicate kid %q", ErrKeyring, key.id)
+		}
+		kr.byID[key.id] = key
+	}
+	sort.SliceStable(kr.keys, func(i, j int) bool {
+		return kr.keys[i].notBefore.Before(kr.keys[j].notBefore)
+	})
+	return kr, nil
+}
+
+// LoadKeyring - ключи из файлов *.pem каталога dir, kid - имя файла без расширения.
+// Среди них должен быть закрытый ключ алгоритма alg
+func LoadKeyring(alg string, dir string) (Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+pemKeyExt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyring, err)
+	}
+	keys := make([]*SigningKey, 0, len(paths))
+	canSign := false
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrKeyring, err)
+		}
+		key, err := ParseSigningKeyPEM(strings.TrimSuffix(filepath.Base(path), pemKeyExt), data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrKeyring, path, err)
+		}
+		keys = append(keys, key)
+		canSign = canSign || (key.alg == alg && key.CanSign())
+	}
+	if !canSign {
+		return nil, fmt.Errorf("%w: no %s private key in %s", ErrKeyring, alg, dir)
+	}
+	return NewKeyring(alg, keys)
+}
+
+func (kr *keyring) SigningKey(now time.Time) (*SigningKey, error) {
+	for i := len(kr.keys) - 1; i >= 0; i-- {
+		key := kr.keys[i]
+		if key.alg == kr.alg && key.activeAt(now) {
+			return key, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+func (kr *keyring) VerificationKey(kid string, now time.Time) (*SigningKey, error) {
+	key, ok := kr.byID[kid]
+	if !ok || key.expiredAt(now) {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (kr *keyring) PublicKeys(now time.Time) []*SigningKey {
+	keys := make([]*SigningKey, 0, len(kr.keys))
+	for _, key := range kr.keys {
+		if !key.expiredAt(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,337 @@
+package tokenmaker_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/require"
+)
+
+type KeyringSuite struct {
+	suite.Suite
+}
+
+func TestKeyring(t *testing.T) {
+	suite.RunSuite(t, new(KeyringSuite))
+}
+
+func newEd25519Key(t provider.T, id string, notBefore time.Time, notAfter time.Time) *token.SigningKey {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := token.NewSigningKey(id, private, notBefore, notAfter)
+	require.NoError(t, err)
+	return key
+}
+
+func newES256Key(t provider.T, id string) *token.SigningKey {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := token.NewSigningKey(id, private, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	return key
+}
+
+func newKeyringMaker(t provider.T, alg string, keys ...*token.SigningKey) token.TokenMaker {
+	keyring, err := token.NewKeyring(alg, keys)
+	require.NoError(t, err)
+	return token.NewJWTKeyringMaker(keyring)
+}
+
+func tokenKeyID(t provider.T, tokenStr string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(tokenStr, &token.Payload{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func (s *KeyringSuite) BeforeEach(t provider.T) {
+	t.Tag("Token Maker")
+}
+
+func (s *KeyringSuite) TestKeyring_Schedule(t provider.T) {
+	now := time.Now()
+	current := newEd25519Key(t, "current", now.Add(-time.Hour), now.Add(2*time.Hour))
+	next := newEd25519Key(t, "next", now.Add(time.Hour), time.Time{})
+	keyring, err := token.NewKeyring(token.AlgEdDSA, []*token.SigningKey{next, current})
+	t.Require().NoError(err)
+
+	t.WithNewStep("Подписывает последний вступивший в силу ключ", func(sCtx provider.StepCtx) {
+		key, err := keyring.SigningKey(now)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("current", key.GetID())
+
+		key, err = keyring.SigningKey(now.Add(90 * time.Minute))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("next", key.GetID())
+	})
+	t.WithNewStep("Подписи старого ключа принимаются до его Not-After", func(sCtx provider.StepCtx) {
+		_, err := keyring.VerificationKey("current", now.Add(90*time.Minute))
+		sCtx.Require().NoError(err)
+		_, err = keyring.VerificationKey("current", now.Add(3*time.Hour))
+		sCtx.Require().ErrorIs(err, token.ErrUnknownKey)
+		_, err = keyring.VerificationKey("unknown", now)
+		sCtx.Require().ErrorIs(err, token.ErrUnknownKey)
+	})
+	t.WithNewStep("JWKS публикует запланированный ключ заранее и убирает истекший", func(sCtx provider.StepCtx) {
+		ids := func(keys []*token.SigningKey) []string {
+			res := make([]string, 0, len(keys))
+			for _, key := range keys {
+				res = append(res, key.GetID())
+			}
+			return res
+		}
+		sCtx.Assert().Equal([]string{"current", "next"}, ids(keyring.PublicKeys(now)))
+		sCtx.Assert().Equal([]string{"next"}, ids(keyring.PublicKeys(now.Add(3*time.Hour))))
+	})
+	t.WithNewStep("Нет действующего ключа", func(sCtx provider.StepCtx) {
+		scheduled, err := token.NewKeyring(token.AlgEdDSA, []*token.SigningKey{next})
+		sCtx.Require().NoError(err)
+		_, err = scheduled.SigningKey(now)
+		sCtx.Require().ErrorIs(err, token.ErrNoSigningKey)
+	})
+	t.WithNewStep("Повторяющийся kid", func(sCtx provider.StepCtx) {
+		_, err := token.NewKeyring(token.AlgEdDSA, []*token.SigningKey{current, current})
+		sCtx.Require().ErrorIs(err, token.ErrKeyring)
+	})
+}
+
+func (s *KeyringSuite) TestJWTKeyringMaker_Rotation(t provider.T) {
+	now := time.Now()
+	old := newEd25519Key(t, "old", now.Add(-time.Hour), time.Time{})
+	oldPublic, err := token.NewSigningKey("old", old.PublicKey(), time.Time{}, now.Add(time.Hour))
+	t.Require().NoError(err)
+	oldRetired, err := token.NewSigningKey("old", old.PublicKey(), now.Add(-2*time.Hour), now.Add(-time.Minute))
+	t.Require().NoError(err)
+	next := newEd25519Key(t, "next", now.Add(-time.Minute), time.Time{})
+
+	oldMaker := newKeyringMaker(t, token.AlgEdDSA, old)
+	oldToken, err := oldMaker.CreateToken(uuid.New(), token.UserRole, time.Minute)
+	t.Require().NoError(err)
+
+	t.WithNewStep("Новый ключ подписывает, токены старого проверяются", func(sCtx provider.StepCtx) {
+		maker := newKeyringMaker(t, token.AlgEdDSA, oldPublic, next)
+
+		_, err := maker.VerifyToken(oldToken, token.UserRole)
+		sCtx.Require().NoError(err)
+
+		newToken, err := maker.CreateToken(uuid.New(), token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("old", tokenKeyID(t, oldToken))
+		sCtx.Assert().Equal("next", tokenKeyID(t, newToken))
+	})
+	t.WithNewStep("После Not-After старого ключа его токены отвергаются", func(sCtx provider.StepCtx) {
+		maker := newKeyringMaker(t, token.AlgEdDSA, oldRetired, next)
+
+		_, err := maker.VerifyToken(oldToken, token.UserRole)
+		sCtx.Require().ErrorIs(err, token.ErrInvalidToken)
+	})
+	t.WithNewStep("Смена алгоритма: ключ ES256 подписывает, EdDSA только проверяет", func(sCtx provider.StepCtx) {
+		maker := newKeyringMaker(t, token.AlgES256, old, newES256Key(t, "es"))
+
+		_, err := maker.VerifyToken(oldToken, token.UserRole)
+		sCtx.Require().NoError(err)
+		newToken, err := maker.CreateToken(uuid.New(), token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("es", tokenKeyID(t, newToken))
+	})
+}
+
+func (s *KeyringSuite) TestJWTKeyringMaker_ForgedHeader(t provider.T) {
+	edKey := newEd25519Key(t, "ed", time.Time{}, time.Time{})
+	esKey := newES256Key(t, "es")
+	maker := newKeyringMaker(t, token.AlgEdDSA, edKey, esKey)
+
+	payload, err := token.NewPayload(uuid.New(), token.UserRole, time.Minute)
+	t.Require().NoError(err)
+
+	t.WithNewStep("Алгоритм заголовка не совпадает с алгоритмом ключа kid", func(sCtx provider.StepCtx) {
+		_, attacker, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		jwtToken := jwt.NewWithClaims(token.SigningMethodEdDSA, payload)
+		jwtToken.Header["kid"] = "es"
+		tokenStr, err := jwtToken.SignedString(attacker)
+		sCtx.Require().NoError(err)
+
+		_, err = maker.VerifyToken(tokenStr, token.UserRole)
+		sCtx.Require().ErrorIs(err, token.ErrInvalidToken)
+	})
+	t.WithNewStep("HS256 с открытым ключом в роли секрета", func(sCtx provider.StepCtx) {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+		jwtToken.Header["kid"] = "ed"
+		tokenStr, err := jwtToken.SignedString([]byte(edKey.PublicKey().(ed25519.PublicKey)))
+		sCtx.Require().NoError(err)
+
+		_, err = maker.VerifyToken(tokenStr, token.UserRole)
+		sCtx.Require().ErrorIs(err, token.ErrInvalidToken)
+	})
+	t.WithNewStep("Токен без kid", func(sCtx provider.StepCtx) {
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		tokenStr, err := jwt.NewWithClaims(token.SigningMethodEdDSA, payload).SignedString(private)
+		sCtx.Require().NoError(err)
+
+		_, err = maker.VerifyToken(tokenStr, token.UserRole)
+		sCtx.Require().ErrorIs(err, token.ErrInvalidToken)
+	})
+}
+
+func (s *KeyringSuite) TestJWTKeyringMaker_JWKS(t provider.T) {
+	edKey := newEd25519Key(t, "ed", time.Time{}, time.Time{})
+	esKey := newES256Key(t, "es")
+	maker := newKeyringMaker(t, token.AlgEdDSA, edKey, esKey)
+
+	t.WithNewStep("Открытые ключи в формате JWK", func(sCtx provider.StepCtx) {
+		keySet, ok := maker.(token.KeySet)
+		sCtx.Require().True(ok)
+
+		data, err := json.Marshal(keySet.JWKS())
+		sCtx.Require().NoError(err)
+		var set token.JWKSet
+		sCtx.Require().NoError(json.Unmarshal(data, &set))
+		sCtx.Require().Len(set.Keys, 2)
+
+		byID := map[string]token.JWK{}
+		for _, jwk := range set.Keys {
+			byID[jwk.Kid] = jwk
+			sCtx.Assert().Equal("sig", jwk.Use)
+		}
+		sCtx.Assert().Equal("OKP", byID["ed"].Kty)
+		sCtx.Assert().Equal("Ed25519", byID["ed"].Crv)
+		sCtx.Assert().Equal(token.AlgEdDSA, byID["ed"].Alg)
+		sCtx.Assert().Empty(byID["ed"].Y)
+		sCtx.Assert().Equal("EC", byID["es"].Kty)
+		sCtx.Assert().Equal("P-256", byID["es"].Crv)
+		sCtx.Assert().Equal(token.AlgES256, byID["es"].Alg)
+		sCtx.Assert().NotEmpty(byID["es"].Y)
+	})
+	t.WithNewStep("Токены проверяются по одним открытым ключам", func(sCtx provider.StepCtx) {
+		userID := uuid.New()
+		tokenStr, err := maker.CreateToken(userID, token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		keys := make([]*token.SigningKey, 0)
+		for _, jwk := range maker.(token.KeySet).JWKS().Keys {
+			key, err := jwk.SigningKey()
+			sCtx.Require().NoError(err)
+			sCtx.Assert().False(key.CanSign())
+			keys = append(keys, key)
+		}
+		verifier := newKeyringMaker(t, token.AlgEdDSA, keys...)
+
+		payload, err := verifier.VerifyToken(tokenStr, token.UserRole)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(userID, payload.GetPersonID())
+		_, err = verifier.CreateToken(userID, token.UserRole, time.Minute)
+		sCtx.Require().ErrorIs(err, token.ErrNoSigningKey)
+	})
+	t.WithNewStep("Отпечаток по RFC 7638", func(sCtx provider.StepCtx) {
+		// пример из RFC 8037, раздел A.3
+		jwk := token.JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+		sCtx.Assert().Equal("kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", jwk.Thumbprint())
+	})
+}
+
+func (s *KeyringSuite) TestLoadKeyring(t provider.T) {
+	writeKey := func(t provider.StepCtx, dir string, name string, blockType string, der []byte, headers map[string]string) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Headers: headers, Bytes: der})
+		t.Require().NoError(os.WriteFile(filepath.Join(dir, name), data, 0o600))
+	}
+
+	t.WithNewStep("Ключи и расписание из каталога", func(sCtx provider.StepCtx) {
+		dir := t.TempDir()
+		now := time.Now()
+
+		_, oldPrivate, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		oldDER, err := x509.MarshalPKIXPublicKey(oldPrivate.Public())
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "2026-09.pem", "PUBLIC KEY", oldDER, map[string]string{
+			"Not-After": now.Add(time.Hour).Format(time.RFC3339),
+		})
+		_, currentPrivate, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		currentDER, err := x509.MarshalPKCS8PrivateKey(currentPrivate)
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "2026-10.pem", "PRIVATE KEY", currentDER, map[string]string{
+			"Not-Before": now.Add(-time.Hour).Format(time.RFC3339),
+		})
+		_, nextPrivate, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		nextDER, err := x509.MarshalPKCS8PrivateKey(nextPrivate)
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "2026-11.pem", "PRIVATE KEY", nextDER, map[string]string{
+			"Not-Before": now.Add(24 * time.Hour).Format(time.RFC3339),
+		})
+		sCtx.Require().NoError(os.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0o600))
+
+		keyring, err := token.LoadKeyring(token.AlgEdDSA, dir)
+		sCtx.Require().NoError(err)
+		key, err := keyring.SigningKey(now)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("2026-10", key.GetID())
+		key, err = keyring.SigningKey(now.Add(25 * time.Hour))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("2026-11", key.GetID())
+		old, err := keyring.VerificationKey("2026-09", now)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().False(old.CanSign())
+		sCtx.Assert().Len(keyring.PublicKeys(now), 3)
+	})
+	t.WithNewStep("EC PRIVATE KEY", func(sCtx provider.StepCtx) {
+		dir := t.TempDir()
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		sCtx.Require().NoError(err)
+		der, err := x509.MarshalECPrivateKey(private)
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "es.pem", "EC PRIVATE KEY", der, nil)
+
+		keyring, err := token.LoadKeyring(token.AlgES256, dir)
+		sCtx.Require().NoError(err)
+		key, err := keyring.SigningKey(time.Now())
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(token.AlgES256, key.GetAlg())
+	})
+	t.WithNewStep("Нет закрытого ключа нужного алгоритма", func(sCtx provider.StepCtx) {
+		dir := t.TempDir()
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "ed.pem", "PRIVATE KEY", der, nil)
+
+		_, err = token.LoadKeyring(token.AlgES256, dir)
+		sCtx.Require().ErrorIs(err, token.ErrKeyring)
+		_, err = token.LoadKeyring(token.AlgEdDSA, t.TempDir())
+		sCtx.Require().ErrorIs(err, token.ErrKeyring)
+	})
+	t.WithNewStep("Неверное расписание и кривая", func(sCtx provider.StepCtx) {
+		dir := t.TempDir()
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		sCtx.Require().NoError(err)
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		sCtx.Require().NoError(err)
+		writeKey(sCtx, dir, "ed.pem", "PRIVATE KEY", der, map[string]string{"Not-Before": "tomorrow"})
+
+		_, err = token.LoadKeyring(token.AlgEdDSA, dir)
+		sCtx.Require().ErrorIs(err, token.ErrSigningKey)
+
+		p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		sCtx.Require().NoError(err)
+		_, err = token.NewSigningKey("p384", p384, time.Time{}, time.Time{})
+		sCtx.Require().ErrorIs(err, token.ErrSigningKey)
+	})
+}
@@ -0,0 +1,117 @@
+package tokenmaker
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+)
+
+// PASETO v4: local - симметричное шифрование, public - подпись Ed25519.
+// Полезная нагрузка та же, что и у JWTMaker
+
+const (
+	pasetoLocalKeySize = 32 // длина ключа v4.local
+	pasetoSeedHexSize  = 64 // hex-строка 32-байтного seed Ed25519
+)
+
+type PasetoLocalMaker struct {
+	key paseto.V4SymmetricKey
+}
+
+func NewPasetoLocalMaker(symmetricKey string) (TokenMaker, error) {
+	if len(symmetricKey) != pasetoLocalKeySize {
+		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", pasetoLocalKeySize)
+	}
+	key, err := paseto.V4SymmetricKeyFromBytes([]byte(symmetricKey))
+	if err != nil {
+		return nil, err
+	}
+	return &PasetoLocalMaker{key: key}, nil
+}
+
+func (maker *PasetoLocalMaker) CreateToken(userID uuid.UUID, role RoleAuth, duration time.Duration) (string, error) {
+	token, err := newPasetoToken(userID, role, duration)
+	if err != nil {
+		return "", err
+	}
+	return token.V4Encrypt(maker.key, nil), nil
+}
+
+func (maker *PasetoLocalMaker) VerifyToken(token string, role RoleAuth) (*Payload, error) {
+	parsed, err := pasetoParser().ParseV4Local(maker.key, token, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return pasetoPayload(parsed, role)
+}
+
+type PasetoPublicMaker struct {
+	secretKey paseto.V4AsymmetricSecretKey
+	publicKey paseto.V4AsymmetricPublicKey
+}
+
+// NewPasetoPublicMaker - privateKeySeed это hex-строка 32-байтного seed ключа Ed25519
+func NewPasetoPublicMaker(privateKeySeed string) (TokenMaker, error) {
+	if len(privateKeySeed) != pasetoSeedHexSize {
+		return nil, fmt.Errorf("invalid key size: must be %d hex characters", pasetoSeedHexSize)
+	}
+	secretKey, err := paseto.NewV4AsymmetricSecretKeyFromSeed(privateKeySeed)
+	if err != nil {
+		return nil, err
+	}
+	return &PasetoPublicMaker{
+		secretKey: secretKey,
+		publicKey: secretKey.Public(),
+	}, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(userID uuid.UUID, role RoleAuth, duration time.Duration) (string, error) {
+	token, err := newPasetoToken(userID, role, duration)
+	if err != nil {
+		return "", err
+	}
+	return token.V4Sign(maker.secretKey, nil), nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string, role RoleAuth) (*Payload, error) {
+	parsed, err := pasetoParser().ParseV4Public(maker.publicKey, token, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return pasetoPayload(parsed, role)
+}
+
+func newPasetoToken(userID uuid.UUID, role RoleAuth, duration time.Duration) (*paseto.Token, error) {
+	payload, err := NewPayload(userID, role, duration)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return paseto.NewTokenFromClaimsJSON(claims, nil)
+}
+
+// pasetoParser проверяет только подпись/шифрование, сроки проверяет Payload.Valid,
+// чтобы ошибки совпадали с JWTMaker
+func pasetoParser() paseto.Parser {
+	return paseto.MakeParser(nil)
+}
+
+func pasetoPayload(token *paseto.Token, role RoleAuth) (*Payload, error) {
+	payload := &Payload{}
+	if err := json.Unmarshal(token.ClaimsJSON(), payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+	if !payload.Role.Includes(role) {
+		return nil, ErrIncorrectRole
+	}
+	return payload, nil
+}
@@ -3,6 +3,7 @@ package tokenmaker
 import (
 	"time"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	"github.com/google/uuid"
 )
 
@@ -10,31 +11,83 @@ import (
 type RoleAuth string
 
 const (
-	UserRole RoleAuth = "user_role"
+	UserRole      RoleAuth = "user_role"
+	ModeratorRole RoleAuth = "moderator_role"
+	AdminRole     RoleAuth = "admin_role"
+	// MFAPendingRole - пароль проверен, но второй фактор еще нет. Роль вне иерархии:
+	// такой токен принимается только там, где требуется именно она, и только для обмена на полный токен.
+	MFAPendingRole RoleAuth = "mfa_pending"
 )
 
+// roleLevels - роли вложены: модератор может все, что пользователь, администратор - все, что модератор
+var roleLevels = map[RoleAuth]int{
+	UserRole:      1,
+	ModeratorRole: 2,
+	AdminRole:     3,
+}
+
+// RoleOf - роль токена для роли пользователя
+func RoleOf(role models.Role) RoleAuth {
+	switch role {
+	case models.RoleAdmin:
+		return AdminRole
+	case models.RoleModerator:
+		return ModeratorRole
+	default:
+		return UserRole
+	}
+}
+
+func (r RoleAuth) Valid() bool {
+	_, ok := roleLevels[r]
+	return ok || r == MFAPendingRole
+}
+
+// Includes - есть ли у роли r права роли required
+func (r RoleAuth) Includes(required RoleAuth) bool {
+	if r == MFAPendingRole || required == MFAPendingRole {
+		return r == required
+	}
+	return r.Valid() && roleLevels[r] >= roleLevels[required]
+}
+
 type Payload struct {
+	ID        uuid.UUID `json:"token_id"` // уникальный идентификатор токена
 	PersonID  uuid.UUID `json:"person_id"`
 	Role      RoleAuth  `json:"role"`
+	IssuedAt  time.Time `json:"issued_at"`
+	NotBefore time.Time `json:"not_before"` // до этого времени токен недействителен
 	ExpiredAt time.Time `json:"expired_at"` // время когда срок действия токена истечет
 }
 
 func NewPayload(personID uuid.UUID, role RoleAuth, duration time.Duration) (*Payload, error) {
+	now := time.Now().UTC()
 	payload := &Payload{
+		ID:        uuid.New(),
 		PersonID:  personID,
 		Role:      role,
-		ExpiredAt: time.Now().UTC().Add(duration),
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiredAt: now.Add(duration),
 	}
 	return payload, nil
 }
 
 func (payload *Payload) Valid() error {
-	if time.Now().After(payload.ExpiredAt) {
+	now := time.Now()
+	if now.After(payload.ExpiredAt) {
 		return ErrExpiredToken
 	}
+	if now.Before(payload.NotBefore) {
+		return ErrInvalidToken
+	}
 	return nil
 }
 
+func (p *Payload) GetID() uuid.UUID {
+	return p.ID
+}
+
 func (p *Payload) GetPersonID() uuid.UUID {
 	return p.PersonID
 }
@@ -43,6 +96,14 @@ func (p *Payload) GetRole() RoleAuth {
 	return p.Role
 }
 
+func (p *Payload) GetIssuedAt() time.Time {
+	return p.IssuedAt
+}
+
+func (p *Payload) GetNotBefore() time.Time {
+	return p.NotBefore
+}
+
 func (p *Payload) GetExpiredAt() time.Time {
 	return p.ExpiredAt
 }
@@ -0,0 +1,183 @@
+package tokenmaker
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Асимметричные ключи подписи JWT: Ed25519 (EdDSA) и ECDSA P-256 (ES256).
+// Ключ без закрытой части только проверяет подписи - так в связке остаются выведенные из оборота ключи
+
+const (
+	AlgEdDSA = "EdDSA"
+	AlgES256 = "ES256"
+
+	signingKeyHexSize = 64 // hex-строка 32-байтного seed Ed25519 или скаляра P-256
+
+	// заголовки PEM-блока с расписанием ключа, значения в RFC 3339
+	pemHeaderNotBefore = "Not-Before"
+	pemHeaderNotAfter  = "Not-After"
+)
+
+var ErrSigningKey = errors.New("invalid signing key")
+
+type SigningKey struct {
+	id        string
+	alg       string
+	private   any       // ed25519.PrivateKey или *ecdsa.PrivateKey; nil - ключ только проверяет подписи
+	public    any       // ed25519.PublicKey или *ecdsa.PublicKey
+	notBefore time.Time // с этого момента ключ подписывает новые токены
+	notAfter  time.Time // с этого момента подписи ключа не принимаются; нулевое - бессрочно
+}
+
+// NewSigningKey - key это закрытый или открытый ключ Ed25519 или ECDSA P-256.
+// Пустой id заменяется отпечатком ключа (RFC 7638)
+func NewSigningKey(id string, key any, notBefore time.Time, notAfter time.Time) (*SigningKey, error) {
+	k := &SigningKey{
+		id:        id,
+		notBefore: notBefore,
+		notAfter:  notAfter,
+	}
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		k.alg, k.private, k.public = AlgEdDSA, key, key.Public()
+	case ed25519.PublicKey:
+		k.alg, k.public = AlgEdDSA, key
+	case *ecdsa.PrivateKey:
+		k.alg, k.private, k.public = AlgES256, key, &key.PublicKey
+	case *ecdsa.PublicKey:
+		k.alg, k.public = AlgES256, key
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrSigningKey, key)
+	}
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	if k.id == "" {
+		k.id = k.jwk().Thumbprint()
+	}
+	return k, nil
+}
+
+func (k *SigningKey) validate() error {
+	if pub, ok := k.public.(*ecdsa.PublicKey); ok && pub.Curve != elliptic.P256() {
+		return fmt.Errorf("%w: only P-256 curve is supported", ErrSigningKey)
+	}
+	if !k.notAfter.IsZero() && !k.notAfter.After(k.notBefore) {
+		return fmt.Errorf("%w: %s must be after %s", ErrSigningKey, pemHeaderNotAfter, pemHeaderNotBefore)
+	}
+	return nil
+}
+
+// ParseSigningKeyHex - ключ из hex-строки: seed Ed25519 для AlgEdDSA или скаляр P-256 для AlgES256
+func ParseSigningKeyHex(alg string, hexKey string) (*SigningKey, error) {
+	if len(hexKey) != signingKeyHexSize {
+		return nil, fmt.Errorf("invalid key size: must be %d hex characters", signingKeyHexSize)
+	}
+	raw, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigningKey, err)
+	}
+	switch alg {
+	case AlgEdDSA:
+		return NewSigningKey("", ed25519.NewKeyFromSeed(raw), time.Time{}, time.Time{})
+	case AlgES256:
+		key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSigningKey, err)
+		}
+		return NewSigningKey("", key, time.Time{}, time.Time{})
+	default:
+		return nil, fmt.Errorf("%w: unknown algorithm %q", ErrSigningKey, alg)
+	}
+}
+
+// ParseSigningKeyPEM - ключ из PEM: PRIVATE KEY (PKCS#8), EC PRIVATE KEY (SEC 1) или PUBLIC KEY (PKIX).
+// Расписание задается заголовками блока Not-Before и Not-After
+func ParseSigningKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrSigningKey)
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: unsupported PEM block %q", ErrSigningKey, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigningKey, err)
+	}
+	notBefore, err := pemHeaderTime(block, pemHeaderNotBefore)
+	if err != nil {
+		return nil, err
+	}
+	notAfter, err := pemHeaderTime(block, pemHeaderNotAfter)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigningKey(id, key, notBefore, notAfter)
+}
+
+func pemHeaderTime(block *pem.Block, header string) (time.Time, error) {
+	value, ok := block.Headers[header]
+	if !ok {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: header %s: %w", ErrSigningKey, header, err)
+	}
+	return t, nil
+}
+
+func (k *SigningKey) GetID() string {
+	return k.id
+}
+
+func (k *SigningKey) GetAlg() string {
+	return k.alg
+}
+
+func (k *SigningKey) GetNotBefore() time.Time {
+	return k.notBefore
+}
+
+func (k *SigningKey) GetNotAfter() time.Time {
+	return k.notAfter
+}
+
+// CanSign - есть ли у ключа закрытая часть
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// PublicKey - ed25519.PublicKey или *ecdsa.PublicKey
+func (k *SigningKey) PublicKey() any {
+	return k.public
+}
+
+// activeAt - подписывает ли ключ новые токены в момент now
+func (k *SigningKey) activeAt(now time.Time) bool {
+	return k.CanSign() && !now.Before(k.notBefore) && !k.expiredAt(now)
+}
+
+// expiredAt - отвергаются ли подписи ключа в момент now
+func (k *SigningKey) expiredAt(now time.Time) bool {
+	return !k.notAfter.IsZero() && !now.Before(k.notAfter)
+}
@@ -2,14 +2,17 @@ package tokenmaker
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
 	"github.com/google/uuid"
 )
 
 type TokenMaker interface {
 	// duration - корректный срок действия, return - одписанную строку токена или ошибку
 	CreateToken(id uuid.UUID, role RoleAuth, duration time.Duration) (string, error)
+	// VerifyToken проверяет токен и то, что его роль включает права role
 	VerifyToken(token string, role RoleAuth) (*Payload, error)
 }
 
@@ -19,6 +22,32 @@ var (
 	ErrIncorrectRole = errors.New("incorrect role")
 )
 
-func NewTokenMaker(symmetricKey string) (TokenMaker, error) {
-	return NewJWTMaker(symmetricKey)
+// NewTokenMaker выбирает реализацию по config.TokenType
+func NewTokenMaker(config cnfg.AppConfig) (TokenMaker, error) {
+	switch config.TokenType {
+	case cnfg.TokenJWT:
+		return NewJWTMaker(config.TokenSymmetricKey)
+	case cnfg.TokenPasetoLocal:
+		return NewPasetoLocalMaker(config.TokenSymmetricKey)
+	case cnfg.TokenPasetoPublic:
+		return NewPasetoPublicMaker(config.TokenPrivateKey)
+	case cnfg.TokenJWTEdDSA:
+		return newAsymmetricJWTMaker(AlgEdDSA, config)
+	case cnfg.TokenJWTES256:
+		return newAsymmetricJWTMaker(AlgES256, config)
+	default:
+		return nil, fmt.Errorf("unknown token type %q", config.TokenType)
+	}
+}
+
+// newAsymmetricJWTMaker - связка из каталога config.TokenKeysDir, а без него - один ключ config.TokenPrivateKey
+func newAsymmetricJWTMaker(alg string, config cnfg.AppConfig) (TokenMaker, error) {
+	if config.TokenKeysDir == "" {
+		return newSingleKeyJWTMaker(alg, config.TokenPrivateKey)
+	}
+	keyring, err := LoadKeyring(alg, config.TokenKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewJWTKeyringMaker(keyring), nil
 }
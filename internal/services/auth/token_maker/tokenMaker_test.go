@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
 	token "github.com/CakeForKit/CraftPlace.git/internal/services/auth/token_maker"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
@@ -13,24 +14,64 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// TokenMakerSuite - общие тесты для всех реализаций TokenMaker
 type TokenMakerSuite struct {
 	suite.Suite
-	maker token.TokenMaker
+	newMaker func(key string) (token.TokenMaker, error)
+	key      string
+	otherKey string
+	maker    token.TokenMaker
 }
 
 func TestTokenMaker(t *testing.T) {
-	suite.RunSuite(t, new(TokenMakerSuite))
+	suite.RunSuite(t, &TokenMakerSuite{
+		newMaker: token.NewJWTMaker,
+		key:      "12345678901234567890123456789012",
+		otherKey: "abcdefghijklmnopqrstuvwxyz123456",
+	})
+}
+
+func TestPasetoLocalMaker(t *testing.T) {
+	suite.RunSuite(t, &TokenMakerSuite{
+		newMaker: token.NewPasetoLocalMaker,
+		key:      "12345678901234567890123456789012",
+		otherKey: "abcdefghijklmnopqrstuvwxyz123456",
+	})
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	suite.RunSuite(t, &TokenMakerSuite{
+		newMaker: token.NewPasetoPublicMaker,
+		key:      "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774",
+		otherKey: "5d7d2a1c3e9b8f604b1a2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071",
+	})
+}
+
+func TestJWTEdDSAMaker(t *testing.T) {
+	suite.RunSuite(t, &TokenMakerSuite{
+		newMaker: token.NewJWTEdDSAMaker,
+		key:      "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774",
+		otherKey: "5d7d2a1c3e9b8f604b1a2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071",
+	})
+}
+
+func TestJWTES256Maker(t *testing.T) {
+	suite.RunSuite(t, &TokenMakerSuite{
+		newMaker: token.NewJWTES256Maker,
+		key:      "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774",
+		otherKey: "5d7d2a1c3e9b8f604b1a2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071",
+	})
 }
 
 func (s *TokenMakerSuite) BeforeEach(t provider.T) {
 	t.Tag("Token Maker")
 
-	maker, err := token.NewTokenMaker("12345678901234567890123456789012")
+	maker, err := s.newMaker(s.key)
 	require.NoError(t, err)
 	s.maker = maker
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_CreateAndVerifyToken(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_CreateAndVerifyToken(t provider.T) {
 	t.WithNewStep("Успешное создание и верификация токена", func(sCtx provider.StepCtx) {
 		// Arrange
 		userID := uuid.New()
@@ -56,10 +97,27 @@ func (s *TokenMakerSuite) TestJWTMaker_CreateAndVerifyToken(t provider.T) {
 		assert.Equal(t, role, payload.GetRole())
 		assert.WithinDuration(t, issuedAt, payload.GetExpiredAt().Add(-duration), time.Second)
 		assert.WithinDuration(t, expiredAt, payload.GetExpiredAt(), time.Second)
+		assert.NotEqual(t, uuid.Nil, payload.GetID())
+		assert.WithinDuration(t, issuedAt, payload.GetIssuedAt(), time.Second)
+		assert.WithinDuration(t, issuedAt, payload.GetNotBefore(), time.Second)
+	})
+	t.WithNewStep("У каждого токена свой идентификатор", func(sCtx provider.StepCtx) {
+		userID := uuid.New()
+
+		first, err := s.maker.CreateToken(userID, token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+		second, err := s.maker.CreateToken(userID, token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		firstPayload, err := s.maker.VerifyToken(first, token.UserRole)
+		sCtx.Require().NoError(err)
+		secondPayload, err := s.maker.VerifyToken(second, token.UserRole)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().NotEqual(firstPayload.GetID(), secondPayload.GetID())
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_ExpiredToken(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_ExpiredToken(t provider.T) {
 	t.WithNewStep("Токен с истекшим сроком действия", func(sCtx provider.StepCtx) {
 		// Arrange
 		userID := uuid.New()
@@ -79,7 +137,7 @@ func (s *TokenMakerSuite) TestJWTMaker_ExpiredToken(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_InvalidTokenAlgNone(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_InvalidTokenAlgNone(t provider.T) {
 	t.WithNewStep("Токен с неверным алгоритмом подписи", func(sCtx provider.StepCtx) {
 		// Arrange
 		userID := uuid.New()
@@ -102,7 +160,7 @@ func (s *TokenMakerSuite) TestJWTMaker_InvalidTokenAlgNone(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_IncorrectRole(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_IncorrectRole(t provider.T) {
 	t.WithNewStep("Токен с неверной ролью", func(sCtx provider.StepCtx) {
 		// Arrange
 		userID := uuid.New()
@@ -123,10 +181,30 @@ func (s *TokenMakerSuite) TestJWTMaker_IncorrectRole(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_InvalidSecretKey(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_MFAPendingRole(t provider.T) {
+	t.WithNewStep("Токен ожидания второго фактора не дает прав пользователя", func(sCtx provider.StepCtx) {
+		tokenStr, err := s.maker.CreateToken(uuid.New(), token.MFAPendingRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		_, err = s.maker.VerifyToken(tokenStr, token.UserRole)
+		sCtx.Require().ErrorIs(err, token.ErrIncorrectRole)
+		payload, err := s.maker.VerifyToken(tokenStr, token.MFAPendingRole)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(token.MFAPendingRole, payload.GetRole())
+	})
+	t.WithNewStep("Полный токен нельзя предъявить вместо токена ожидания", func(sCtx provider.StepCtx) {
+		tokenStr, err := s.maker.CreateToken(uuid.New(), token.AdminRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		_, err = s.maker.VerifyToken(tokenStr, token.MFAPendingRole)
+		sCtx.Require().ErrorIs(err, token.ErrIncorrectRole)
+	})
+}
+
+func (s *TokenMakerSuite) TestTokenMaker_InvalidSecretKey(t provider.T) {
 	t.WithNewStep("Создание maker с неверным секретным ключом", func(sCtx provider.StepCtx) {
 		// Arrange & Act
-		maker, err := token.NewTokenMaker("short_key")
+		maker, err := s.newMaker("short_key")
 
 		// Assert
 		sCtx.Require().Error(err)
@@ -135,7 +213,7 @@ func (s *TokenMakerSuite) TestJWTMaker_InvalidSecretKey(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_ValidWithDifferentRoles(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_ValidWithDifferentRoles(t provider.T) {
 	t.WithNewStep("Успешная верификация с правильной ролью", func(sCtx provider.StepCtx) {
 		// Arrange
 		userID := uuid.New()
@@ -155,7 +233,7 @@ func (s *TokenMakerSuite) TestJWTMaker_ValidWithDifferentRoles(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_EmptyToken(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_EmptyToken(t provider.T) {
 	t.WithNewStep("Верификация пустого токена", func(sCtx provider.StepCtx) {
 		// Arrange
 		emptyToken := ""
@@ -171,7 +249,7 @@ func (s *TokenMakerSuite) TestJWTMaker_EmptyToken(t provider.T) {
 	})
 }
 
-func (s *TokenMakerSuite) TestJWTMaker_MalformedToken(t provider.T) {
+func (s *TokenMakerSuite) TestTokenMaker_MalformedToken(t provider.T) {
 	t.WithNewStep("Верификация поврежденного токена", func(sCtx provider.StepCtx) {
 		// Arrange
 		malformedToken := "malformed.jwt.token"
@@ -186,3 +264,43 @@ func (s *TokenMakerSuite) TestJWTMaker_MalformedToken(t provider.T) {
 		sCtx.Require().Nil(payload)
 	})
 }
+
+func (s *TokenMakerSuite) TestTokenMaker_OtherKey(t provider.T) {
+	t.WithNewStep("Токен, выпущенный с другим ключом", func(sCtx provider.StepCtx) {
+		// Arrange
+		otherMaker, err := s.newMaker(s.otherKey)
+		sCtx.Require().NoError(err)
+		tokenStr, err := otherMaker.CreateToken(uuid.New(), token.UserRole, time.Minute)
+		sCtx.Require().NoError(err)
+
+		// Act
+		payload, err := s.maker.VerifyToken(tokenStr, token.UserRole)
+
+		// Assert
+		sCtx.Require().ErrorIs(err, token.ErrInvalidToken)
+		sCtx.Require().Nil(payload)
+	})
+}
+
+func TestNewTokenMaker(t *testing.T) {
+	appCnfg := cnfg.AppConfig{
+		TokenSymmetricKey: "12345678901234567890123456789012",
+		TokenPrivateKey:   "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774",
+	}
+	for tokenType, expected := range map[string]any{
+		cnfg.TokenJWT:          &token.JWTMaker{},
+		cnfg.TokenPasetoLocal:  &token.PasetoLocalMaker{},
+		cnfg.TokenPasetoPublic: &token.PasetoPublicMaker{},
+		cnfg.TokenJWTEdDSA:     &token.JWTKeyringMaker{},
+		cnfg.TokenJWTES256:     &token.JWTKeyringMaker{},
+	} {
+		appCnfg.TokenType = tokenType
+		maker, err := token.NewTokenMaker(appCnfg)
+		require.NoError(t, err, tokenType)
+		assert.IsType(t, expected, maker, tokenType)
+	}
+
+	appCnfg.TokenType = "unknown"
+	_, err := token.NewTokenMaker(appCnfg)
+	assert.Error(t, err)
+}
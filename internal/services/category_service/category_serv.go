@@ -3,19 +3,128 @@ package categoryservice
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
 	"github.com/google/uuid"
 )
 
 type CategoryServ interface {
 	GetCategorys(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error)
+	// Add, Delete и Update доступны ролям, которым AuthZ.Can разрешает действие над категориями
 	Add(ctx context.Context, addReq reqresp.AddCategoryRequest) error
-	Delete(ctx context.Context, categoryID uuid.UUID) error
+	// Delete удаляет категорию; если на нее ссылаются товары, без detachProducts возвращает ErrCategoryInUse,
+	// а с detachProducts убирает категорию из этих товаров
+	Delete(ctx context.Context, categoryID uuid.UUID, detachProducts bool) error
 	Update(ctx context.Context, updateReq reqresp.UpdateCategoryRequest) error
 }
 
 var (
-	ErrCategoryServ = errors.New("CategoryServ")
+	ErrCategoryServ     = errors.New("CategoryServ")
+	ErrCategoryNotFound = categoryrep.ErrCategoryNotFound
+	ErrCategoryInUse    = categoryrep.ErrCategoryInUse
+	ErrDuplicateTitle   = categoryrep.ErrDuplicateTitle
+
+	ErrParentNotFound      = categoryrep.ErrParentNotFound
+	ErrCategoryCycle       = categoryrep.ErrCategoryCycle
+	ErrCategoryHasChildren = categoryrep.ErrCategoryHasChildren
 )
+
+func NewCategoryServ(authz auth.AuthZ, categoryRep categoryrep.CategoryRep) CategoryServ {
+	return &categoryServ{
+		authz:       authz,
+		categoryRep: categoryRep,
+	}
+}
+
+type categoryServ struct {
+	authz       auth.AuthZ
+	categoryRep categoryrep.CategoryRep
+}
+
+func (s *categoryServ) GetCategorys(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error) {
+	categories, err := s.categoryRep.GetAll(ctx, filterOps)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	return categories, nil
+}
+
+func (s *categoryServ) Add(ctx context.Context, addReq reqresp.AddCategoryRequest) error {
+	if err := s.authorize(ctx, auth.ActionCreate); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	parentID, err := parseParentID(addReq.ParentID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	category, err := models.NewCategory(uuid.New(), addReq.Title, addReq.Description, parentID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	if err := s.categoryRep.Add(ctx, category); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	return nil
+}
+
+func (s *categoryServ) Delete(ctx context.Context, categoryID uuid.UUID, detachProducts bool) error {
+	if err := s.authorize(ctx, auth.ActionDelete); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	deleteCategory := s.categoryRep.Delete
+	if detachProducts {
+		deleteCategory = s.categoryRep.DeleteDetached
+	}
+	if err := deleteCategory(ctx, categoryID); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	return nil
+}
+
+func (s *categoryServ) Update(ctx context.Context, updateReq reqresp.UpdateCategoryRequest) error {
+	if err := s.authorize(ctx, auth.ActionUpdate); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	categoryID, err := uuid.Parse(updateReq.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	parentID, err := parseParentID(updateReq.ParentID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	if parentID == categoryID {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, ErrCategoryCycle)
+	}
+	category, err := models.NewCategory(categoryID, updateReq.Title, updateReq.Description, parentID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	if err := s.categoryRep.Update(ctx, category); err != nil {
+		return fmt.Errorf("%w: %w", ErrCategoryServ, err)
+	}
+	return nil
+}
+
+// parseParentID - пустая строка означает корневую категорию
+func parseParentID(parentID string) (uuid.UUID, error) {
+	if parentID == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(parentID)
+}
+
+// authorize отличает анонимный запрос (auth.ErrNotAuthZ) от недостатка прав (auth.ErrHasNoRights)
+func (s *categoryServ) authorize(ctx context.Context, action auth.Action) error {
+	if _, err := s.authz.UserIDFromContext(ctx); err != nil {
+		return err
+	}
+	if !s.authz.Can(ctx, action, auth.ResourceCategory) {
+		return fmt.Errorf("%w: %s category", auth.ErrHasNoRights, action)
+	}
+	return nil
+}
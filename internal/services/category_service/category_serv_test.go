@@ -0,0 +1,97 @@
+package categoryservice_test
+
+import (
+	"context"
+	"testing"
+
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	categoryservice "github.com/CakeForKit/CraftPlace.git/internal/services/category_service"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type CategoryServSuite struct {
+	suite.Suite
+}
+
+func TestCategoryServ(t *testing.T) {
+	suite.RunSuite(t, new(CategoryServSuite))
+}
+
+func (s *CategoryServSuite) TestCategoryServ_Add(t provider.T) {
+	req := reqresp.AddCategoryRequest{Title: "Керамика", Description: "Посуда и декор"}
+
+	t.WithNewStep("admin", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionCreate, auth.ResourceCategory).Return(true)
+		mockCategoryRep := new(categoryrep.MockCategoryRep)
+		mockCategoryRep.On("Add", ctx, mock.Anything).Return(nil)
+		serv := categoryservice.NewCategoryServ(mockAuthZ, mockCategoryRep)
+
+		sCtx.Require().NoError(serv.Add(ctx, req))
+		mockCategoryRep.AssertExpectations(t)
+	})
+	t.WithNewStep("duplicate title", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionCreate, auth.ResourceCategory).Return(true)
+		mockCategoryRep := new(categoryrep.MockCategoryRep)
+		mockCategoryRep.On("Add", ctx, mock.Anything).Return(categoryrep.ErrDuplicateTitle)
+		serv := categoryservice.NewCategoryServ(mockAuthZ, mockCategoryRep)
+
+		err := serv.Add(ctx, req)
+
+		sCtx.Require().ErrorIs(err, categoryservice.ErrDuplicateTitle)
+	})
+	t.WithNewStep("not admin", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionCreate, auth.ResourceCategory).Return(false)
+		mockCategoryRep := new(categoryrep.MockCategoryRep)
+		serv := categoryservice.NewCategoryServ(mockAuthZ, mockCategoryRep)
+
+		err := serv.Add(ctx, req)
+
+		sCtx.Require().ErrorIs(err, auth.ErrHasNoRights)
+		mockCategoryRep.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CategoryServSuite) TestCategoryServ_Delete(t provider.T) {
+	t.WithNewStep("category in use", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		categoryID := uuid.New()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionDelete, auth.ResourceCategory).Return(true)
+		mockCategoryRep := new(categoryrep.MockCategoryRep)
+		mockCategoryRep.On("Delete", ctx, categoryID).Return(categoryrep.ErrCategoryInUse)
+		serv := categoryservice.NewCategoryServ(mockAuthZ, mockCategoryRep)
+
+		err := serv.Delete(ctx, categoryID, false)
+
+		sCtx.Require().ErrorIs(err, categoryservice.ErrCategoryInUse)
+		mockCategoryRep.AssertNotCalled(t, "DeleteDetached", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("detach products", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		categoryID := uuid.New()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockAuthZ.On("Can", ctx, auth.ActionDelete, auth.ResourceCategory).Return(true)
+		mockCategoryRep := new(categoryrep.MockCategoryRep)
+		mockCategoryRep.On("DeleteDetached", ctx, categoryID).Return(nil)
+		serv := categoryservice.NewCategoryServ(mockAuthZ, mockCategoryRep)
+
+		sCtx.Require().NoError(serv.Delete(ctx, categoryID, true))
+		mockCategoryRep.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
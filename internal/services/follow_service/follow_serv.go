@@ -0,0 +1,94 @@
+package followservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/searcher"
+	"github.com/google/uuid"
+)
+
+// FollowServ - подписки покупателей на магазины и лента постов из них
+type FollowServ interface {
+	// UserID в контексте: подписаться и отписаться можно только от своего имени
+	Follow(ctx context.Context, shopID uuid.UUID) error
+	Unfollow(ctx context.Context, shopID uuid.UUID) error
+	// Feed - посты магазинов подписки, новые первыми. Страницы листаются курсором из NextCursor,
+	// без лимита отдается pagination.DefaultLimit постов.
+	Feed(ctx context.Context, page reqresp.PageOps) (*pagination.Page[*models.Post], error)
+	// FollowerCounts - число подписчиков каждого из магазинов, без подписчиков - 0
+	FollowerCounts(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error)
+}
+
+var (
+	ErrFollowServ    = errors.New("FollowServ")
+	ErrShopNotFound  = followrep.ErrShopNotFound
+	ErrInvalidFilter = searcher.ErrInvalidFilter
+)
+
+func NewFollowServ(authz auth.AuthZ, followRep followrep.FollowRep, searcherServ searcher.Searcher) FollowServ {
+	return &followServ{
+		authz:        authz,
+		followRep:    followRep,
+		searcherServ: searcherServ,
+	}
+}
+
+type followServ struct {
+	authz        auth.AuthZ
+	followRep    followrep.FollowRep
+	searcherServ searcher.Searcher
+}
+
+func (s *followServ) Follow(ctx context.Context, shopID uuid.UUID) error {
+	userID, err := s.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	if err := s.followRep.Follow(ctx, userID, shopID); err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	return nil
+}
+
+func (s *followServ) Unfollow(ctx context.Context, shopID uuid.UUID) error {
+	userID, err := s.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	if err := s.followRep.Unfollow(ctx, userID, shopID); err != nil {
+		return fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	return nil
+}
+
+func (s *followServ) Feed(ctx context.Context, page reqresp.PageOps) (*pagination.Page[*models.Post], error) {
+	userID, err := s.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	posts, err := s.searcherServ.GetPosts(ctx, &reqresp.PostFilter{
+		FollowerID: userID,
+		SortBy:     reqresp.SortByTimePublication,
+		Order:      reqresp.OrderDesc,
+		Page:       page,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	return posts, nil
+}
+
+func (s *followServ) FollowerCounts(ctx context.Context, shopIDs uuid.UUIDs) (map[uuid.UUID]int, error) {
+	counts, err := s.followRep.CountFollowers(ctx, shopIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFollowServ, err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,115 @@
+package followservice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	followservice "github.com/CakeForKit/CraftPlace.git/internal/services/follow_service"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/searcher"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type FollowServSuite struct {
+	suite.Suite
+}
+
+func TestFollowServ(t *testing.T) {
+	suite.RunSuite(t, new(FollowServSuite))
+}
+
+func newSearcher(postRep postrep.PostRep) searcher.Searcher {
+	return searcher.NewSearcher(
+		new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), postRep, new(productrep.MockProductRep),
+		new(searchrep.MockSearchRep), 0,
+	)
+}
+
+func (s *FollowServSuite) TestFollowServ_Follow(t provider.T) {
+	t.WithNewStep("user follows from own name", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		userID, shopID := uuid.New(), uuid.New()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		mockFollowRep := new(followrep.MockFollowRep)
+		mockFollowRep.On("Follow", ctx, userID, shopID).Return(nil)
+		serv := followservice.NewFollowServ(mockAuthZ, mockFollowRep, newSearcher(new(postrep.MockPostRep)))
+
+		err := serv.Follow(ctx, shopID)
+
+		sCtx.Require().NoError(err)
+		mockFollowRep.AssertExpectations(t)
+	})
+	t.WithNewStep("unknown shop", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		userID, shopID := uuid.New(), uuid.New()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		mockFollowRep := new(followrep.MockFollowRep)
+		mockFollowRep.On("Follow", ctx, userID, shopID).Return(followrep.ErrShopNotFound)
+		serv := followservice.NewFollowServ(mockAuthZ, mockFollowRep, newSearcher(new(postrep.MockPostRep)))
+
+		err := serv.Follow(ctx, shopID)
+
+		sCtx.Require().ErrorIs(err, followservice.ErrShopNotFound)
+	})
+	t.WithNewStep("not authorized", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.Nil, auth.ErrNotAuthZ)
+		mockFollowRep := new(followrep.MockFollowRep)
+		serv := followservice.NewFollowServ(mockAuthZ, mockFollowRep, newSearcher(new(postrep.MockPostRep)))
+
+		err := serv.Unfollow(ctx, uuid.New())
+
+		sCtx.Require().ErrorIs(err, auth.ErrNotAuthZ)
+		mockFollowRep.AssertNotCalled(t, "Unfollow", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *FollowServSuite) TestFollowServ_Feed(t provider.T) {
+	t.WithNewStep("posts of followed shops, newest first", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		userID := uuid.New()
+		expected := []*models.Post{testobj.NewPostMother().PostP()}
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(userID, nil)
+		mockPostRep := new(postrep.MockPostRep)
+		withFeedFilter := mock.MatchedBy(func(f *reqresp.PostFilter) bool {
+			return f.FollowerID == userID && f.SortBy == reqresp.SortByTimePublication && f.Order == reqresp.OrderDesc
+		})
+		mockPostRep.On("GetAll", ctx, withFeedFilter).Return(expected, nil)
+		serv := followservice.NewFollowServ(mockAuthZ, new(followrep.MockFollowRep), newSearcher(mockPostRep))
+
+		feed, err := serv.Feed(ctx, reqresp.PageOps{Limit: 10})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(expected, feed.Items)
+		mockPostRep.AssertExpectations(t)
+		mockPostRep.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("invalid cursor", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockAuthZ := new(auth.MockAuthZ)
+		mockAuthZ.On("UserIDFromContext", ctx).Return(uuid.New(), nil)
+		mockPostRep := new(postrep.MockPostRep)
+		serv := followservice.NewFollowServ(mockAuthZ, new(followrep.MockFollowRep), newSearcher(mockPostRep))
+
+		_, err := serv.Feed(ctx, reqresp.PageOps{Cursor: "x", Offset: 3})
+
+		sCtx.Require().ErrorIs(err, followservice.ErrInvalidFilter)
+		mockPostRep.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,55 @@
+package mediaservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	"github.com/google/uuid"
+)
+
+// ImageCleaner удаляет файлы изображений вместе с товарами, постами и магазинами.
+// Строки изображений удаляет каскад в БД, поэтому список файлов берется до удаления
+// записи, а сами файлы удаляются RemoveFiles после него.
+type ImageCleaner interface {
+	OwnerImages(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID) ([]*models.Image, error)
+	ShopImages(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error)
+	RemoveFiles(ctx context.Context, images []*models.Image)
+}
+
+func NewImageCleaner(imageRep imagerep.ImageRep, storage mediastorage.MediaStorage) ImageCleaner {
+	return &imageCleaner{
+		imageRep: imageRep,
+		storage:  storage,
+	}
+}
+
+type imageCleaner struct {
+	imageRep imagerep.ImageRep
+	storage  mediastorage.MediaStorage
+}
+
+func (c *imageCleaner) OwnerImages(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID) ([]*models.Image, error) {
+	images, err := c.imageRep.GetByOwners(ctx, owner, uuid.UUIDs{ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	return images[ownerID], nil
+}
+
+func (c *imageCleaner) ShopImages(ctx context.Context, shopID uuid.UUID) ([]*models.Image, error) {
+	images, err := c.imageRep.GetByShop(ctx, shopID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	return images, nil
+}
+
+// RemoveFiles - ошибки удаления, как и в removeFiles, не возвращаются
+func (c *imageCleaner) RemoveFiles(ctx context.Context, images []*models.Image) {
+	for _, img := range images {
+		removeFiles(ctx, c.storage, img)
+	}
+}
@@ -0,0 +1,193 @@
+package mediaservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	"github.com/google/uuid"
+)
+
+// maxImagePixels защищает от "декомпрессионных бомб": маленький файл с огромными размерами
+// картинки занял бы при декодировании гигабайты памяти
+const maxImagePixels = 40_000_000
+
+// MediaServ - изображения товаров и постов. Менять список изображений может тот,
+// кому OwnerPolicy разрешает изменять саму запись.
+type MediaServ interface {
+	// AddImage проверяет файл, сохраняет оригинал и миниатюру и ставит изображение в конец списка записи
+	AddImage(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, data []byte) (reqresp.ImageResponse, error)
+	DeleteImage(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) error
+	ReorderImages(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, reorderReq reqresp.ReorderImagesRequest) error
+	// Images - изображения записей по порядку, у записи без изображений пустой список
+	Images(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]reqresp.ImageResponse, error)
+}
+
+var (
+	ErrMediaServ            = errors.New("MediaServ")
+	ErrUnsupportedMediaType = errors.New("unsupported image type, expected jpeg, png or gif")
+	ErrImageTooLarge        = errors.New("image is too large")
+	ErrInvalidImage         = errors.New("invalid image")
+	ErrImageNotFound        = imagerep.ErrImageNotFound
+	ErrOwnerNotFound        = imagerep.ErrOwnerNotFound
+	ErrTooManyImages        = imagerep.ErrTooManyImages
+	ErrInvalidOrder         = imagerep.ErrInvalidOrder
+)
+
+// allowedTypes - допустимые типы по результату http.DetectContentType и расширения файлов
+var allowedTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+func NewMediaServ(
+	imageRep imagerep.ImageRep,
+	storage mediastorage.MediaStorage,
+	policy ownerpolicy.OwnerPolicy,
+	maxUploadSize int64,
+) MediaServ {
+	return &mediaServ{
+		imageRep:      imageRep,
+		storage:       storage,
+		policy:        policy,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+type mediaServ struct {
+	imageRep      imagerep.ImageRep
+	storage       mediastorage.MediaStorage
+	policy        ownerpolicy.OwnerPolicy
+	maxUploadSize int64
+}
+
+func (s *mediaServ) AddImage(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, data []byte) (reqresp.ImageResponse, error) {
+	if err := s.authorize(ctx, owner, ownerID); err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	if int64(len(data)) > s.maxUploadSize {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: more than %d bytes", ErrMediaServ, ErrImageTooLarge, s.maxUploadSize)
+	}
+	// тип определяется по содержимому, Content-Type и имя файла от клиента не учитываются
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: %s", ErrMediaServ, ErrUnsupportedMediaType, contentType)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: %w", ErrMediaServ, ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: empty image", ErrMediaServ, ErrInvalidImage)
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: %dx%d pixels", ErrMediaServ, ErrImageTooLarge, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w: %w", ErrMediaServ, ErrInvalidImage, err)
+	}
+	thumb, thumbType, thumbExt, err := makeThumbnail(src, contentType)
+	if err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+
+	imageID := uuid.New()
+	prefix := fmt.Sprintf("%ss/%s/%s", owner, ownerID, imageID)
+	key := prefix + "." + ext
+	thumbKey := prefix + "_thumb." + thumbExt
+	img, err := models.NewImage(imageID, owner, ownerID, 0, key, thumbKey, contentType, config.Width, config.Height)
+	if err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+
+	if err := s.storage.Put(ctx, key, data, contentType); err != nil {
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	if err := s.storage.Put(ctx, thumbKey, thumb, thumbType); err != nil {
+		s.removeFiles(ctx, img)
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	added, err := s.imageRep.Add(ctx, img)
+	if err != nil {
+		s.removeFiles(ctx, img)
+		return reqresp.ImageResponse{}, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	return added.ToResponse(s.storage.URL), nil
+}
+
+func (s *mediaServ) DeleteImage(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, imageID uuid.UUID) error {
+	if err := s.authorize(ctx, owner, ownerID); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	img, err := s.imageRep.Delete(ctx, owner, ownerID, imageID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	s.removeFiles(ctx, img)
+	return nil
+}
+
+func (s *mediaServ) ReorderImages(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID, reorderReq reqresp.ReorderImagesRequest) error {
+	if err := s.authorize(ctx, owner, ownerID); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	if err := s.imageRep.Reorder(ctx, owner, ownerID, reorderReq.ImageIDs); err != nil {
+		return fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	return nil
+}
+
+func (s *mediaServ) Images(ctx context.Context, owner models.ImageOwner, ownerIDs uuid.UUIDs) (map[uuid.UUID][]reqresp.ImageResponse, error) {
+	images, err := s.imageRep.GetByOwners(ctx, owner, ownerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMediaServ, err)
+	}
+	res := make(map[uuid.UUID][]reqresp.ImageResponse, len(ownerIDs))
+	for _, ownerID := range ownerIDs {
+		list := make([]reqresp.ImageResponse, 0, len(images[ownerID]))
+		for _, img := range images[ownerID] {
+			list = append(list, img.ToResponse(s.storage.URL))
+		}
+		res[ownerID] = list
+	}
+	return res, nil
+}
+
+func (s *mediaServ) authorize(ctx context.Context, owner models.ImageOwner, ownerID uuid.UUID) error {
+	var err error
+	switch owner {
+	case models.ImageOwnerProduct:
+		_, err = s.policy.AuthorizeProduct(ctx, ownerID, auth.ActionUpdate)
+	case models.ImageOwnerPost:
+		_, err = s.policy.AuthorizePost(ctx, ownerID, auth.ActionUpdate)
+	default:
+		err = fmt.Errorf("%w: %q", ErrOwnerNotFound, owner)
+	}
+	return err
+}
+
+func (s *mediaServ) removeFiles(ctx context.Context, img *models.Image) {
+	removeFiles(ctx, s.storage, img)
+}
+
+// removeFiles - файлы без записи в репозитории никому не видны, поэтому ошибка
+// удаления не отменяет операцию, а оставляет мусор в хранилище
+func removeFiles(ctx context.Context, storage mediastorage.MediaStorage, img *models.Image) {
+	_ = storage.Delete(ctx, img.GetKey())
+	_ = storage.Delete(ctx, img.GetThumbnailKey())
+}
@@ -0,0 +1,179 @@
+package mediaservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+const maxUploadSize = 1 << 20
+
+type MediaServSuite struct {
+	suite.Suite
+}
+
+func TestMediaServ(t *testing.T) {
+	suite.RunSuite(t, new(MediaServSuite))
+}
+
+func (s *MediaServSuite) TestMediaServ_AddImage(t provider.T) {
+	productCreator := testobj.NewProductMother()
+	imageCreator := testobj.NewImageMother()
+
+	t.WithNewStep("png with thumbnail", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		data := imageCreator.PNG(800, 400)
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		stored := map[string][]byte{}
+		mockStorage := new(mediastorage.MockMediaStorage)
+		mockStorage.On("Put", ctx, mock.Anything, mock.Anything, "image/png").
+			Run(func(args mock.Arguments) { stored[args.String(1)] = args.Get(2).([]byte) }).
+			Return(nil)
+		mockStorage.On("URL", mock.Anything).Return("url")
+		var added *models.Image
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("Add", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { added = args.Get(1).(*models.Image) }).
+			Return(imageCreator.ImageOfProductP(product.GetID()), nil)
+		serv := mediaservice.NewMediaServ(mockImageRep, mockStorage, mockPolicy, maxUploadSize)
+
+		res, err := serv.AddImage(ctx, models.ImageOwnerProduct, product.GetID(), data)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("url", res.URL)
+		sCtx.Require().NotNil(added)
+		sCtx.Assert().Equal("image/png", added.GetContentType())
+		sCtx.Assert().Equal(800, added.GetWidth())
+		sCtx.Assert().Equal(400, added.GetHeight())
+		sCtx.Require().Len(stored, 2)
+		for key, file := range stored {
+			sCtx.Assert().True(strings.HasPrefix(key, "products/"+product.GetID().String()+"/"), key)
+			if !strings.Contains(key, "_thumb") {
+				sCtx.Assert().Equal(data, file)
+				continue
+			}
+			thumb, _, err := image.DecodeConfig(bytes.NewReader(file))
+			sCtx.Require().NoError(err)
+			sCtx.Assert().Equal(mediaservice.ThumbnailSize, thumb.Width)
+			sCtx.Assert().Equal(mediaservice.ThumbnailSize/2, thumb.Height)
+		}
+	})
+	t.WithNewStep("type is sniffed from content", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		serv := mediaservice.NewMediaServ(new(imagerep.MockImageRep), mockStorage, mockPolicy, maxUploadSize)
+
+		_, err := serv.AddImage(ctx, models.ImageOwnerProduct, product.GetID(), []byte("<html><body>not an image</body></html>"))
+
+		sCtx.Require().ErrorIs(err, mediaservice.ErrUnsupportedMediaType)
+		mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("file is too large", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		serv := mediaservice.NewMediaServ(new(imagerep.MockImageRep), new(mediastorage.MockMediaStorage), mockPolicy, 100)
+
+		_, err := serv.AddImage(ctx, models.ImageOwnerProduct, product.GetID(), imageCreator.JPEG(64, 64))
+
+		sCtx.Require().ErrorIs(err, mediaservice.ErrImageTooLarge)
+	})
+	t.WithNewStep("product of another user", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		productID := uuid.New()
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, productID, auth.ActionUpdate).Return(nil, auth.ErrHasNoRights)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		serv := mediaservice.NewMediaServ(new(imagerep.MockImageRep), mockStorage, mockPolicy, maxUploadSize)
+
+		_, err := serv.AddImage(ctx, models.ImageOwnerProduct, productID, imageCreator.PNG(10, 10))
+
+		sCtx.Require().ErrorIs(err, auth.ErrHasNoRights)
+		mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("files are removed when the list is full", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		mockStorage.On("Put", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockStorage.On("Delete", ctx, mock.Anything).Return(nil)
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("Add", ctx, mock.Anything).Return(nil, imagerep.ErrTooManyImages)
+		serv := mediaservice.NewMediaServ(mockImageRep, mockStorage, mockPolicy, maxUploadSize)
+
+		_, err := serv.AddImage(ctx, models.ImageOwnerProduct, product.GetID(), imageCreator.JPEG(32, 32))
+
+		sCtx.Require().ErrorIs(err, mediaservice.ErrTooManyImages)
+		mockStorage.AssertNumberOfCalls(t, "Delete", 2)
+	})
+}
+
+func (s *MediaServSuite) TestMediaServ_DeleteImage(t provider.T) {
+	productCreator := testobj.NewProductMother()
+	imageCreator := testobj.NewImageMother()
+
+	t.WithNewStep("files are deleted after the record", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		img := imageCreator.ImageOfProductP(product.GetID())
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("Delete", ctx, models.ImageOwnerProduct, product.GetID(), img.GetID()).Return(img, nil)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		mockStorage.On("Delete", ctx, img.GetKey()).Return(nil)
+		mockStorage.On("Delete", ctx, img.GetThumbnailKey()).Return(errors.New("storage is unavailable"))
+		serv := mediaservice.NewMediaServ(mockImageRep, mockStorage, mockPolicy, maxUploadSize)
+
+		err := serv.DeleteImage(ctx, models.ImageOwnerProduct, product.GetID(), img.GetID())
+
+		sCtx.Require().NoError(err)
+		mockStorage.AssertExpectations(t)
+	})
+	t.WithNewStep("image not found", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		imageID := uuid.New()
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("Delete", ctx, models.ImageOwnerProduct, product.GetID(), imageID).Return(nil, imagerep.ErrImageNotFound)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		serv := mediaservice.NewMediaServ(mockImageRep, mockStorage, mockPolicy, maxUploadSize)
+
+		err := serv.DeleteImage(ctx, models.ImageOwnerProduct, product.GetID(), imageID)
+
+		sCtx.Require().ErrorIs(err, mediaservice.ErrImageNotFound)
+		mockStorage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
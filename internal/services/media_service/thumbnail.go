@@ -0,0 +1,85 @@
+package mediaservice
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// ThumbnailSize - наибольшая сторона миниатюры в пикселях
+	ThumbnailSize    = 320
+	thumbnailQuality = 85
+	// maxBoxSamples - сколько точек исходника по каждой оси усредняется в пиксель миниатюры
+	maxBoxSamples = 4
+)
+
+// makeThumbnail уменьшает изображение с сохранением пропорций так, чтобы оно вписалось
+// в квадрат ThumbnailSize. Фотографии (jpeg) сохраняются в jpeg, остальное - в png,
+// чтобы не потерять прозрачность. Возвращает данные, их тип и расширение файла.
+func makeThumbnail(src image.Image, contentType string) ([]byte, string, string, error) {
+	dst := downscale(src, ThumbnailSize)
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, "", "", fmt.Errorf("thumbnail: %w", err)
+		}
+		return buf.Bytes(), "image/jpeg", "jpg", nil
+	}
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, "", "", fmt.Errorf("thumbnail: %w", err)
+	}
+	return buf.Bytes(), "image/png", "png", nil
+}
+
+// downscale - усреднение по прямоугольнику исходника, который накрывает пиксель результата.
+// В большом прямоугольнике берется сетка не больше maxBoxSamples x maxBoxSamples точек,
+// чтобы время не зависело от размера исходника. Маленькие изображения не увеличиваются.
+func downscale(src image.Image, size int) *image.NRGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := srcW, srcH
+	if srcW > size || srcH > size {
+		if srcW >= srcH {
+			dstW, dstH = size, max(1, srcH*size/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*size/srcH), size
+		}
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			dst.SetNRGBA(x, y, boxAverage(src, bounds.Min, x0, x1, y0, y1))
+		}
+	}
+	return dst
+}
+
+func boxAverage(src image.Image, origin image.Point, x0, x1, y0, y1 int) color.NRGBA {
+	stepX := max(1, (x1-x0)/maxBoxSamples)
+	stepY := max(1, (y1-y0)/maxBoxSamples)
+	var r, g, b, a, n uint64
+	for sy := y0; sy < y1; sy += stepY {
+		for sx := x0; sx < x1; sx += stepX {
+			// At возвращает цвет с умноженной на альфу яркостью, поэтому суммы усредняются корректно
+			cr, cg, cb, ca := src.At(origin.X+sx, origin.Y+sy).RGBA()
+			r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+			n++
+		}
+	}
+	if a == 0 {
+		return color.NRGBA{}
+	}
+	// обратно из premultiplied в NRGBA
+	return color.NRGBA{
+		R: uint8(r * 0xff / a),
+		G: uint8(g * 0xff / a),
+		B: uint8(b * 0xff / a),
+		A: uint8(a / n >> 8),
+	}
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"sync"
+	"time"
+)
+
+// NewFileSender - письма для разработки: дописываются в файл path в читаемом виде,
+// при пустом path печатаются в стандартный вывод
+func NewFileSender(from string, path string) (Sender, error) {
+	addr, err := parseFrom(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSender, err)
+	}
+	return &fileSender{
+		from: addr,
+		path: path,
+	}, nil
+}
+
+type fileSender struct {
+	from *mail.Address
+	path string
+	mu   sync.Mutex
+}
+
+func (s *fileSender) Send(ctx context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out io.Writer = os.Stdout
+	if s.path != "" {
+		f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrSender, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	_, err := fmt.Fprintf(out, "----- %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n",
+		time.Now().UTC().Format(time.RFC3339), s.from, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSender, err)
+	}
+	return nil
+}
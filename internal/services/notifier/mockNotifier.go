@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) UserRegistered(ctx context.Context, user *models.User, verifyToken string) {
+	m.Called(ctx, user, verifyToken)
+}
+
+func (m *MockNotifier) EmailVerification(ctx context.Context, user *models.User, verifyToken string) {
+	m.Called(ctx, user, verifyToken)
+}
+
+func (m *MockNotifier) PasswordReset(ctx context.Context, user *models.User, resetToken string) {
+	m.Called(ctx, user, resetToken)
+}
+
+func (m *MockNotifier) PasswordChanged(ctx context.Context, user *models.User) {
+	m.Called(ctx, user)
+}
+
+func (m *MockNotifier) PostPublished(ctx context.Context, post *models.Post) {
+	m.Called(ctx, post)
+}
+
+func (m *MockNotifier) Close() {
+	m.Called()
+}
@@ -0,0 +1,234 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	"github.com/google/uuid"
+)
+
+// Notifier - письма пользователям о событиях на платформе. Письма отправляются в фоне:
+// методы не ждут доставки и не возвращают ошибок, ошибки отправки пишутся в лог.
+type Notifier interface {
+	// UserRegistered - приветствие со ссылкой для подтверждения почты, verifyToken - токен для ссылки
+	UserRegistered(ctx context.Context, user *models.User, verifyToken string)
+	EmailVerification(ctx context.Context, user *models.User, verifyToken string)
+	PasswordReset(ctx context.Context, user *models.User, resetToken string)
+	PasswordChanged(ctx context.Context, user *models.User)
+	// PostPublished - письма подписчикам магазина, кроме его владельца, с подтвержденной почтой
+	PostPublished(ctx context.Context, post *models.Post)
+	// Close перестает принимать события и дожидается отправки уже принятых
+	Close()
+}
+
+var (
+	ErrNotifier  = errors.New("Notifier")
+	ErrQueueFull = errors.New("notification queue is full")
+	ErrClosed    = errors.New("notifier is closed")
+)
+
+const (
+	queueSize = 1024
+	workers   = 4
+	// jobTimeout - время на одно событие, для нового поста - на письма всем подписчикам
+	jobTimeout = 5 * time.Minute
+	// maxPostPreview - сколько символов поста попадает в письмо
+	maxPostPreview = 300
+
+	// страницы сайта для ссылок из писем
+	verifyEmailPath   = "/verify-email"
+	resetPasswordPath = "/reset-password"
+)
+
+// job - отложенная отправка писем по одному событию
+type job func(ctx context.Context) error
+
+func NewNotifier(
+	config cnfg.NotifyConfig,
+	sender Sender,
+	userRep userrep.UserRep,
+	shopRep shoprep.ShopRep,
+	followRep followrep.FollowRep,
+) (Notifier, error) {
+	locale := Locale(config.Locale)
+	if !locale.Valid() {
+		return nil, fmt.Errorf("%w: unknown locale %q", ErrNotifier, config.Locale)
+	}
+	tmpls, err := parseTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotifier, err)
+	}
+	n := &notifier{
+		sender:    sender,
+		templates: tmpls,
+		locale:    locale,
+		baseURL:   config.BaseURL,
+		userRep:   userRep,
+		shopRep:   shopRep,
+		followRep: followRep,
+		jobs:      make(chan job, queueSize),
+	}
+	n.wg.Add(workers)
+	for range workers {
+		go n.run()
+	}
+	return n, nil
+}
+
+type notifier struct {
+	sender    Sender
+	templates templates
+	locale    Locale
+	baseURL   string
+	userRep   userrep.UserRep
+	shopRep   shoprep.ShopRep
+	followRep followrep.FollowRep
+
+	jobs   chan job
+	mu     sync.RWMutex // защищает closed от отправки в закрытый канал
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func (n *notifier) UserRegistered(ctx context.Context, user *models.User, verifyToken string) {
+	n.enqueue(EventUserRegistered, func(ctx context.Context) error {
+		return n.sendTo(ctx, user, EventUserRegistered, letterData{ActionURL: n.actionURL(verifyEmailPath, verifyToken)})
+	})
+}
+
+func (n *notifier) EmailVerification(ctx context.Context, user *models.User, verifyToken string) {
+	n.enqueue(EventEmailVerification, func(ctx context.Context) error {
+		return n.sendTo(ctx, user, EventEmailVerification, letterData{ActionURL: n.actionURL(verifyEmailPath, verifyToken)})
+	})
+}
+
+func (n *notifier) PasswordReset(ctx context.Context, user *models.User, resetToken string) {
+	n.enqueue(EventPasswordReset, func(ctx context.Context) error {
+		return n.sendTo(ctx, user, EventPasswordReset, letterData{ActionURL: n.actionURL(resetPasswordPath, resetToken)})
+	})
+}
+
+func (n *notifier) PasswordChanged(ctx context.Context, user *models.User) {
+	n.enqueue(EventPasswordChanged, func(ctx context.Context) error {
+		return n.sendTo(ctx, user, EventPasswordChanged, letterData{})
+	})
+}
+
+func (n *notifier) PostPublished(ctx context.Context, post *models.Post) {
+	n.enqueue(EventPostPublished, func(ctx context.Context) error {
+		shop, err := n.shopRep.GetByID(ctx, post.GetShopID())
+		if err != nil {
+			return err
+		}
+		followerIDs, err := n.followRep.FollowerIDs(ctx, shop.GetID())
+		if err != nil {
+			return err
+		}
+		data := letterData{
+			ShopTitle: shop.GetTitle(),
+			ShopURL:   fmt.Sprintf("%s/shops/%s", n.baseURL, shop.GetID()),
+			PostText:  preview(post.GetDescription()),
+		}
+		// письмо одному подписчику не должно мешать остальным
+		var errs []error
+		for _, id := range followerIDs {
+			if id == shop.GetUserID() {
+				continue
+			}
+			if err := n.sendToFollower(ctx, id, data); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func (n *notifier) Close() {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.jobs)
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+func (n *notifier) enqueue(event Event, j job) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.closed {
+		log.Printf("%v: %s: %v", ErrNotifier, event, ErrClosed)
+		return
+	}
+	select {
+	case n.jobs <- j:
+	default:
+		log.Printf("%v: %s: %v", ErrNotifier, event, ErrQueueFull)
+	}
+}
+
+func (n *notifier) run() {
+	defer n.wg.Done()
+	for j := range n.jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+		if err := j(ctx); err != nil {
+			log.Printf("%v: %v", ErrNotifier, err)
+		}
+		cancel()
+	}
+}
+
+// sendToFollower - рассылки получают только пользователи с подтвержденной почтой
+func (n *notifier) sendToFollower(ctx context.Context, userID uuid.UUID, data letterData) error {
+	user, err := n.userRep.GetByID(ctx, userID)
+	if errors.Is(err, userrep.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		return nil
+	}
+	return n.sendTo(ctx, user, EventPostPublished, data)
+}
+
+// sendTo отправляет письмо пользователю, если у него есть адрес почты
+func (n *notifier) sendTo(ctx context.Context, user *models.User, event Event, data letterData) error {
+	to := user.GetEmail()
+	if to == "" {
+		return nil
+	}
+	data.Username = user.GetUsername()
+	data.SiteURL = n.baseURL
+	subject, body, err := n.templates.render(n.locale, event, data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", event, err)
+	}
+	if err := n.sender.Send(ctx, Message{To: to, Subject: subject, Body: body}); err != nil {
+		return fmt.Errorf("%s to %s: %w", event, to, err)
+	}
+	return nil
+}
+
+// actionURL - ссылка из письма на страницу сайта, которая передает токен в API
+func (n *notifier) actionURL(path string, token string) string {
+	return n.baseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func preview(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxPostPreview {
+		return text
+	}
+	return string(runes[:maxPostPreview]) + "…"
+}
@@ -0,0 +1,153 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	followrep "github.com/CakeForKit/CraftPlace.git/internal/repository/follow_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type NotifierSuite struct {
+	suite.Suite
+}
+
+func TestNotifier(t *testing.T) {
+	suite.RunSuite(t, new(NotifierSuite))
+}
+
+func notifyConfig(locale string) cnfg.NotifyConfig {
+	return cnfg.NotifyConfig{
+		Sender:  cnfg.NotifyFile,
+		From:    "CraftPlace <noreply@craftplace.local>",
+		Locale:  locale,
+		BaseURL: "https://craftplace.ru",
+	}
+}
+
+func (s *NotifierSuite) TestNotifier_UserRegistered(t provider.T) {
+	t.WithNewStep("russian letter with verification link", func(sCtx provider.StepCtx) {
+		user := testobj.NewUserMother().UserWithEmailP(uuid.New(), "anna@example.com", true)
+		mockSender := new(notifier.MockSender)
+		mockSender.On("Send", mock.Anything, mock.Anything).Return(nil)
+		n, err := notifier.NewNotifier(notifyConfig("ru"), mockSender,
+			new(userrep.MockUserRep), new(shoprep.MockShopRep), new(followrep.MockFollowRep))
+		sCtx.Require().NoError(err)
+
+		n.UserRegistered(context.Background(), user, "verify-token")
+		n.Close()
+
+		mockSender.AssertNumberOfCalls(t, "Send", 1)
+		msg := mockSender.Calls[0].Arguments.Get(1).(notifier.Message)
+		sCtx.Assert().Equal("anna@example.com", msg.To)
+		sCtx.Assert().Equal("Добро пожаловать в CraftPlace", msg.Subject)
+		sCtx.Assert().Contains(msg.Body, "Здравствуйте, test-user!")
+		sCtx.Assert().Contains(msg.Body, "https://craftplace.ru/verify-email?token=verify-token")
+	})
+	t.WithNewStep("english letter", func(sCtx provider.StepCtx) {
+		user := testobj.NewUserMother().UserWithEmailP(uuid.New(), "anna@example.com", true)
+		mockSender := new(notifier.MockSender)
+		mockSender.On("Send", mock.Anything, mock.Anything).Return(nil)
+		n, err := notifier.NewNotifier(notifyConfig("en"), mockSender,
+			new(userrep.MockUserRep), new(shoprep.MockShopRep), new(followrep.MockFollowRep))
+		sCtx.Require().NoError(err)
+
+		n.PasswordChanged(context.Background(), user)
+		n.Close()
+
+		mockSender.AssertNumberOfCalls(t, "Send", 1)
+		msg := mockSender.Calls[0].Arguments.Get(1).(notifier.Message)
+		sCtx.Assert().Equal("Your password has been changed", msg.Subject)
+		sCtx.Assert().True(strings.HasPrefix(msg.Body, "Hello, test-user!"))
+	})
+	t.WithNewStep("user without email gets no letter", func(sCtx provider.StepCtx) {
+		user := testobj.NewUserMother().DefaultUserP(uuid.New())
+		mockSender := new(notifier.MockSender)
+		n, err := notifier.NewNotifier(notifyConfig("ru"), mockSender,
+			new(userrep.MockUserRep), new(shoprep.MockShopRep), new(followrep.MockFollowRep))
+		sCtx.Require().NoError(err)
+
+		n.UserRegistered(context.Background(), user, "verify-token")
+		n.Close()
+
+		mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("unknown locale", func(sCtx provider.StepCtx) {
+		_, err := notifier.NewNotifier(notifyConfig("de"), new(notifier.MockSender),
+			new(userrep.MockUserRep), new(shoprep.MockShopRep), new(followrep.MockFollowRep))
+		sCtx.Require().ErrorIs(err, notifier.ErrNotifier)
+	})
+}
+
+func (s *NotifierSuite) TestNotifier_PostPublished(t provider.T) {
+	t.WithNewStep("letters to verified followers except the owner", func(sCtx provider.StepCtx) {
+		userMother := testobj.NewUserMother()
+		owner := userMother.UserWithEmailP(uuid.New(), "master@example.com", true)
+		shop, err := models.NewShop(uuid.New(), "Звёздная мастерская", "", owner.GetID(), nil)
+		sCtx.Require().NoError(err)
+		post, err := models.NewPost(uuid.New(), "Новые серьги", time.Now().UTC(), shop.GetID())
+		sCtx.Require().NoError(err)
+		withEmail := userMother.UserWithEmailP(uuid.New(), "anna@example.com", true)
+		failing := userMother.UserWithEmailP(uuid.New(), "bad@example.com", true)
+		noEmail := userMother.DefaultUserP(uuid.New())
+		unverified := userMother.UserWithEmailP(uuid.New(), "ivan@example.com", false)
+
+		mockShopRep := new(shoprep.MockShopRep)
+		mockShopRep.On("GetByID", mock.Anything, shop.GetID()).Return(shop, nil)
+		mockFollowRep := new(followrep.MockFollowRep)
+		mockFollowRep.On("FollowerIDs", mock.Anything, shop.GetID()).
+			Return(uuid.UUIDs{failing.GetID(), owner.GetID(), noEmail.GetID(), unverified.GetID(), withEmail.GetID()}, nil)
+		mockUserRep := new(userrep.MockUserRep)
+		for _, user := range []*models.User{owner, withEmail, failing, noEmail, unverified} {
+			mockUserRep.On("GetByID", mock.Anything, user.GetID()).Return(user, nil)
+		}
+		mockSender := new(notifier.MockSender)
+		mockSender.On("Send", mock.Anything, mock.MatchedBy(func(msg notifier.Message) bool {
+			return msg.To == "bad@example.com"
+		})).Return(errors.New("mailbox unavailable"))
+		mockSender.On("Send", mock.Anything, mock.Anything).Return(nil)
+		n, err := notifier.NewNotifier(notifyConfig("ru"), mockSender, mockUserRep, mockShopRep, mockFollowRep)
+		sCtx.Require().NoError(err)
+
+		n.PostPublished(context.Background(), post)
+		n.Close()
+
+		mockSender.AssertNumberOfCalls(t, "Send", 2)
+		msg := mockSender.Calls[1].Arguments.Get(1).(notifier.Message)
+		sCtx.Assert().Equal("anna@example.com", msg.To)
+		sCtx.Assert().Equal("Новый пост в магазине «Звёздная мастерская»", msg.Subject)
+		sCtx.Assert().Contains(msg.Body, "Новые серьги")
+		sCtx.Assert().Contains(msg.Body, "https://craftplace.ru/shops/"+shop.GetID().String())
+	})
+}
+
+func (s *NotifierSuite) TestNotifier_PasswordReset(t provider.T) {
+	t.WithNewStep("letter with reset link", func(sCtx provider.StepCtx) {
+		user := testobj.NewUserMother().UserWithEmailP(uuid.New(), "anna@example.com", false)
+		mockSender := new(notifier.MockSender)
+		mockSender.On("Send", mock.Anything, mock.Anything).Return(nil)
+		n, err := notifier.NewNotifier(notifyConfig("en"), mockSender,
+			new(userrep.MockUserRep), new(shoprep.MockShopRep), new(followrep.MockFollowRep))
+		sCtx.Require().NoError(err)
+
+		n.PasswordReset(context.Background(), user, "a+b/c")
+		n.Close()
+
+		mockSender.AssertNumberOfCalls(t, "Send", 1)
+		msg := mockSender.Calls[0].Arguments.Get(1).(notifier.Message)
+		sCtx.Assert().Equal("anna@example.com", msg.To)
+		sCtx.Assert().Contains(msg.Body, "https://craftplace.ru/reset-password?token=a%2Bb%2Fc")
+	})
+}
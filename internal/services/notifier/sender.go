@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+// Message - письмо одному получателю
+type Message struct {
+	To      string // адрес без имени: user@example.com
+	Subject string
+	Body    string // обычный текст
+}
+
+// Sender доставляет письма: по SMTP или в файл для разработки
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var (
+	ErrSender      = errors.New("Sender")
+	ErrInvalidFrom = errors.New("invalid sender address")
+)
+
+// NewSender выбирает реализацию по config.Sender
+func NewSender(config cnfg.NotifyConfig) (Sender, error) {
+	switch config.Sender {
+	case cnfg.NotifyFile:
+		return NewFileSender(config.From, config.FilePath)
+	case cnfg.NotifySMTP:
+		return NewSMTPSender(config)
+	default:
+		return nil, fmt.Errorf("%w: unknown sender %q", ErrSender, config.Sender)
+	}
+}
+
+func parseFrom(from string) (*mail.Address, error) {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFrom, err)
+	}
+	return addr, nil
+}
+
+// encodeMessage собирает письмо в формате RFC 5322: заголовки в UTF-8 кодируются по RFC 2047,
+// текст - quoted-printable
+func encodeMessage(from *mail.Address, msg Message, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", (&mail.Address{Address: msg.To}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(strings.ReplaceAll(msg.Body, "\r\n", "\n"))); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
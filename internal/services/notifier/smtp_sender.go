@@ -0,0 +1,103 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+// NewSMTPSender - отправка через SMTP-сервер config.SMTPHost:config.SMTPPort.
+// Если сервер поддерживает STARTTLS, соединение шифруется; при заданном SMTPUsername
+// используется AUTH PLAIN, которую net/smtp разрешает только по TLS или на localhost.
+func NewSMTPSender(config cnfg.NotifyConfig) (Sender, error) {
+	addr, err := parseFrom(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSender, err)
+	}
+	s := &smtpSender{
+		from:    addr,
+		host:    config.SMTPHost,
+		addr:    net.JoinHostPort(config.SMTPHost, strconv.Itoa(config.SMTPPort)),
+		timeout: config.SMTPTimeout,
+	}
+	if config.SMTPUsername != "" {
+		s.auth = smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+	}
+	return s, nil
+}
+
+type smtpSender struct {
+	from    *mail.Address
+	host    string
+	addr    string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	data, err := encodeMessage(s.from, msg, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSender, err)
+	}
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	if err := s.send(ctx, msg.To, data); err != nil {
+		return fmt.Errorf("%w: smtp %s: %w", ErrSender, s.addr, err)
+	}
+	return nil
+}
+
+func (s *smtpSender) send(ctx context.Context, to string, data []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.auth != nil {
+		if err := c.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,145 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type SMTPSenderSuite struct {
+	suite.Suite
+}
+
+func TestSMTPSender(t *testing.T) {
+	suite.RunSuite(t, new(SMTPSenderSuite))
+}
+
+// fakeSMTP - SMTP-сервер на localhost, принимает одно письмо
+type fakeSMTP struct {
+	ln   net.Listener
+	from string
+	to   []string
+	data chan string
+}
+
+func newFakeSMTP(t provider.StepCtx) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	t.Require().NoError(err)
+	s := &fakeSMTP{ln: ln, data: make(chan string, 1)}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		switch upper := strings.ToUpper(cmd); {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.data <- data.String()
+			reply("250 queued")
+		case upper == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *SMTPSenderSuite) TestSMTPSender_Send(t provider.T) {
+	t.WithNewStep("letter reaches the server", func(sCtx provider.StepCtx) {
+		server := newFakeSMTP(sCtx)
+		defer server.ln.Close()
+		sender, err := notifier.NewSMTPSender(cnfg.NotifyConfig{
+			From:        "CraftPlace <noreply@craftplace.local>",
+			SMTPHost:    "127.0.0.1",
+			SMTPPort:    server.port(),
+			SMTPTimeout: 5 * time.Second,
+		})
+		sCtx.Require().NoError(err)
+
+		err = sender.Send(context.Background(), notifier.Message{
+			To:      "anna@example.com",
+			Subject: "Новый пост в магазине «Звёзды»",
+			Body:    "Здравствуйте!\nНовые серьги уже в магазине.\n",
+		})
+		sCtx.Require().NoError(err)
+
+		data := <-server.data
+		sCtx.Assert().Equal("noreply@craftplace.local", server.from)
+		sCtx.Assert().Equal([]string{"anna@example.com"}, server.to)
+		msg, err := mail.ReadMessage(strings.NewReader(data))
+		sCtx.Require().NoError(err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("Новый пост в магазине «Звёзды»", subject)
+		sCtx.Assert().Equal("<anna@example.com>", msg.Header.Get("To"))
+		body := new(strings.Builder)
+		_, err = bufio.NewReader(quotedprintable.NewReader(msg.Body)).WriteTo(body)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("Здравствуйте!\r\nНовые серьги уже в магазине.\r\n", body.String())
+	})
+	t.WithNewStep("server is down", func(sCtx provider.StepCtx) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		sCtx.Require().NoError(err)
+		port := ln.Addr().(*net.TCPAddr).Port
+		ln.Close()
+		sender, err := notifier.NewSMTPSender(cnfg.NotifyConfig{
+			From:        "noreply@craftplace.local",
+			SMTPHost:    "127.0.0.1",
+			SMTPPort:    port,
+			SMTPTimeout: time.Second,
+		})
+		sCtx.Require().NoError(err)
+
+		err = sender.Send(context.Background(), notifier.Message{To: "anna@example.com", Subject: "s", Body: "b"})
+		sCtx.Require().ErrorIs(err, notifier.ErrSender)
+	})
+}
@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Locale - язык писем
+type Locale string
+
+const (
+	LocaleRu Locale = "ru"
+	LocaleEn Locale = "en"
+)
+
+func (l Locale) Valid() bool {
+	return l == LocaleRu || l == LocaleEn
+}
+
+// Event - событие, о котором пишется письмо; шаблон письма лежит в templates/{locale}/{event}.tmpl
+type Event string
+
+const (
+	EventUserRegistered    Event = "user_registered"
+	EventEmailVerification Event = "email_verification"
+	EventPasswordReset     Event = "password_reset"
+	EventPasswordChanged   Event = "password_changed"
+	EventPostPublished     Event = "post_published"
+)
+
+var (
+	locales = []Locale{LocaleRu, LocaleEn}
+	events  = []Event{
+		EventUserRegistered, EventEmailVerification, EventPasswordReset, EventPasswordChanged, EventPostPublished,
+	}
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// letterData - данные для шаблонов писем
+type letterData struct {
+	Username  string
+	SiteURL   string
+	ActionURL string // ссылка с одноразовым токеном
+	ShopTitle string
+	ShopURL   string
+	PostText  string
+}
+
+// templates - шаблоны по языку и событию, в каждом определены subject и body
+type templates map[Locale]map[Event]*template.Template
+
+func parseTemplates() (templates, error) {
+	res := make(templates, len(locales))
+	for _, locale := range locales {
+		res[locale] = make(map[Event]*template.Template, len(events))
+		for _, event := range events {
+			name := fmt.Sprintf("templates/%s/%s.tmpl", locale, event)
+			tmpl, err := template.New(string(event)).Option("missingkey=error").ParseFS(templatesFS, name)
+			if err != nil {
+				return nil, err
+			}
+			for _, part := range []string{"subject", "body"} {
+				if tmpl.Lookup(part) == nil {
+					return nil, fmt.Errorf("%s: no %q template", name, part)
+				}
+			}
+			res[locale][event] = tmpl
+		}
+	}
+	return res, nil
+}
+
+func (t templates) render(locale Locale, event Event, data letterData) (subject string, body string, err error) {
+	tmpl, ok := t[locale][event]
+	if !ok {
+		return "", "", fmt.Errorf("no template %s/%s", locale, event)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	// тема письма - одна строка
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(buf.String()) + "\n", nil
+}
@@ -3,12 +3,20 @@ package postservice
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
 	"github.com/google/uuid"
 )
 
+// PostServ - публиковать и удалять посты может только владелец магазина
 type PostServ interface {
 	GetPosts(ctx context.Context) ([]*models.Post, error)
 	Add(ctx context.Context, addReq reqresp.AddPostRequest) error
@@ -17,5 +25,68 @@ type PostServ interface {
 }
 
 var (
-	ErrPostServ = errors.New("PostServ")
+	ErrPostServ        = errors.New("PostServ")
+	ErrPostNotFound    = postrep.ErrPostNotFound
+	ErrUpdateForbidden = errors.New("post can not be changed after publication")
 )
+
+func NewPostServ(
+	postRep postrep.PostRep, policy ownerpolicy.OwnerPolicy, notifier notifier.Notifier, images mediaservice.ImageCleaner,
+) PostServ {
+	return &postServ{
+		postRep:  postRep,
+		policy:   policy,
+		notifier: notifier,
+		images:   images,
+	}
+}
+
+type postServ struct {
+	postRep  postrep.PostRep
+	policy   ownerpolicy.OwnerPolicy
+	notifier notifier.Notifier
+	images   mediaservice.ImageCleaner
+}
+
+func (s *postServ) GetPosts(ctx context.Context) ([]*models.Post, error) {
+	posts, err := s.postRep.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	return posts, nil
+}
+
+func (s *postServ) Add(ctx context.Context, addReq reqresp.AddPostRequest) error {
+	if _, err := s.policy.AuthorizeShop(ctx, addReq.ShopID, auth.ActionUpdate); err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	post, err := models.NewPost(uuid.New(), addReq.Description, time.Now().UTC(), addReq.ShopID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	if err := s.postRep.Add(ctx, post); err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	s.notifier.PostPublished(ctx, post)
+	return nil
+}
+
+func (s *postServ) Delete(ctx context.Context, postID uuid.UUID) error {
+	if _, err := s.policy.AuthorizePost(ctx, postID, auth.ActionDelete); err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	images, err := s.images.OwnerImages(ctx, models.ImageOwnerPost, postID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	if err := s.postRep.Delete(ctx, postID); err != nil {
+		return fmt.Errorf("%w: %w", ErrPostServ, err)
+	}
+	s.images.RemoveFiles(ctx, images)
+	return nil
+}
+
+// Update - пост нельзя изменить после публикации
+func (s *postServ) Update(ctx context.Context, updateReq reqresp.UpdatePostRequest) error {
+	return fmt.Errorf("%w: %w", ErrPostServ, ErrUpdateForbidden)
+}
@@ -3,11 +3,18 @@ package productservice
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
 	"github.com/google/uuid"
 )
 
+// ProductServ - товары может менять только владелец магазина
 type ProductServ interface {
 	Add(ctx context.Context, addReq reqresp.AddProductRequest) error
 	Delete(ctx context.Context, productID uuid.UUID) error
@@ -15,5 +22,90 @@ type ProductServ interface {
 }
 
 var (
-	ErrProductServ = errors.New("ProductServ")
+	ErrProductServ     = errors.New("ProductServ")
+	ErrProductNotFound = productrep.ErrProductNotFound
 )
+
+func NewProductServ(
+	productRep productrep.ProductRep, policy ownerpolicy.OwnerPolicy, images mediaservice.ImageCleaner,
+) ProductServ {
+	return &productServ{
+		productRep: productRep,
+		policy:     policy,
+		images:     images,
+	}
+}
+
+type productServ struct {
+	productRep productrep.ProductRep
+	policy     ownerpolicy.OwnerPolicy
+	images     mediaservice.ImageCleaner
+}
+
+func (s *productServ) Add(ctx context.Context, addReq reqresp.AddProductRequest) error {
+	if _, err := s.policy.AuthorizeShop(ctx, addReq.ShopID, auth.ActionUpdate); err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	product, err := models.NewProduct(
+		uuid.New(),
+		addReq.Title,
+		addReq.Description,
+		addReq.Cost,
+		addReq.ShopID,
+		addReq.CategoryIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	if err := s.productRep.Add(ctx, product); err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	return nil
+}
+
+func (s *productServ) Delete(ctx context.Context, productID uuid.UUID) error {
+	if _, err := s.policy.AuthorizeProduct(ctx, productID, auth.ActionDelete); err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	images, err := s.images.OwnerImages(ctx, models.ImageOwnerProduct, productID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	if err := s.productRep.Delete(ctx, productID); err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	s.images.RemoveFiles(ctx, images)
+	return nil
+}
+
+func (s *productServ) Update(ctx context.Context, updateReq reqresp.UpdateProductRequest) error {
+	productID, err := uuid.Parse(updateReq.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	current, err := s.policy.AuthorizeProduct(ctx, productID, auth.ActionUpdate)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	// товар можно перенести только в другой свой магазин
+	if updateReq.ShopID != current.GetShopID() {
+		if _, err := s.policy.AuthorizeShop(ctx, updateReq.ShopID, auth.ActionUpdate); err != nil {
+			return fmt.Errorf("%w: %w", ErrProductServ, err)
+		}
+	}
+	product, err := models.NewProduct(
+		productID,
+		updateReq.Title,
+		updateReq.Description,
+		updateReq.Cost,
+		updateReq.ShopID,
+		updateReq.CategoryIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	if err := s.productRep.Update(ctx, product); err != nil {
+		return fmt.Errorf("%w: %w", ErrProductServ, err)
+	}
+	return nil
+}
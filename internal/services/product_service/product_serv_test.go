@@ -0,0 +1,129 @@
+package productservice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	imagerep "github.com/CakeForKit/CraftPlace.git/internal/repository/image_rep"
+	mediastorage "github.com/CakeForKit/CraftPlace.git/internal/repository/media_storage"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
+	productservice "github.com/CakeForKit/CraftPlace.git/internal/services/product_service"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type ProductServSuite struct {
+	suite.Suite
+}
+
+func TestProductServ(t *testing.T) {
+	suite.RunSuite(t, new(ProductServSuite))
+}
+
+func (s *ProductServSuite) TestProductServ_Update(t provider.T) {
+	productCreator := testobj.NewProductMother()
+	shopCreator := testobj.NewShopMother()
+
+	t.WithNewStep("move product to own shop", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		newShop := shopCreator.ShopP()
+		req := reqresp.UpdateProductRequest{
+			ID:          product.GetID().String(),
+			Title:       product.GetTitle(),
+			Description: product.GetDescription(),
+			Cost:        product.GetCost(),
+			ShopID:      newShop.GetID(),
+		}
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockPolicy.On("AuthorizeShop", ctx, newShop.GetID(), auth.ActionUpdate).Return(newShop, nil)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("Update", ctx, mock.Anything).Return(nil)
+		serv := productservice.NewProductServ(mockProductRep, mockPolicy, nil)
+
+		err := serv.Update(ctx, req)
+
+		sCtx.Require().NoError(err)
+		mockPolicy.AssertExpectations(t)
+	})
+	t.WithNewStep("move product to shop of another user", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		foreignShopID := uuid.New()
+		req := reqresp.UpdateProductRequest{
+			ID:          product.GetID().String(),
+			Title:       product.GetTitle(),
+			Description: product.GetDescription(),
+			Cost:        product.GetCost(),
+			ShopID:      foreignShopID,
+		}
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionUpdate).Return(product, nil)
+		mockPolicy.On("AuthorizeShop", ctx, foreignShopID, auth.ActionUpdate).Return(nil, auth.ErrHasNoRights)
+		mockProductRep := new(productrep.MockProductRep)
+		serv := productservice.NewProductServ(mockProductRep, mockPolicy, nil)
+
+		err := serv.Update(ctx, req)
+
+		sCtx.Require().ErrorIs(err, auth.ErrHasNoRights)
+		mockProductRep.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *ProductServSuite) TestProductServ_Delete(t provider.T) {
+	productCreator := testobj.NewProductMother()
+
+	t.WithNewStep("image files are removed with the product", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		img := testobj.NewImageMother().ImageOfProductP(product.GetID())
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionDelete).Return(product, nil)
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("GetByOwners", ctx, models.ImageOwnerProduct, uuid.UUIDs{product.GetID()}).
+			Return(map[uuid.UUID][]*models.Image{product.GetID(): {img}}, nil)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		mockStorage.On("Delete", ctx, mock.Anything).Return(nil)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("Delete", ctx, product.GetID()).Return(nil)
+		serv := productservice.NewProductServ(mockProductRep, mockPolicy, mediaservice.NewImageCleaner(mockImageRep, mockStorage))
+
+		err := serv.Delete(ctx, product.GetID())
+
+		sCtx.Require().NoError(err)
+		mockStorage.AssertCalled(t, "Delete", ctx, img.GetKey())
+		mockStorage.AssertCalled(t, "Delete", ctx, img.GetThumbnailKey())
+	})
+	t.WithNewStep("files are kept when the product is not deleted", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		product := productCreator.ProductP()
+		img := testobj.NewImageMother().ImageOfProductP(product.GetID())
+
+		mockPolicy := new(ownerpolicy.MockOwnerPolicy)
+		mockPolicy.On("AuthorizeProduct", ctx, product.GetID(), auth.ActionDelete).Return(product, nil)
+		mockImageRep := new(imagerep.MockImageRep)
+		mockImageRep.On("GetByOwners", ctx, models.ImageOwnerProduct, uuid.UUIDs{product.GetID()}).
+			Return(map[uuid.UUID][]*models.Image{product.GetID(): {img}}, nil)
+		mockStorage := new(mediastorage.MockMediaStorage)
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("Delete", ctx, product.GetID()).Return(productrep.ErrProductRep)
+		serv := productservice.NewProductServ(mockProductRep, mockPolicy, mediaservice.NewImageCleaner(mockImageRep, mockStorage))
+
+		err := serv.Delete(ctx, product.GetID())
+
+		sCtx.Require().ErrorIs(err, productrep.ErrProductRep)
+		mockStorage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
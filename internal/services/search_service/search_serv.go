@@ -0,0 +1,89 @@
+package searchservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+)
+
+// MaxQueryLen - ограничение длины поисковой строки в символах
+const MaxQueryLen = 200
+
+// SearchServ - полнотекстовый поиск по товарам, магазинам и постам
+type SearchServ interface {
+	// Search возвращает страницу результатов, самые релевантные первыми.
+	// Без лимита отдается pagination.DefaultLimit записей.
+	Search(ctx context.Context, filterOps *reqresp.SearchFilter) (*pagination.Page[*models.SearchHit], error)
+}
+
+var (
+	ErrSearchServ    = errors.New("SearchServ")
+	ErrInvalidFilter = errors.New("invalid search filter")
+)
+
+func NewSearchServ(searchRep searchrep.SearchRep) SearchServ {
+	return &searchServ{
+		searchRep: searchRep,
+	}
+}
+
+type searchServ struct {
+	searchRep searchrep.SearchRep
+}
+
+func (s *searchServ) Search(ctx context.Context, filterOps *reqresp.SearchFilter) (*pagination.Page[*models.SearchHit], error) {
+	f := reqresp.SearchFilter{}
+	if filterOps != nil {
+		f = *filterOps
+	}
+	if err := normalize(&f); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	total, err := s.searchRep.Count(ctx, &f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSearchServ, err)
+	}
+	items := make([]*models.SearchHit, 0)
+	if total > f.Page.Offset {
+		items, err = s.searchRep.Search(ctx, &f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSearchServ, err)
+		}
+	}
+	return &pagination.Page[*models.SearchHit]{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// normalize проверяет запрос и страницу и подставляет значения по умолчанию
+func normalize(f *reqresp.SearchFilter) error {
+	if utf8.RuneCountInString(f.Query) > MaxQueryLen {
+		return fmt.Errorf("query is longer than %d characters", MaxQueryLen)
+	}
+	if len(searchrep.QueryTerms(f.Query)) == 0 {
+		return errors.New("query has no words")
+	}
+	if f.Kind != "" && !models.SearchKind(f.Kind).Valid() {
+		return fmt.Errorf("unknown kind %q", f.Kind)
+	}
+	if f.Page.Cursor != "" {
+		return errors.New("search results are paged by offset, cursor is not supported")
+	}
+	if f.Page.Offset < 0 {
+		return fmt.Errorf("negative offset %d", f.Page.Offset)
+	}
+	if f.Page.Limit <= 0 {
+		f.Page.Limit = pagination.DefaultLimit
+	} else if f.Page.Limit > pagination.MaxLimit {
+		f.Page.Limit = pagination.MaxLimit
+	}
+	return nil
+}
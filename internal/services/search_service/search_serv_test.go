@@ -0,0 +1,73 @@
+package searchservice_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	searchservice "github.com/CakeForKit/CraftPlace.git/internal/services/search_service"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type SearchServSuite struct {
+	suite.Suite
+}
+
+func TestSearchServ(t *testing.T) {
+	suite.RunSuite(t, new(SearchServSuite))
+}
+
+func (s *SearchServSuite) TestSearchServ_Search(t provider.T) {
+	t.WithNewStep("default page", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		hit, err := models.NewSearchHit(models.SearchKindShop, uuid.New(), uuid.New(), "Звезды", "<mark>Звезды</mark>", 0.6)
+		sCtx.Require().NoError(err)
+		expected := &reqresp.SearchFilter{Query: "звезды", Page: reqresp.PageOps{Limit: pagination.DefaultLimit}}
+
+		mockSearchRep := new(searchrep.MockSearchRep)
+		mockSearchRep.On("Count", ctx, expected).Return(1, nil)
+		mockSearchRep.On("Search", ctx, expected).Return([]*models.SearchHit{hit}, nil)
+		serv := searchservice.NewSearchServ(mockSearchRep)
+
+		res, err := serv.Search(ctx, &reqresp.SearchFilter{Query: "звезды"})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(1, res.Total)
+		sCtx.Assert().Equal([]*models.SearchHit{hit}, res.Items)
+	})
+	t.WithNewStep("offset past the results", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockSearchRep := new(searchrep.MockSearchRep)
+		mockSearchRep.On("Count", ctx, mock.Anything).Return(3, nil)
+		serv := searchservice.NewSearchServ(mockSearchRep)
+
+		res, err := serv.Search(ctx, &reqresp.SearchFilter{Query: "звезды", Page: reqresp.PageOps{Offset: 3}})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(3, res.Total)
+		sCtx.Assert().Empty(res.Items)
+		mockSearchRep.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("invalid filter", func(sCtx provider.StepCtx) {
+		serv := searchservice.NewSearchServ(new(searchrep.MockSearchRep))
+		filters := []reqresp.SearchFilter{
+			{Query: ""},
+			{Query: " ?! "},
+			{Query: strings.Repeat("а", searchservice.MaxQueryLen+1)},
+			{Query: "звезды", Kind: "category"},
+			{Query: "звезды", Page: reqresp.PageOps{Cursor: "abc"}},
+		}
+		for _, filterOps := range filters {
+			_, err := serv.Search(context.Background(), &filterOps)
+
+			sCtx.Assert().ErrorIs(err, searchservice.ErrInvalidFilter, filterOps)
+		}
+	})
+}
@@ -3,77 +3,240 @@ package searcher
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
 
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
-	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
 	"github.com/google/uuid"
 )
 
 type Searcher interface {
-	GetCategories(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error)
-	GetShops(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error)
-	GetPosts(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error)
-	GetProducts(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error)
+	// Списки возвращаются постранично: без лимита отдается pagination.DefaultLimit записей
+	GetCategories(ctx context.Context, filterOps *reqresp.CategoryFilter) (*pagination.Page[*models.Category], error)
+	GetShops(ctx context.Context, filterOps *reqresp.ShopFilter) (*pagination.Page[*models.Shop], error)
+	GetPosts(ctx context.Context, filterOps *reqresp.PostFilter) (*pagination.Page[*models.Post], error)
+	GetProducts(ctx context.Context, filterOps *reqresp.ProductFilter) (*pagination.Page[*models.Product], error)
 
+	// GetCategoryTree - все категории, по нему строятся дерево и пути от корня
+	GetCategoryTree(ctx context.Context) (*models.CategoryTree, error)
 	GetCategoruByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error)
 	GetShopByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error)
+
+	// Suggest - подсказки по названиям магазинов, товаров и категорий для строки поиска.
+	// На поиск отводится suggestTimeout: если не успели, возвращаются пустые списки с TimedOut.
+	Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) (reqresp.SuggestResponse, error)
 }
 
+const (
+	// MinSuggestQueryLen - по более короткому запросу подсказки не ищутся
+	MinSuggestQueryLen = 2
+	// MaxSuggestQueryLen - ограничение длины запроса подсказок в символах
+	MaxSuggestQueryLen  = 100
+	DefaultSuggestLimit = 5
+	MaxSuggestLimit     = 10
+)
+
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-	ErrShopNotFound     = errors.New("shop not found")
+	ErrCategoryNotFound = categoryrep.ErrCategoryNotFound
+	ErrShopNotFound     = shoprep.ErrShopNotFound
+	ErrInvalidFilter    = errors.New("invalid filter")
 )
 
-func NewSearcher() Searcher {
-	return &searcher{}
+func NewSearcher(
+	categoryRep categoryrep.CategoryRep,
+	shopRep shoprep.ShopRep,
+	postRep postrep.PostRep,
+	productRep productrep.ProductRep,
+	searchRep searchrep.SearchRep,
+	suggestTimeout time.Duration,
+) Searcher {
+	return &searcher{
+		categoryRep:    categoryRep,
+		shopRep:        shopRep,
+		postRep:        postRep,
+		productRep:     productRep,
+		searchRep:      searchRep,
+		suggestTimeout: suggestTimeout,
+	}
 }
 
 type searcher struct {
+	categoryRep    categoryrep.CategoryRep
+	shopRep        shoprep.ShopRep
+	postRep        postrep.PostRep
+	productRep     productrep.ProductRep
+	searchRep      searchrep.SearchRep
+	suggestTimeout time.Duration
 }
 
-func (s *searcher) GetCategories(ctx context.Context, filterOps *reqresp.CategoryFilter) ([]*models.Category, error) {
-	categoryCreator := testobj.NewCategoryMother()
-	res := make([]*models.Category, 3)
-	for i := range res {
-		res[i] = categoryCreator.CategoryP()
+func (s *searcher) GetCategories(ctx context.Context, filterOps *reqresp.CategoryFilter) (*pagination.Page[*models.Category], error) {
+	f := reqresp.CategoryFilter{}
+	if filterOps != nil {
+		f = *filterOps
 	}
-	return res, nil
+	if err := pagination.Normalize(&f.SortBy, &f.Order, &f.Page, []string{reqresp.SortByTitle}, reqresp.OrderAsc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	return fetchPage(&f.Page, f.SortBy, f.Order,
+		func() ([]*models.Category, error) { return s.categoryRep.GetAll(ctx, &f) },
+		func() (int, error) { return s.categoryRep.Count(ctx, &f) },
+	)
 }
 
-func (s *searcher) GetPosts(ctx context.Context, filterOps *reqresp.PostFilter) ([]*models.Post, error) {
-	postCreator := testobj.NewPostMother()
-	res := make([]*models.Post, 3)
-	for i := range res {
-		res[i] = postCreator.PostP()
+func (s *searcher) GetPosts(ctx context.Context, filterOps *reqresp.PostFilter) (*pagination.Page[*models.Post], error) {
+	f := reqresp.PostFilter{}
+	if filterOps != nil {
+		f = *filterOps
 	}
-	return res, nil
+	if err := pagination.Normalize(&f.SortBy, &f.Order, &f.Page, []string{reqresp.SortByTimePublication}, reqresp.OrderDesc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	// в ленте общее число не считается: COUNT прошел бы по всем постам всех магазинов подписки
+	// на каждой странице, а сама страница читает из каждого магазина не больше limit постов
+	var count func() (int, error)
+	if f.FollowerID == uuid.Nil {
+		count = func() (int, error) { return s.postRep.Count(ctx, &f) }
+	}
+	return fetchPage(&f.Page, f.SortBy, f.Order,
+		func() ([]*models.Post, error) { return s.postRep.GetAll(ctx, &f) },
+		count,
+	)
 }
 
-func (s *searcher) GetProducts(ctx context.Context, filterOps *reqresp.ProductFilter) ([]*models.Product, error) {
-	creator := testobj.NewProductMother()
-	res := make([]*models.Product, 3)
-	for i := range res {
-		res[i] = creator.ProductP()
+func (s *searcher) GetProducts(ctx context.Context, filterOps *reqresp.ProductFilter) (*pagination.Page[*models.Product], error) {
+	f := reqresp.ProductFilter{}
+	if filterOps != nil {
+		f = *filterOps
 	}
-	return res, nil
+	if f.MaxCost > 0 && f.MinCost > f.MaxCost {
+		return nil, fmt.Errorf("%w: min_cost %d is greater than max_cost %d",
+			ErrInvalidFilter, f.MinCost, f.MaxCost)
+	}
+	if err := pagination.Normalize(&f.SortBy, &f.Order, &f.Page, []string{reqresp.SortByTitle, reqresp.SortByCost}, reqresp.OrderAsc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	return fetchPage(&f.Page, f.SortBy, f.Order,
+		func() ([]*models.Product, error) { return s.productRep.GetAll(ctx, &f) },
+		func() (int, error) { return s.productRep.Count(ctx, &f) },
+	)
 }
 
-func (s *searcher) GetShops(ctx context.Context, filterOps *reqresp.ShopFilter) ([]*models.Shop, error) {
-	creator := testobj.NewShopMother()
-	res := make([]*models.Shop, 3)
-	for i := range res {
-		res[i] = creator.ShopP()
+func (s *searcher) GetShops(ctx context.Context, filterOps *reqresp.ShopFilter) (*pagination.Page[*models.Shop], error) {
+	f := reqresp.ShopFilter{}
+	if filterOps != nil {
+		f = *filterOps
 	}
-	return res, nil
+	if err := pagination.Normalize(&f.SortBy, &f.Order, &f.Page, []string{reqresp.SortByTitle}, reqresp.OrderAsc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	return fetchPage(&f.Page, f.SortBy, f.Order,
+		func() ([]*models.Shop, error) { return s.shopRep.GetAll(ctx, &f) },
+		func() (int, error) { return s.shopRep.Count(ctx, &f) },
+	)
+}
+
+func (s *searcher) GetCategoryTree(ctx context.Context) (*models.CategoryTree, error) {
+	return s.categoryRep.GetTree(ctx)
 }
 
 func (s *searcher) GetCategoruByID(ctx context.Context, categoryID uuid.UUID) (*models.Category, error) {
-	categoryCreator := testobj.NewCategoryMother()
-	return categoryCreator.CategoryP(), nil
+	return s.categoryRep.GetByID(ctx, categoryID)
 }
 
 func (s *searcher) GetShopByID(ctx context.Context, shopID uuid.UUID) (*models.Shop, error) {
-	creator := testobj.NewShopMother()
-	return creator.ShopP(), nil
+	return s.shopRep.GetByID(ctx, shopID)
+}
+
+func (s *searcher) Suggest(ctx context.Context, filterOps *reqresp.SuggestFilter) (reqresp.SuggestResponse, error) {
+	res := reqresp.SuggestResponse{
+		Shops:      make([]reqresp.SuggestionResponse, 0),
+		Products:   make([]reqresp.SuggestionResponse, 0),
+		Categories: make([]reqresp.SuggestionResponse, 0),
+	}
+	f := reqresp.SuggestFilter{}
+	if filterOps != nil {
+		f = *filterOps
+	}
+	if utf8.RuneCountInString(f.Query) > MaxSuggestQueryLen {
+		return res, fmt.Errorf("%w: query is longer than %d characters", ErrInvalidFilter, MaxSuggestQueryLen)
+	}
+	if f.Limit < 0 {
+		return res, fmt.Errorf("%w: negative limit", ErrInvalidFilter)
+	} else if f.Limit == 0 {
+		f.Limit = DefaultSuggestLimit
+	}
+	f.Limit = min(f.Limit, MaxSuggestLimit)
+	if utf8.RuneCountInString(searchrep.SuggestText(f.Query)) < MinSuggestQueryLen {
+		return res, nil
+	}
+
+	suggestCtx, cancel := context.WithTimeout(ctx, s.suggestTimeout)
+	defer cancel()
+	suggestions, err := s.searchRep.Suggest(suggestCtx, &f)
+	if err != nil {
+		// отмену запроса клиентом не выдаем за нехватку времени
+		if ctx.Err() == nil && errors.Is(suggestCtx.Err(), context.DeadlineExceeded) {
+			res.TimedOut = true
+			return res, nil
+		}
+		return res, err
+	}
+	for _, suggestion := range suggestions {
+		switch suggestion.GetKind() {
+		case models.SuggestionShop:
+			res.Shops = append(res.Shops, suggestion.ToResponse())
+		case models.SuggestionProduct:
+			res.Products = append(res.Products, suggestion.ToResponse())
+		case models.SuggestionCategory:
+			res.Categories = append(res.Categories, suggestion.ToResponse())
+		}
+	}
+	return res, nil
+}
+
+type sortable interface {
+	GetID() uuid.UUID
+	SortValue(sortBy string) any
+}
+
+// fetchPage запрашивает на одну запись больше лимита, чтобы узнать, есть ли следующая страница,
+// и строит курсор по последней выданной записи. Без count общее число не считается (Total = 0)
+func fetchPage[T sortable](
+	page *reqresp.PageOps,
+	sortBy string,
+	order string,
+	getAll func() ([]T, error),
+	count func() (int, error),
+) (*pagination.Page[T], error) {
+	limit := page.Limit
+	page.Limit = limit + 1
+	items, err := getAll()
+	page.Limit = limit
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	if count != nil {
+		total, err = count()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &pagination.Page[T]{Items: items, Total: total}
+	if len(items) > limit {
+		res.Items = items[:limit]
+		last := res.Items[limit-1]
+		cursor := pagination.NewCursor(sortBy, order == reqresp.OrderDesc, last.SortValue(sortBy), last.GetID())
+		res.NextCursor = cursor.Encode()
+	}
+	return res, nil
 }
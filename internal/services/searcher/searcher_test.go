@@ -0,0 +1,227 @@
+package searcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/CakeForKit/CraftPlace.git/internal/models/pagination"
+	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	categoryrep "github.com/CakeForKit/CraftPlace.git/internal/repository/category_rep"
+	postrep "github.com/CakeForKit/CraftPlace.git/internal/repository/post_rep"
+	productrep "github.com/CakeForKit/CraftPlace.git/internal/repository/product_rep"
+	searchrep "github.com/CakeForKit/CraftPlace.git/internal/repository/search_rep"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/searcher"
+	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/stretchr/testify/mock"
+)
+
+type SearcherSuite struct {
+	suite.Suite
+}
+
+func TestSearcher(t *testing.T) {
+	suite.RunSuite(t, new(SearcherSuite))
+}
+
+func (s *SearcherSuite) TestSearcher_GetProducts(t provider.T) {
+	productCreator := testobj.NewProductMother()
+
+	t.WithNewStep("filter is passed to repository", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		filterOps := &reqresp.ProductFilter{Title: "звезд", MinCost: 10, MaxCost: 100}
+		expected := []*models.Product{productCreator.ProductP()}
+		withFilter := mock.MatchedBy(func(f *reqresp.ProductFilter) bool {
+			return f.Title == filterOps.Title && f.MinCost == filterOps.MinCost && f.MaxCost == filterOps.MaxCost
+		})
+
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetAll", ctx, withFilter).Return(expected, nil)
+		mockProductRep.On("Count", ctx, withFilter).Return(1, nil)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), new(postrep.MockPostRep), mockProductRep,
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		res, err := serv.GetProducts(ctx, filterOps)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(expected, res.Items)
+		sCtx.Assert().Equal(1, res.Total)
+		sCtx.Assert().Empty(res.NextCursor)
+		mockProductRep.AssertExpectations(t)
+	})
+	t.WithNewStep("min cost greater than max cost", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockProductRep := new(productrep.MockProductRep)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), new(postrep.MockPostRep), mockProductRep,
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		_, err := serv.GetProducts(ctx, &reqresp.ProductFilter{MinCost: 200, MaxCost: 100})
+
+		sCtx.Require().ErrorIs(err, searcher.ErrInvalidFilter)
+		mockProductRep.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("only min cost", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		filterOps := &reqresp.ProductFilter{MinCost: 200}
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetAll", ctx, mock.Anything).Return([]*models.Product{}, nil)
+		mockProductRep.On("Count", ctx, mock.Anything).Return(0, nil)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), new(postrep.MockPostRep), mockProductRep,
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		_, err := serv.GetProducts(ctx, filterOps)
+
+		sCtx.Require().NoError(err, "max_cost = 0 means no upper bound")
+	})
+}
+
+func (s *SearcherSuite) TestSearcher_Pagination(t provider.T) {
+	productCreator := testobj.NewProductMother()
+
+	t.WithNewStep("next cursor is built from the last item of the page", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		first, second := productCreator.ProductP(), productCreator.ProductP()
+
+		mockProductRep := new(productrep.MockProductRep)
+		mockProductRep.On("GetAll", ctx, mock.MatchedBy(func(f *reqresp.ProductFilter) bool {
+			return f.Page.Limit == 2 && f.SortBy == reqresp.SortByCost
+		})).Return([]*models.Product{first, second}, nil)
+		mockProductRep.On("Count", ctx, mock.Anything).Return(5, nil)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), new(postrep.MockPostRep), mockProductRep,
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		res, err := serv.GetProducts(ctx, &reqresp.ProductFilter{
+			SortBy: reqresp.SortByCost,
+			Order:  reqresp.OrderDesc,
+			Page:   reqresp.PageOps{Limit: 1},
+		})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]*models.Product{first}, res.Items)
+		sCtx.Assert().Equal(5, res.Total)
+		cursor, err := pagination.Decode(res.NextCursor, reqresp.SortByCost, true)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(first.GetID(), cursor.ID)
+	})
+	t.WithNewStep("default limit", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockPostRep := new(postrep.MockPostRep)
+		mockPostRep.On("GetAll", ctx, mock.MatchedBy(func(f *reqresp.PostFilter) bool {
+			return f.Page.Limit == pagination.DefaultLimit+1 &&
+				f.SortBy == reqresp.SortByTimePublication && f.Order == reqresp.OrderDesc
+		})).Return([]*models.Post{}, nil)
+		mockPostRep.On("Count", ctx, mock.Anything).Return(0, nil)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), mockPostRep, new(productrep.MockProductRep),
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		res, err := serv.GetPosts(ctx, nil)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(res.Items)
+		mockPostRep.AssertExpectations(t)
+	})
+	t.WithNewStep("invalid sort and page options", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockShopRep := new(shoprep.MockShopRep)
+		serv := searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), mockShopRep, new(postrep.MockPostRep), new(productrep.MockProductRep),
+			new(searchrep.MockSearchRep), time.Second,
+		)
+
+		_, err := serv.GetShops(ctx, &reqresp.ShopFilter{SortBy: reqresp.SortByCost})
+		sCtx.Require().ErrorIs(err, searcher.ErrInvalidFilter)
+		_, err = serv.GetShops(ctx, &reqresp.ShopFilter{Order: "sideways"})
+		sCtx.Require().ErrorIs(err, searcher.ErrInvalidFilter)
+		_, err = serv.GetShops(ctx, &reqresp.ShopFilter{Page: reqresp.PageOps{Cursor: "not a cursor"}})
+		sCtx.Require().ErrorIs(err, searcher.ErrInvalidFilter)
+		mockShopRep.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *SearcherSuite) TestSearcher_Suggest(t provider.T) {
+	newSearcher := func(searchRep searchrep.SearchRep, timeout time.Duration) searcher.Searcher {
+		return searcher.NewSearcher(
+			new(categoryrep.MockCategoryRep), new(shoprep.MockShopRep), new(postrep.MockPostRep), new(productrep.MockProductRep),
+			searchRep, timeout,
+		)
+	}
+
+	t.WithNewStep("suggestions are grouped by kind", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		shop, err := models.NewSuggestion(models.SuggestionShop, uuid.New(), "Звёздная лавка", 1.5)
+		sCtx.Require().NoError(err)
+		product, err := models.NewSuggestion(models.SuggestionProduct, uuid.New(), "Серёжки со звездой", 0.9)
+		sCtx.Require().NoError(err)
+		mockSearchRep := new(searchrep.MockSearchRep)
+		mockSearchRep.On("Suggest", mock.Anything, mock.MatchedBy(func(f *reqresp.SuggestFilter) bool {
+			return f.Query == "звезд" && f.Limit == searcher.DefaultSuggestLimit
+		})).Return([]*models.Suggestion{shop, product}, nil)
+
+		res, err := newSearcher(mockSearchRep, time.Second).Suggest(ctx, &reqresp.SuggestFilter{Query: "звезд"})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]reqresp.SuggestionResponse{shop.ToResponse()}, res.Shops)
+		sCtx.Assert().Equal([]reqresp.SuggestionResponse{product.ToResponse()}, res.Products)
+		sCtx.Assert().Empty(res.Categories)
+		sCtx.Assert().False(res.TimedOut)
+		mockSearchRep.AssertExpectations(t)
+	})
+	t.WithNewStep("limit is capped", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockSearchRep := new(searchrep.MockSearchRep)
+		mockSearchRep.On("Suggest", mock.Anything, mock.MatchedBy(func(f *reqresp.SuggestFilter) bool {
+			return f.Limit == searcher.MaxSuggestLimit
+		})).Return([]*models.Suggestion{}, nil)
+
+		_, err := newSearcher(mockSearchRep, time.Second).Suggest(ctx, &reqresp.SuggestFilter{Query: "шарф", Limit: 100})
+
+		sCtx.Require().NoError(err)
+		mockSearchRep.AssertExpectations(t)
+	})
+	t.WithNewStep("too short query", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockSearchRep := new(searchrep.MockSearchRep)
+
+		res, err := newSearcher(mockSearchRep, time.Second).Suggest(ctx, &reqresp.SuggestFilter{Query: " ш "})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Empty(res.Shops)
+		mockSearchRep.AssertNotCalled(t, "Suggest", mock.Anything, mock.Anything)
+	})
+	t.WithNewStep("negative limit", func(sCtx provider.StepCtx) {
+		_, err := newSearcher(new(searchrep.MockSearchRep), time.Second).
+			Suggest(context.Background(), &reqresp.SuggestFilter{Query: "шарф", Limit: -1})
+
+		sCtx.Require().ErrorIs(err, searcher.ErrInvalidFilter)
+	})
+	t.WithNewStep("latency budget is exceeded", func(sCtx provider.StepCtx) {
+		ctx := context.Background()
+		mockSearchRep := new(searchrep.MockSearchRep)
+		mockSearchRep.On("Suggest", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(nil, context.DeadlineExceeded)
+
+		res, err := newSearcher(mockSearchRep, 10*time.Millisecond).Suggest(ctx, &reqresp.SuggestFilter{Query: "шарф"})
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(res.TimedOut)
+		sCtx.Assert().Empty(res.Products)
+	})
+}
@@ -3,14 +3,21 @@ package shopservice
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
 	reqresp "github.com/CakeForKit/CraftPlace.git/internal/models/req_resp"
+	shoprep "github.com/CakeForKit/CraftPlace.git/internal/repository/shop_rep"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
+	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
+	ownerpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/owner_policy"
+	mediaservice "github.com/CakeForKit/CraftPlace.git/internal/services/media_service"
 	"github.com/google/uuid"
 )
 
 type ShopServ interface {
-	// UserID в контексте
+	// UserID в контексте, Add доступен только пользователю с подтвержденной почтой,
+	// Delete и Update доступны только владельцу магазина
 	Add(ctx context.Context, addReq reqresp.AddShopRequest) (*models.Shop, error)
 	Delete(ctx context.Context, shopID uuid.UUID) error
 	Update(ctx context.Context, updateReq reqresp.UpdateShopRequest) (*models.Shop, error)
@@ -18,5 +25,111 @@ type ShopServ interface {
 
 var (
 	ErrShopServ     = errors.New("ShopServ")
-	ErrShopNotFound = errors.New("shop not found")
+	ErrShopNotFound = shoprep.ErrShopNotFound
+	// ErrInvalidShop - данные магазина не прошли проверку модели, например контакт повторяется
+	ErrInvalidShop    = models.ErrShopValidate
+	ErrInvalidContact = models.ErrContactLinkValidate
+	// ErrEmailNotVerified - магазин может открыть только пользователь с подтвержденной почтой
+	ErrEmailNotVerified = errors.New("email is not verified")
 )
+
+func NewShopServ(
+	authz auth.AuthZ, shopRep shoprep.ShopRep, policy ownerpolicy.OwnerPolicy, userRep userrep.UserRep,
+	images mediaservice.ImageCleaner,
+) ShopServ {
+	return &shopServ{
+		authz:   authz,
+		shopRep: shopRep,
+		policy:  policy,
+		userRep: userRep,
+		images:  images,
+	}
+}
+
+type shopServ struct {
+	authz   auth.AuthZ
+	shopRep shoprep.ShopRep
+	policy  ownerpolicy.OwnerPolicy
+	userRep userrep.UserRep
+	images  mediaservice.ImageCleaner
+}
+
+func (s *shopServ) Add(ctx context.Context, addReq reqresp.AddShopRequest) (*models.Shop, error) {
+	userID, err := s.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	user, err := s.userRep.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	if !user.IsEmailVerified() {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, ErrEmailNotVerified)
+	}
+	contacts, err := contactLinks(addReq.Contacts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	shop, err := models.NewShop(uuid.New(), addReq.Title, addReq.Description, userID, contacts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	if err := s.shopRep.Add(ctx, shop); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	return shop, nil
+}
+
+func (s *shopServ) Delete(ctx context.Context, shopID uuid.UUID) error {
+	if _, err := s.policy.AuthorizeShop(ctx, shopID, auth.ActionDelete); err != nil {
+		return fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	// товары и посты магазина удаляются вместе с ним, а с ними - их изображения
+	images, err := s.images.ShopImages(ctx, shopID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	if err := s.shopRep.Delete(ctx, shopID); err != nil {
+		return fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	s.images.RemoveFiles(ctx, images)
+	return nil
+}
+
+func (s *shopServ) Update(ctx context.Context, updateReq reqresp.UpdateShopRequest) (*models.Shop, error) {
+	shopID, err := uuid.Parse(updateReq.ShopID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	shop, err := s.policy.AuthorizeShop(ctx, shopID, auth.ActionUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	contacts := shop.GetContacts()
+	if updateReq.Contacts != nil {
+		contacts, err = contactLinks(updateReq.Contacts)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+		}
+	}
+	updated, err := models.NewShop(shop.GetID(), updateReq.Title, updateReq.Description, shop.GetUserID(), contacts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	if err := s.shopRep.Update(ctx, updated); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShopServ, err)
+	}
+	return updated, nil
+}
+
+func contactLinks(reqs []reqresp.ContactLinkRequest) ([]*models.ContactLink, error) {
+	res := make([]*models.ContactLink, 0, len(reqs))
+	for _, req := range reqs {
+		contact, err := models.NewContactLink(models.ContactType(req.Type), req.Value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, contact)
+	}
+	return res, nil
+}
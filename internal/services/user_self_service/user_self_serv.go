@@ -6,8 +6,12 @@ import (
 	"fmt"
 
 	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	userrep "github.com/CakeForKit/CraftPlace.git/internal/repository/user_rep"
 	auth "github.com/CakeForKit/CraftPlace.git/internal/services/auth/authZ"
-	testobj "github.com/CakeForKit/CraftPlace.git/internal/tests/test_obj"
+	authuser "github.com/CakeForKit/CraftPlace.git/internal/services/auth/auth_user"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/auth/hasher"
+	passwordpolicy "github.com/CakeForKit/CraftPlace.git/internal/services/auth/password_policy"
+	"github.com/CakeForKit/CraftPlace.git/internal/services/notifier"
 	"github.com/google/uuid"
 )
 
@@ -15,42 +19,125 @@ type UserSelfServ interface {
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	ChangeLogin(ctx context.Context, newLogin string) error
 	ChangePassword(ctx context.Context, newPassword string) error
+	// ChangeEmail задает новую почту, она считается неподтвержденной до перехода по ссылке из письма
+	ChangeEmail(ctx context.Context, newEmail string) error
 }
 
 var (
 	ErrUserSelfServ = errors.New("UserSelfServ")
 )
 
-func NewUserSelfServ(authz auth.AuthZ) UserSelfServ {
+func NewUserSelfServ(
+	authz auth.AuthZ,
+	authu authuser.AuthUser,
+	urep userrep.UserRep,
+	hasher hasher.Hasher,
+	policy passwordpolicy.PasswordPolicy,
+	notifier notifier.Notifier,
+) UserSelfServ {
 	return &userSelfServ{
-		authz: authz,
+		authz:    authz,
+		authu:    authu,
+		userrep:  urep,
+		hasher:   hasher,
+		policy:   policy,
+		notifier: notifier,
 	}
 }
 
 type userSelfServ struct {
-	authz auth.AuthZ
+	authz    auth.AuthZ
+	authu    authuser.AuthUser
+	userrep  userrep.UserRep
+	hasher   hasher.Hasher
+	policy   passwordpolicy.PasswordPolicy
+	notifier notifier.Notifier
 }
 
 func (s *userSelfServ) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
-	creator := testobj.NewUserMother()
-	return creator.DefaultUserP(userID), nil
+	return s.userrep.GetByID(ctx, userID)
 }
 
 func (s *userSelfServ) ChangeLogin(ctx context.Context, newLogin string) error {
-	userID, err := s.authz.UserIDFromContext(ctx)
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	updated, err := models.NewUser(
+		user.GetID(), user.GetUsername(), newLogin, user.GetHashedPassword(), user.GetRole(),
+		user.GetEmail(), user.IsEmailVerified(),
+	)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
 	}
-	_ = userID
-	// TODO UserRep.Update
+	if err := s.userrep.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
 	return nil
 }
+
 func (s *userSelfServ) ChangePassword(ctx context.Context, newPassword string) error {
-	userID, err := s.authz.UserIDFromContext(ctx)
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.Validate(newPassword); err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	hashedPassword, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	updated, err := models.NewUser(
+		user.GetID(), user.GetUsername(), user.GetLogin(), hashedPassword, user.GetRole(),
+		user.GetEmail(), user.IsEmailVerified(),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	if err := s.userrep.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	// старый пароль мог утечь: все выданные по нему сессии завершаются
+	if err := s.authu.LogoutAll(ctx, user.GetID()); err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	s.notifier.PasswordChanged(ctx, &updated)
+	return nil
+}
+
+func (s *userSelfServ) ChangeEmail(ctx context.Context, newEmail string) error {
+	user, err := s.currentUser(ctx)
 	if err != nil {
+		return err
+	}
+	if models.NormalizeEmail(newEmail) == user.GetEmail() {
+		return nil
+	}
+	updated, err := models.NewUser(
+		user.GetID(), user.GetUsername(), user.GetLogin(), user.GetHashedPassword(), user.GetRole(),
+		newEmail, false,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	if err := s.userrep.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	if err := s.authu.RequestEmailVerification(ctx, user.GetID()); err != nil {
 		return fmt.Errorf("%w: %w", ErrUserSelfServ, err)
 	}
-	_ = userID
-	// TODO UserRep.Update
 	return nil
 }
+
+func (s *userSelfServ) currentUser(ctx context.Context) (*models.User, error) {
+	userID, err := s.authz.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	user, err := s.userrep.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUserSelfServ, err)
+	}
+	return user, nil
+}
@@ -0,0 +1,34 @@
+package testobj
+
+import (
+	"time"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+)
+
+type AppConfigMother interface {
+	Default() cnfg.AppConfig
+}
+
+func NewAppConfigMother() AppConfigMother {
+	return &appConfigMother{}
+}
+
+type appConfigMother struct{}
+
+func (am *appConfigMother) Default() cnfg.AppConfig {
+	return cnfg.AppConfig{
+		Port:                 8080,
+		TokenType:            cnfg.TokenJWT,
+		TokenSymmetricKey:    "12345678901234567890123456789012",
+		AccessTokenDuration:  15 * time.Minute,
+		RefreshTokenDuration: 30 * 24 * time.Hour,
+		StorageType:          cnfg.StorageMemory,
+		SuggestTimeout:       150 * time.Millisecond,
+
+		EmailVerifyTokenDuration:   48 * time.Hour,
+		PasswordResetTokenDuration: time.Hour,
+		MFAIssuer:                  "CraftPlace",
+		MFATokenDuration:           5 * time.Minute,
+	}
+}
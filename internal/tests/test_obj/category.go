@@ -7,6 +7,7 @@ import (
 
 type CategoryMother interface {
 	CategoryP() *models.Category
+	CategoryOfParentP(parentID uuid.UUID) *models.Category
 }
 
 func NewCategoryMother() CategoryMother {
@@ -16,10 +17,15 @@ func NewCategoryMother() CategoryMother {
 type categoryMother struct{}
 
 func (um *categoryMother) CategoryP() *models.Category {
+	return um.CategoryOfParentP(uuid.Nil)
+}
+
+func (um *categoryMother) CategoryOfParentP(parentID uuid.UUID) *models.Category {
 	category, _ := models.NewCategory(
 		uuid.New(),
 		"test-title"+uuid.New().String(),
 		"test-desription",
+		parentID,
 	)
 	return category
 }
@@ -0,0 +1,64 @@
+package testobj
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/CakeForKit/CraftPlace.git/internal/models/models"
+	"github.com/google/uuid"
+)
+
+type ImageMother interface {
+	ImageOfProductP(productID uuid.UUID) *models.Image
+	// PNG и JPEG - содержимое файлов-изображений заданного размера для загрузки
+	PNG(width, height int) []byte
+	JPEG(width, height int) []byte
+}
+
+func NewImageMother() ImageMother {
+	return &imageMother{}
+}
+
+type imageMother struct{}
+
+func (m *imageMother) ImageOfProductP(productID uuid.UUID) *models.Image {
+	id := uuid.New()
+	img, _ := models.NewImage(
+		id,
+		models.ImageOwnerProduct,
+		productID,
+		0,
+		fmt.Sprintf("products/%s/%s.png", productID, id),
+		fmt.Sprintf("products/%s/%s_thumb.png", productID, id),
+		"image/png",
+		640,
+		480,
+	)
+	return img
+}
+
+func (m *imageMother) PNG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, gradient(width, height))
+	return buf.Bytes()
+}
+
+func (m *imageMother) JPEG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = jpeg.Encode(&buf, gradient(width, height), nil)
+	return buf.Bytes()
+}
+
+func gradient(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
@@ -0,0 +1,35 @@
+package testobj
+
+import "github.com/CakeForKit/CraftPlace.git/internal/cnfg"
+
+type PasswordConfigMother interface {
+	Default() cnfg.PasswordConfig
+	Bcrypt() cnfg.PasswordConfig
+}
+
+func NewPasswordConfigMother() PasswordConfigMother {
+	return &passwordConfigMother{}
+}
+
+type passwordConfigMother struct{}
+
+// Default - Argon2id с минимальными параметрами, чтобы тесты не тратили время на хеширование
+func (pm *passwordConfigMother) Default() cnfg.PasswordConfig {
+	return cnfg.PasswordConfig{
+		HashAlgorithm: cnfg.HashArgon2id,
+		Argon2Memory:  1024,
+		Argon2Time:    1,
+		Argon2Threads: 1,
+		BcryptCost:    4,
+		MinLength:     8,
+		MaxLength:     128,
+		MinClasses:    2,
+	}
+}
+
+func (pm *passwordConfigMother) Bcrypt() cnfg.PasswordConfig {
+	config := pm.Default()
+	config.HashAlgorithm = cnfg.HashBcrypt
+	config.MaxLength = 72
+	return config
+}
@@ -9,6 +9,7 @@ import (
 
 type PayloadMother interface {
 	UserPayload(userID uuid.UUID) tokenmaker.Payload
+	RolePayload(userID uuid.UUID, role tokenmaker.RoleAuth) tokenmaker.Payload
 }
 
 func NewPayloadMother() PayloadMother {
@@ -18,9 +19,17 @@ func NewPayloadMother() PayloadMother {
 type payloadMother struct{}
 
 func (pm *payloadMother) UserPayload(userID uuid.UUID) tokenmaker.Payload {
+	return pm.RolePayload(userID, tokenmaker.UserRole)
+}
+
+func (pm *payloadMother) RolePayload(userID uuid.UUID, role tokenmaker.RoleAuth) tokenmaker.Payload {
+	now := time.Now()
 	return tokenmaker.Payload{
+		ID:        uuid.New(),
 		PersonID:  userID,
-		Role:      tokenmaker.UserRole,
-		ExpiredAt: time.Now().Add(time.Hour),
+		Role:      role,
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiredAt: now.Add(time.Hour),
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 type ProductMother interface {
 	ProductP() *models.Product
+	ProductOfShopP(shopID uuid.UUID) *models.Product
 }
 
 func NewProductMother() ProductMother {
@@ -26,3 +27,15 @@ func (um *productMother) ProductP() *models.Product {
 	)
 	return product
 }
+
+func (um *productMother) ProductOfShopP(shopID uuid.UUID) *models.Product {
+	product, _ := models.NewProduct(
+		uuid.New(),
+		"test-title"+uuid.New().String(),
+		"test-desription",
+		1000,
+		shopID,
+		uuid.UUIDs{uuid.New()},
+	)
+	return product
+}
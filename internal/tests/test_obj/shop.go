@@ -7,6 +7,7 @@ import (
 
 type ShopMother interface {
 	ShopP() *models.Shop
+	ShopOfUserP(userID uuid.UUID) *models.Shop
 }
 
 func NewShopMother() ShopMother {
@@ -21,6 +22,18 @@ func (um *shopMother) ShopP() *models.Shop {
 		"test-title"+uuid.New().String(),
 		"test-desription",
 		uuid.New(),
+		nil,
+	)
+	return shop
+}
+
+func (um *shopMother) ShopOfUserP(userID uuid.UUID) *models.Shop {
+	shop, _ := models.NewShop(
+		uuid.New(),
+		"test-title"+uuid.New().String(),
+		"test-desription",
+		userID,
+		nil,
 	)
 	return shop
 }
@@ -10,6 +10,8 @@ type UserMother interface {
 	UserWithPswdHash(userID uuid.UUID, hashedPassword string) models.User
 	DefaultUserP(userID uuid.UUID) *models.User
 	UserWithLoginP(userID uuid.UUID, login string) *models.User
+	UserWithRoleP(userID uuid.UUID, role models.Role) *models.User
+	UserWithEmailP(userID uuid.UUID, email string, verified bool) *models.User
 }
 
 func NewUserMother() UserMother {
@@ -24,6 +26,9 @@ func (um *userMother) UserWithPswdHash(userID uuid.UUID, hashedPassword string)
 		"test-user",
 		"test-login"+uuid.New().String(),
 		hashedPassword,
+		models.RoleUser,
+		"",
+		false,
 	)
 	return user
 }
@@ -34,6 +39,9 @@ func (um *userMother) DefaultUserP(userID uuid.UUID) *models.User {
 		"test-user",
 		"test-login"+uuid.New().String(),
 		"hashed-password",
+		models.RoleUser,
+		"",
+		false,
 	)
 	return &user
 }
@@ -44,6 +52,35 @@ func (um *userMother) UserWithLoginP(userID uuid.UUID, login string) *models.Use
 		"test-user",
 		login,
 		"hashed-password",
+		models.RoleUser,
+		"",
+		false,
+	)
+	return &user
+}
+
+func (um *userMother) UserWithRoleP(userID uuid.UUID, role models.Role) *models.User {
+	user, _ := models.NewUser(
+		userID,
+		"test-user",
+		"test-login"+uuid.New().String(),
+		"hashed-password",
+		role,
+		"",
+		false,
+	)
+	return &user
+}
+
+func (um *userMother) UserWithEmailP(userID uuid.UUID, email string, verified bool) *models.User {
+	user, _ := models.NewUser(
+		userID,
+		"test-user",
+		"test-login"+uuid.New().String(),
+		"hashed-password",
+		models.RoleUser,
+		email,
+		verified,
 	)
 	return &user
 }
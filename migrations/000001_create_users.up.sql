@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS users (
+    id              UUID PRIMARY KEY,
+    username        VARCHAR(50) NOT NULL DEFAULT '',
+    login           VARCHAR(50) NOT NULL,
+    hashed_password TEXT        NOT NULL,
+    CONSTRAINT users_login_unique UNIQUE (login)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS shops (
+    id          UUID PRIMARY KEY,
+    title       VARCHAR(50)  NOT NULL CHECK (title <> ''),
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    user_id     UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS shops_user_id_idx ON shops (user_id);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id          UUID PRIMARY KEY,
+    title       VARCHAR(50)  NOT NULL CHECK (title <> ''),
+    description VARCHAR(200) NOT NULL DEFAULT ''
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS products (
+    id          UUID PRIMARY KEY,
+    title       VARCHAR(50)  NOT NULL CHECK (title <> ''),
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    cost        BIGINT       NOT NULL CHECK (cost >= 0),
+    shop_id     UUID         NOT NULL REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS products_shop_id_idx ON products (shop_id);
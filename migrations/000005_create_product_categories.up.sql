@@ -0,0 +1,8 @@
+-- связь товар <-> категория (многие ко многим)
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id  UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories (id) ON DELETE RESTRICT,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_categories_category_id_idx ON product_categories (category_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS posts (
+    id               UUID PRIMARY KEY,
+    description      VARCHAR(200) NOT NULL DEFAULT '',
+    time_publication TIMESTAMPTZ  NOT NULL,
+    shop_id          UUID         NOT NULL REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS posts_shop_id_time_publication_idx ON posts (shop_id, time_publication DESC);
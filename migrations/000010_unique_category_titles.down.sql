@@ -0,0 +1 @@
+DROP INDEX IF EXISTS categories_title_key;
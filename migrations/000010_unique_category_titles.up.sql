@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS categories_title_key ON categories (lower(title));
@@ -0,0 +1,6 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id UUID
+        CONSTRAINT categories_parent_id_fkey REFERENCES categories (id) ON DELETE RESTRICT
+        CONSTRAINT categories_parent_check CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_images;
+DROP TABLE IF EXISTS product_images;
@@ -0,0 +1,25 @@
+-- позиции в списке уникальны, но проверяются в конце транзакции: перестановка и сдвиг
+-- после удаления временно дают повторы
+CREATE TABLE IF NOT EXISTS product_images (
+    id            UUID PRIMARY KEY,
+    product_id    UUID         NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    position      INTEGER      NOT NULL CHECK (position >= 0),
+    storage_key   VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    content_type  VARCHAR(50)  NOT NULL,
+    width         INTEGER      NOT NULL CHECK (width > 0),
+    height        INTEGER      NOT NULL CHECK (height > 0),
+    CONSTRAINT product_images_position_key UNIQUE (product_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE TABLE IF NOT EXISTS post_images (
+    id            UUID PRIMARY KEY,
+    post_id       UUID         NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    position      INTEGER      NOT NULL CHECK (position >= 0),
+    storage_key   VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    content_type  VARCHAR(50)  NOT NULL,
+    width         INTEGER      NOT NULL CHECK (width > 0),
+    height        INTEGER      NOT NULL CHECK (height > 0),
+    CONSTRAINT post_images_position_key UNIQUE (post_id, position) DEFERRABLE INITIALLY DEFERRED
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS posts_search_vector_idx;
+DROP INDEX IF EXISTS shops_search_vector_idx;
+DROP INDEX IF EXISTS products_search_vector_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE shops DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,17 @@
+-- полнотекстовый поиск с русским словарем; ё заменяется на е и в текстах, и в запросах
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', translate(title, 'ёЁ', 'еЕ')), 'A') ||
+    setweight(to_tsvector('russian', translate(description, 'ёЁ', 'еЕ')), 'B')
+) STORED;
+
+ALTER TABLE shops ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', translate(title, 'ёЁ', 'еЕ')), 'A')
+) STORED;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', translate(description, 'ёЁ', 'еЕ')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx ON products USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS shops_search_vector_idx ON shops USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS posts_search_vector_idx ON posts USING GIN (search_vector);
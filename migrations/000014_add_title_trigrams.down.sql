@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS categories_title_trgm_idx;
+DROP INDEX IF EXISTS products_title_trgm_idx;
+DROP INDEX IF EXISTS shops_title_trgm_idx;
+
+-- расширение не удаляется: им могут пользоваться и другие объекты БД
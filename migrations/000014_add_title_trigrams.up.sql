@@ -0,0 +1,10 @@
+-- подсказки в строке поиска: подстрока и похожесть по триграммам на названия,
+-- приведенные к нижнему регистру, с ё, замененной на е (как в searchrep.SuggestText)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS shops_title_trgm_idx
+    ON shops USING GIN (lower(translate(title, 'ёЁ', 'еЕ')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS products_title_trgm_idx
+    ON products USING GIN (lower(translate(title, 'ёЁ', 'еЕ')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS categories_title_trgm_idx
+    ON categories USING GIN (lower(translate(title, 'ёЁ', 'еЕ')) gin_trgm_ops);
@@ -0,0 +1,5 @@
+-- контакты магазина: [{"type": "telegram", "value": "craft_star"}, ...] в порядке, заданном мастером;
+-- значения проверяет и приводит к каноническому виду models.NewContactLink
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS contacts JSONB NOT NULL DEFAULT '[]'::jsonb
+        CONSTRAINT shops_contacts_array CHECK (jsonb_typeof(contacts) = 'array');
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS posts_shop_id_time_publication_idx ON posts (shop_id, time_publication DESC);
+DROP INDEX IF EXISTS posts_shop_id_time_publication_id_idx;
+
+DROP TABLE IF EXISTS shop_follows;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS shop_follows (
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    shop_id    UUID        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, shop_id),
+    CONSTRAINT shop_follows_shop_id_fkey FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+-- число подписчиков магазина
+CREATE INDEX IF NOT EXISTS shop_follows_shop_id_idx ON shop_follows (shop_id);
+
+-- лента берет последние посты каждого магазина подписки по ключу страницы (time_publication, id)
+-- прямо из индекса, поэтому в нем есть id
+CREATE INDEX IF NOT EXISTS posts_shop_id_time_publication_id_idx ON posts (shop_id, time_publication DESC, id DESC);
+DROP INDEX IF EXISTS posts_shop_id_time_publication_idx;
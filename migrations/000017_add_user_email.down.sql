@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_tokens;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_email_unique,
+    DROP COLUMN IF EXISTS email_verified,
+    DROP COLUMN IF EXISTS email;
@@ -0,0 +1,22 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email          VARCHAR(254),
+    ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT false;
+
+-- адреса хранятся в нижнем регистре, у пользователей без почты - NULL
+ALTER TABLE users
+    ADD CONSTRAINT users_email_unique UNIQUE (email);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    purpose    VARCHAR(20)  NOT NULL
+        CONSTRAINT user_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password')),
+    token_hash TEXT         NOT NULL,
+    email      VARCHAR(254) NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    used_at    TIMESTAMPTZ,
+    CONSTRAINT user_tokens_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS user_tokens_user_id_purpose_idx ON user_tokens (user_id, purpose);
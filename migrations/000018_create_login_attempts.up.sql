@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    scope         VARCHAR(10)  NOT NULL
+        CONSTRAINT login_attempts_scope_check CHECK (scope IN ('login', 'ip')),
+    subject       VARCHAR(255) NOT NULL,
+    failures      INTEGER      NOT NULL,
+    last_failure  TIMESTAMPTZ  NOT NULL,
+    blocked_until TIMESTAMPTZ,
+    PRIMARY KEY (scope, subject)
+);
+
+-- журнал блокировок входа, записи не удаляются
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id           UUID PRIMARY KEY,
+    scope        VARCHAR(10)  NOT NULL
+        CONSTRAINT login_lockouts_scope_check CHECK (scope IN ('login', 'ip')),
+    subject      VARCHAR(255) NOT NULL,
+    failures     INTEGER      NOT NULL,
+    locked_until TIMESTAMPTZ  NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_lockouts_created_at_idx ON login_lockouts (created_at DESC);
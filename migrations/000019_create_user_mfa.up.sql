@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id    UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret     VARCHAR(64) NOT NULL,
+    enabled    BOOLEAN     NOT NULL DEFAULT false,
+    last_step  BIGINT      NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- хранятся только SHA-256 хеши кодов, использованный код удаляется
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    user_id   UUID NOT NULL REFERENCES user_mfa (user_id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    PRIMARY KEY (user_id, code_hash)
+);
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS - SQL-миграции в формате golang-migrate ({version}_{title}.{up|down}.sql)
+//
+//go:embed *.sql
+var FS embed.FS
@@ -3,5 +3,5 @@
 ALLURE_LAUNCH_START=$(date +%s000) \
 ALLURE_LAUNCH_END=$(date +%s000) \
 ALLURE_LAUNCH_NAME="unit-test-$(shell date +%Y%m%d-%H%M%S)" \
-go test -shuffle=on github.com/CakeForKit/CraftPlace.git/internal/services/...;
+go test -shuffle=on github.com/CakeForKit/CraftPlace.git/internal/services/... github.com/CakeForKit/CraftPlace.git/internal/repository/... github.com/CakeForKit/CraftPlace.git/internal/api/...;
 exit 0